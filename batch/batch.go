@@ -0,0 +1,395 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+// Request is one panel of a batch. Its keys are the command line flag names
+// (query, elementType, elementId, startTime, endTime, responseType, ...) plus
+// name, the key of the panel in the combined output, which defaults to query.
+type Request map[string]string
+
+// Result is the outcome of one panel in the combined output.
+type Result struct {
+	Query       string      `json:"query"`
+	ElementType string      `json:"elementType"`
+	Data        interface{} `json:"data,omitempty"`
+	Error       string      `json:"error,omitempty"`
+	// ErrorType is the type of Error, see comman_function.ErrorTypeOf.
+	ErrorType string `json:"errorType,omitempty"`
+	// Identities are the identifiers the panel used and where they came from.
+	Identities []comman_function.Identity `json:"identities,omitempty"`
+	// Retries counts the aws calls of the panel that were retried.
+	Retries int64 `json:"retries,omitempty"`
+	// Metadata describes the data of the panel.
+	Metadata *comman_function.ResultMetadata `json:"metadata,omitempty"`
+	// Panel is the result the panel returned, before it was rendered into Data.
+	Panel *comman_function.PanelResult `json:"-"`
+
+	err error
+}
+
+// FailedError reports the panels of a batch that failed, in request order. It
+// unwraps to the error of the first of them, so the batch exits with the code
+// of its error type.
+type FailedError struct {
+	Panels []string
+	Err    error
+}
+
+func (e *FailedError) Error() string {
+	if len(e.Panels) == 1 {
+		return fmt.Sprintf("panel %s failed: %v", e.Panels[0], e.Err)
+	}
+	return fmt.Sprintf("panels %s failed, %s with: %v", strings.Join(e.Panels, ", "), e.Panels[0], e.Err)
+}
+
+func (e *FailedError) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads a batch document, either json or yaml. The document is a list of
+// requests, or a mapping with the list under "panels" and values shared by every
+// panel under "defaults":
+//
+//	defaults:
+//	  elementType: EC2
+//	  elementId: 1234
+//	panels:
+//	  - query: cpu_utilization_panel
+//	  - query: network_utilization_panel
+//	    name: network
+//	    responseType: frame
+func Parse(data []byte) ([]Request, error) {
+	var doc interface{}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("error parsing batch json: %w", err)
+		}
+	} else {
+		var err error
+		doc, err = comman_function.DecodeYaml(data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing batch yaml: %w", err)
+		}
+	}
+
+	defaults := Request{}
+	var panels []interface{}
+	switch v := doc.(type) {
+	case []interface{}:
+		panels = v
+	case map[string]interface{}:
+		var err error
+		if defaults, err = toRequest(v["defaults"]); err != nil {
+			return nil, fmt.Errorf("defaults: %w", err)
+		}
+		list, ok := v["panels"].([]interface{})
+		if !ok {
+			return nil, errors.New("batch document has no panels list")
+		}
+		panels = list
+	default:
+		return nil, errors.New("batch document must be a list of panels or a mapping with a panels list")
+	}
+
+	requests := make([]Request, 0, len(panels))
+	names := map[string]bool{}
+	for i, item := range panels {
+		panel, err := toRequest(item)
+		if err != nil {
+			return nil, fmt.Errorf("panel %d: %w", i+1, err)
+		}
+		request := Request{}
+		for k, v := range defaults {
+			request[k] = v
+		}
+		for k, v := range panel {
+			request[k] = v
+		}
+		if request["query"] == "" || request["elementType"] == "" {
+			return nil, fmt.Errorf("panel %d: query and elementType are required", i+1)
+		}
+		if request["name"] == "" {
+			request["name"] = request["query"]
+		}
+		if names[request["name"]] {
+			return nil, fmt.Errorf("panel %d: duplicate name %q, set a unique name", i+1, request["name"])
+		}
+		names[request["name"]] = true
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+func toRequest(value interface{}) (Request, error) {
+	request := Request{}
+	if value == nil {
+		return request, nil
+	}
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("expected a mapping")
+	}
+	for key, field := range fields {
+		switch v := field.(type) {
+		case nil:
+		case string:
+			request[key] = v
+		case json.Number:
+			request[key] = v.String()
+		case bool:
+			request[key] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("%s must be a scalar value", key)
+		}
+	}
+	return request, nil
+}
+
+type job struct {
+	req    *comman_function.PanelRequest
+	result *Result
+}
+
+// Run executes the requests with at most workers panels in flight and returns
+// the results keyed by panel name. Every distinct CMDB element is resolved once
+// and shared by the panels that reference it.
+func Run(requests []Request, clientAuth *model.Auth, workers int) map[string]*Result {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make(map[string]*Result, len(requests))
+	var jobs []*job
+	for _, request := range requests {
+		result := &Result{Query: request["query"], ElementType: request["elementType"]}
+		results[request["name"]] = result
+
+		if _, err := comman_function.LookupPanel(result.ElementType, result.Query); err != nil {
+			result.fail(err)
+			continue
+		}
+		req, err := comman_function.BuildPanelRequest(func(name string) string {
+			return request[name]
+		}, clientAuth)
+		if err != nil {
+			result.fail(err)
+			continue
+		}
+		jobs = append(jobs, &job{req: req, result: result})
+	}
+
+	resolveCloudElements(jobs)
+
+	queue := make(chan *job)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				runJob(j)
+			}
+		}()
+	}
+	for _, j := range jobs {
+		if j.result.Error == "" {
+			queue <- j
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return results
+}
+
+// resolveCloudElements looks up every distinct cmdbApiUrl/elementId pair once.
+// Panels of an element that cannot be resolved fail with the CMDB error,
+// unless they name their instance or log group themselves.
+func resolveCloudElements(jobs []*job) {
+	type elementKey struct{ cmdbApiUrl, elementId string }
+	type resolved struct {
+		element *model.CloudElement
+		err     error
+	}
+
+	elements := map[elementKey]*resolved{}
+	for _, j := range jobs {
+		if j.req.ElementId == "" {
+			continue
+		}
+		key := elementKey{cmdbApiUrl: j.req.CmdbApiUrl, elementId: j.req.ElementId}
+		r, ok := elements[key]
+		if !ok {
+			element, err := comman_function.GetCloudElement(j.req)
+			r = &resolved{element: element, err: err}
+			elements[key] = r
+		}
+		if r.err != nil {
+			if j.req.InstanceId == "" && j.req.LogGroupName == "" {
+				j.result.fail(r.err)
+			}
+			continue
+		}
+		j.req.CloudElement = r.element
+	}
+}
+
+// Failed returns a FailedError naming the panels of results that failed, nil
+// when none did.
+func Failed(requests []Request, results map[string]*Result) error {
+	var failed *FailedError
+	for _, request := range requests {
+		result, ok := results[request["name"]]
+		if !ok || result.err == nil {
+			continue
+		}
+		if failed == nil {
+			failed = &FailedError{Err: result.err}
+		}
+		failed.Panels = append(failed.Panels, request["name"])
+	}
+	if failed == nil {
+		return nil
+	}
+	return failed
+}
+
+// fail records err as the outcome of the panel.
+func (r *Result) fail(err error) {
+	r.err = err
+	r.Error = err.Error()
+	r.ErrorType = comman_function.ErrorTypeOf(err)
+}
+
+func runJob(j *job) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			j.result.fail(fmt.Errorf("panel %s panicked: %v", j.result.Query, rec))
+		}
+	}()
+
+	log.Printf("running panel %s for element type %s", j.result.Query, j.result.ElementType)
+	result, err := comman_function.ExecutePanel(j.result.ElementType, j.result.Query, j.req)
+	if err != nil {
+		j.result.fail(err)
+		return
+	}
+	if result == nil {
+		j.result.fail(&comman_function.NoDataError{Panel: j.result.Query})
+		return
+	}
+	j.result.Panel = result
+	j.result.Identities = result.Identities
+	j.result.Retries = result.Retries
+	j.result.Metadata = result.Metadata
+	if j.req.ResponseType == comman_function.ResponseTypeFrame {
+		j.result.Data = result.Frame
+		return
+	}
+	if j.req.ResponseType == comman_function.ResponseTypeAppkube {
+		p, err := comman_function.LookupPanel(j.result.ElementType, j.result.Query)
+		if err == nil {
+			j.result.Data, err = p.RenderAppkube(result)
+		}
+		if err != nil {
+			j.result.fail(err)
+		}
+		return
+	}
+	if jsonResp, ok := result.Json.(string); ok && json.Valid([]byte(jsonResp)) {
+		j.result.Data = json.RawMessage(jsonResp)
+		return
+	}
+	j.result.Data = result.Json
+}
+
+// Write writes results in the given output. Json and yaml are one document
+// keyed by panel name; ndjson, csv and table are the rows of every panel in
+// request order, with a panel column naming the panel of each row and an
+// error column for the panels that failed.
+func Write(w io.Writer, requests []Request, results map[string]*Result, output string) error {
+	switch output {
+	case "", comman_function.OutputJson:
+		jsonBytes, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(jsonBytes))
+		return err
+	case comman_function.OutputYaml:
+		return comman_function.WriteOutput(w, results, output)
+	}
+
+	columns := []string{"panel"}
+	index := map[string]int{"panel": 0}
+	var rows []map[string]interface{}
+	for _, request := range requests {
+		result := results[request["name"]]
+		if result == nil {
+			continue
+		}
+		if result.Error != "" {
+			rows = append(rows, map[string]interface{}{"panel": request["name"], "error": result.Error})
+			continue
+		}
+		panelColumns, panelRows := comman_function.Tabulate(outputPayload(request, result, output))
+		for _, column := range panelColumns {
+			if _, ok := index[column]; !ok {
+				index[column] = len(columns)
+				columns = append(columns, column)
+			}
+		}
+		for _, panelRow := range panelRows {
+			row := map[string]interface{}{"panel": request["name"]}
+			for i, column := range panelColumns {
+				row[column] = panelRow[i]
+			}
+			rows = append(rows, row)
+		}
+	}
+	if _, ok := index["error"]; !ok {
+		columns = append(columns, "error")
+	}
+
+	cells := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]interface{}, len(columns))
+		for j, column := range columns {
+			cells[i][j] = row[column]
+		}
+	}
+	return comman_function.WriteRows(w, columns, cells, output)
+}
+
+// outputPayload is the value of result written as rows, see
+// comman_function.PanelDefinition.OutputPayload.
+func outputPayload(request Request, result *Result, output string) interface{} {
+	if result.Panel == nil {
+		return result.Data
+	}
+	p, err := comman_function.LookupPanel(result.ElementType, result.Query)
+	if err != nil {
+		return result.Data
+	}
+	payload, err := p.OutputPayload(result.Panel, request["responseType"], output)
+	if err != nil {
+		return result.Data
+	}
+	return payload
+}
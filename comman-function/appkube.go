@@ -0,0 +1,854 @@
+package comman_function
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const ResponseTypeAppkube = "appkube"
+
+// AppkubeShape is the appkube panel type a panel renders into for
+// --responseType=appkube.
+type AppkubeShape string
+
+const (
+	AppkubeUtilization AppkubeShape = "utilization"
+	AppkubeDoughnut    AppkubeShape = "doughnut"
+	AppkubeTimeSeries  AppkubeShape = "timeseries"
+	AppkubeTable       AppkubeShape = "table"
+	AppkubeStatus      AppkubeShape = "status"
+)
+
+// AppkubeUtilizationPanel is a list of labelled values with units, e.g.
+// {"label":"Inbound Traffic","value":"500 MBPS"}.
+type AppkubeUtilizationPanel struct {
+	Items []AppkubeUtilizationItem `json:"items"`
+	// Metadata describes the data of the panel, see ResultMetadata.
+	Metadata *ResultMetadata `json:"metadata,omitempty"`
+}
+
+type AppkubeUtilizationItem struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// AppkubeDoughnutPanel splits a total into labelled parts. Change is the
+// percentage change of the total and Increment its direction.
+type AppkubeDoughnutPanel struct {
+	Total     float64               `json:"total"`
+	Increment bool                  `json:"increment"`
+	Change    float64               `json:"change"`
+	Items     []AppkubeDoughnutItem `json:"items"`
+	// Metadata describes the data of the panel, see ResultMetadata.
+	Metadata *ResultMetadata `json:"metadata,omitempty"`
+}
+
+type AppkubeDoughnutItem struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+}
+
+type AppkubeTimeSeriesPanel struct {
+	Series []AppkubeSeries `json:"series"`
+	// Metadata describes the data of the panel, see ResultMetadata.
+	Metadata *ResultMetadata `json:"metadata,omitempty"`
+}
+
+// AppkubeSeries is one line of a time series panel, its points sorted by
+// time. Unit is the human readable unit of the values, e.g. MBPS.
+type AppkubeSeries struct {
+	Label  string         `json:"label"`
+	Unit   string         `json:"unit,omitempty"`
+	Points []AppkubePoint `json:"points"`
+}
+
+type AppkubePoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+type AppkubeTablePanel struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+	// Metadata describes the data of the panel, see ResultMetadata.
+	Metadata *ResultMetadata `json:"metadata,omitempty"`
+}
+
+// AppkubeStatusPanel lists the state of each part of an element. Status is
+// one of ok, warning, critical or unknown.
+type AppkubeStatusPanel struct {
+	Items []AppkubeStatusItem `json:"items"`
+	// Metadata describes the data of the panel, see ResultMetadata.
+	Metadata *ResultMetadata `json:"metadata,omitempty"`
+}
+
+type AppkubeStatusItem struct {
+	Label  string `json:"label"`
+	Value  string `json:"value"`
+	Status string `json:"status"`
+}
+
+// RenderAppkube converts the result of the panel into its appkube shape. The
+// shape is built from the json output of the panel, or from its frames when
+// the json output has nothing to show, e.g. for graph panels. It carries the
+// metadata of the result.
+func (p *PanelDefinition) RenderAppkube(result *PanelResult) (interface{}, error) {
+	value := appkubeJson(result.Json)
+	frames, isFrames := result.Frame.([]Frame)
+	if value == nil && !isFrames {
+		// panels whose json output is text keep their data in the frame
+		value = appkubeJson(result.Frame)
+	}
+	switch p.Shape {
+	case AppkubeUtilization:
+		panel := p.appkubeUtilization(value, frames, result.Series)
+		panel.Metadata = result.Metadata
+		return panel, nil
+	case AppkubeDoughnut:
+		panel := p.appkubeDoughnut(value, frames)
+		panel.Metadata = result.Metadata
+		return panel, nil
+	case AppkubeTimeSeries:
+		panel := p.appkubeTimeSeries(value, frames, result.Series)
+		panel.Metadata = result.Metadata
+		return panel, nil
+	case AppkubeTable:
+		panel := appkubeTable(value, frames)
+		panel.Metadata = result.Metadata
+		return panel, nil
+	case AppkubeStatus:
+		panel := p.appkubeStatus(value, result.Series)
+		panel.Metadata = result.Metadata
+		return panel, nil
+	}
+	return nil, fmt.Errorf("panel %q has no appkube shape", p.Name)
+}
+
+func (p *PanelDefinition) appkubeUtilization(value interface{}, frames []Frame, series SeriesSet) AppkubeUtilizationPanel {
+	panel := AppkubeUtilizationPanel{Items: []AppkubeUtilizationItem{}}
+	add := func(label string, v interface{}) {
+		switch v := v.(type) {
+		case float64:
+			panel.Items = append(panel.Items, AppkubeUtilizationItem{Label: label, Value: FormatUnit(v, p.unitFor(label, series))})
+		case string:
+			panel.Items = append(panel.Items, AppkubeUtilizationItem{Label: label, Value: v})
+		}
+	}
+	switch v := value.(type) {
+	case *jsonObject:
+		for _, leaf := range v.leaves() {
+			add(p.leafLabel(leaf.key), leaf.value)
+		}
+	case []interface{}:
+		for _, row := range v {
+			if label, number, ok := labelledNumber(row); ok {
+				add(label, number)
+			}
+		}
+	case float64:
+		add(appkubeLabel(p.Name), v)
+	}
+	if len(panel.Items) > 0 {
+		return panel
+	}
+	for _, frame := range frames {
+		if last, ok := frame.last(); ok {
+			panel.Items = append(panel.Items, AppkubeUtilizationItem{Label: frame.label(), Value: FormatUnit(last, p.frameUnit(frame, series))})
+		}
+	}
+	return panel
+}
+
+func (p *PanelDefinition) appkubeDoughnut(value interface{}, frames []Frame) AppkubeDoughnutPanel {
+	panel := AppkubeDoughnutPanel{Items: []AppkubeDoughnutItem{}, Increment: true}
+	total, hasTotal := 0.0, false
+	switch v := value.(type) {
+	case *jsonObject:
+		for _, leaf := range v.leaves() {
+			key := strings.ToLower(leaf.key)
+			switch {
+			case key == "percentagechange" || key == "change":
+				panel.Change, _ = leaf.value.(float64)
+			case key == "changetype":
+				panel.Increment = leaf.value != "decrement"
+			case strings.HasPrefix(key, "total"):
+				total, hasTotal = leaf.value.(float64)
+			default:
+				if number, ok := leaf.value.(float64); ok {
+					panel.Items = append(panel.Items, AppkubeDoughnutItem{Label: p.leafLabel(leaf.key), Value: number})
+				}
+			}
+		}
+		for _, row := range v.rows() {
+			if label, number, ok := labelledNumber(row); ok {
+				panel.Items = append(panel.Items, AppkubeDoughnutItem{Label: label, Value: number})
+			}
+		}
+	case []interface{}:
+		for _, row := range v {
+			if label, number, ok := labelledNumber(row); ok {
+				panel.Items = append(panel.Items, AppkubeDoughnutItem{Label: label, Value: number})
+			}
+		}
+	}
+	if len(panel.Items) == 0 {
+		for _, frame := range frames {
+			if last, ok := frame.last(); ok {
+				panel.Items = append(panel.Items, AppkubeDoughnutItem{Label: frame.label(), Value: last})
+			}
+		}
+	}
+	if !hasTotal {
+		for _, item := range panel.Items {
+			total += item.Value
+		}
+	}
+	panel.Total = roundValue(total)
+	panel.Increment = panel.Increment && panel.Change >= 0
+	return panel
+}
+
+func (p *PanelDefinition) appkubeTimeSeries(value interface{}, frames []Frame, seriesSet SeriesSet) AppkubeTimeSeriesPanel {
+	panel := AppkubeTimeSeriesPanel{Series: []AppkubeSeries{}}
+	series := map[string]*AppkubeSeries{}
+	var labels []string
+	addPoint := func(label, unit string, t time.Time, number float64) {
+		s, ok := series[label]
+		if !ok {
+			s = &AppkubeSeries{Label: label, Unit: HumanUnit(unit), Points: []AppkubePoint{}}
+			series[label] = s
+			labels = append(labels, label)
+		}
+		s.Points = append(s.Points, AppkubePoint{Time: t, Value: number})
+	}
+	for _, frame := range frames {
+		if len(frame.Data.Values) != 2 {
+			continue
+		}
+		for i, t := range frame.Data.Values[0] {
+			ms, isTime := t.(int64)
+			number, isNumber := frame.Data.Values[1][i].(float64)
+			if isTime && isNumber {
+				addPoint(frame.label(), p.frameUnit(frame, seriesSet), time.UnixMilli(ms).UTC(), number)
+			}
+		}
+	}
+	if len(labels) == 0 {
+		collectSeries("", value, func(label string, t time.Time, number float64) {
+			addPoint(label, p.unitFor(label, seriesSet), t, number)
+		})
+	}
+	for _, label := range labels {
+		s := series[label]
+		sort.SliceStable(s.Points, func(a, b int) bool { return s.Points[a].Time.Before(s.Points[b].Time) })
+		panel.Series = append(panel.Series, *s)
+	}
+	return panel
+}
+
+// collectSeries walks value for timestamped numbers: rows with a time column,
+// objects keyed by time and objects of either, labelled by their keys.
+func collectSeries(label string, value interface{}, add func(string, time.Time, float64)) {
+	switch v := value.(type) {
+	case []interface{}:
+		seen := map[string]int{}
+		for _, row := range v {
+			object, ok := row.(*jsonObject)
+			if !ok {
+				continue
+			}
+			t, timeKey, ok := object.time()
+			if !ok {
+				// a row per element, e.g. {"InstanceType":"t3.micro","Items":{...}}
+				rowLabel := label
+				if name, ok := object.name(); ok {
+					rowLabel = name
+				}
+				if seen[rowLabel]++; seen[rowLabel] > 1 {
+					rowLabel = fmt.Sprintf("%s (%d)", rowLabel, seen[rowLabel])
+				}
+				for _, key := range object.keys {
+					collectSeries(rowLabel, object.values[key], add)
+				}
+				continue
+			}
+			for _, key := range object.keys {
+				if number, ok := object.values[key].(float64); ok && key != timeKey {
+					add(seriesLabel(label, key, v), t, number)
+				}
+			}
+		}
+	case *jsonObject:
+		for _, key := range v.keys {
+			if t, ok := parseAppkubeTime(key); ok {
+				if number, ok := v.values[key].(float64); ok {
+					add(label, t, number)
+				}
+				continue
+			}
+			child := appkubeLabel(key)
+			if label != "" {
+				child = label + " " + child
+			}
+			collectSeries(child, v.values[key], add)
+		}
+	}
+}
+
+// seriesLabel names the series of a value column. A row list with a single
+// value column is named after its parent key.
+func seriesLabel(parent, key string, rows []interface{}) string {
+	if parent != "" {
+		if object, ok := rows[0].(*jsonObject); ok && object.numbers() == 1 {
+			return parent
+		}
+		return parent + " " + appkubeLabel(key)
+	}
+	return appkubeLabel(key)
+}
+
+func appkubeTable(value interface{}, frames []Frame) AppkubeTablePanel {
+	panel := AppkubeTablePanel{Columns: []string{}, Rows: [][]interface{}{}}
+	var rows []*jsonObject
+	switch v := value.(type) {
+	case []interface{}:
+		for _, row := range v {
+			if object, ok := row.(*jsonObject); ok {
+				rows = append(rows, object)
+			}
+		}
+	case *jsonObject:
+		rows = []*jsonObject{v}
+	}
+	if len(rows) == 0 && len(frames) > 0 {
+		panel.Columns = []string{"Time", "Label", "Value"}
+		for _, frame := range frames {
+			if len(frame.Data.Values) != 2 {
+				continue
+			}
+			for i, t := range frame.Data.Values[0] {
+				if ms, ok := t.(int64); ok {
+					panel.Rows = append(panel.Rows, []interface{}{time.UnixMilli(ms).UTC(), frame.label(), frame.Data.Values[1][i]})
+				}
+			}
+		}
+		return panel
+	}
+
+	index := map[string]int{}
+	for _, row := range rows {
+		for _, key := range row.keys {
+			if _, ok := index[key]; !ok {
+				index[key] = len(panel.Columns)
+				panel.Columns = append(panel.Columns, key)
+			}
+		}
+	}
+	for _, row := range rows {
+		cells := make([]interface{}, len(panel.Columns))
+		for _, key := range row.keys {
+			cells[index[key]] = row.values[key]
+		}
+		panel.Rows = append(panel.Rows, cells)
+	}
+	return panel
+}
+
+func (p *PanelDefinition) appkubeStatus(value interface{}, series SeriesSet) AppkubeStatusPanel {
+	panel := AppkubeStatusPanel{Items: []AppkubeStatusItem{}}
+	switch v := value.(type) {
+	case []interface{}:
+		for i, row := range v {
+			object, ok := row.(*jsonObject)
+			if !ok {
+				continue
+			}
+			label, ok := object.name()
+			if !ok {
+				label = fmt.Sprintf("%s %d", appkubeLabel(p.Name), i+1)
+			}
+			state := ""
+			for _, key := range object.keys {
+				if s, ok := object.values[key].(string); ok && isStatusKey(key) {
+					state = s
+					break
+				}
+			}
+			panel.Items = append(panel.Items, AppkubeStatusItem{Label: label, Value: state, Status: statusOf(state)})
+		}
+	case *jsonObject:
+		leaves := v.leaves()
+		var statusLeaves []jsonLeaf
+		for _, leaf := range leaves {
+			if _, ok := leaf.value.(string); ok && isStatusKey(leaf.key) {
+				statusLeaves = append(statusLeaves, leaf)
+			}
+		}
+		if len(statusLeaves) > 0 {
+			leaves = statusLeaves
+		}
+		for _, leaf := range leaves {
+			item := AppkubeStatusItem{Label: p.leafLabel(leaf.key)}
+			switch value := leaf.value.(type) {
+			case string:
+				item.Value, item.Status = value, statusOf(value)
+			case float64:
+				item.Value, item.Status = FormatUnit(value, p.unitFor(leaf.key, series)), countStatus(leaf.key, value)
+			case bool:
+				item.Value, item.Status = strconv.FormatBool(value), "unknown"
+				if value {
+					item.Status = "ok"
+				}
+			default:
+				continue
+			}
+			panel.Items = append(panel.Items, item)
+		}
+	case float64:
+		panel.Items = append(panel.Items, AppkubeStatusItem{Label: appkubeLabel(p.Name), Value: FormatUnit(v, p.Unit), Status: countStatus(p.Name, v)})
+	}
+	return panel
+}
+
+var (
+	okStates       = []string{"running", "healthy", "ok", "passed", "available", "active", "inservice", "succeeded", "success", "completed", "enabled"}
+	warningStates  = []string{"pending", "stopping", "stopped", "degraded", "impaired", "initializing", "insufficient", "unused", "draining", "throttled"}
+	criticalStates = []string{"unhealthy", "failed", "failure", "error", "terminated", "alarm", "critical", "down", "missed"}
+)
+
+// statusOf classifies a state string such as "running" or "unhealthy".
+func statusOf(state string) string {
+	state = strings.ToLower(state)
+	for _, group := range []struct {
+		status string
+		words  []string
+	}{{"critical", criticalStates}, {"warning", warningStates}, {"ok", okStates}} {
+		for _, word := range group.words {
+			if strings.Contains(state, word) {
+				return group.status
+			}
+		}
+	}
+	return "unknown"
+}
+
+// countStatus classifies a count by its name: any unhealthy, failed or
+// stopped count is critical, every other count is ok.
+func countStatus(name string, count float64) string {
+	if count > 0 && statusOf(name) != "ok" && statusOf(name) != "unknown" {
+		return statusOf(name)
+	}
+	return "ok"
+}
+
+func isStatusKey(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "status") || strings.Contains(key, "state") || strings.Contains(key, "health")
+}
+
+// unitFor is the unit of the value labelled label: the unit of the panel, or
+// the one its name implies. Totals, e.g. Data Transferred, or the values of
+// panels whose metrics are all sums are sizes rather than rates.
+func (p *PanelDefinition) unitFor(label string, series SeriesSet) string {
+	unit := p.Unit
+	if unit == "" {
+		unit = GrafanaUnit("", strings.ReplaceAll(label, " ", ""))
+	}
+	if isTotalLabel(label) || series.allSums() {
+		return totalUnit(unit)
+	}
+	return unit
+}
+
+// frameUnit is the unit of the values of frame: the unit of the panel, or the
+// one of the frame. The values of a Sum are sizes rather than rates.
+func (p *PanelDefinition) frameUnit(frame Frame, series SeriesSet) string {
+	unit := p.Unit
+	if unit == "" && len(frame.Schema.Fields) == 2 && frame.Schema.Fields[1].Config != nil {
+		unit = frame.Schema.Fields[1].Config.Unit
+	}
+	if series.statOf(frame) == "Sum" {
+		return totalUnit(unit)
+	}
+	return unit
+}
+
+// totalUnits maps grafana rate units to the size unit of their totals.
+var totalUnits = map[string]string{
+	"Bps": "decbytes",
+	"KBs": "deckbytes",
+	"MBs": "decmbytes",
+	"GBs": "decgbytes",
+	"bps": "decbits",
+}
+
+// totalUnit is the unit of a total of values in unit, e.g. MB for MBPS.
+func totalUnit(unit string) string {
+	if total, ok := totalUnits[unit]; ok {
+		return total
+	}
+	return unit
+}
+
+// isTotalLabel reports whether label names a total, e.g. DataTransferred or
+// Total Bytes.
+func isTotalLabel(label string) bool {
+	label = strings.ToLower(label)
+	return strings.Contains(label, "total") || strings.Contains(label, "transferred")
+}
+
+// label is the display name of the value field of frame.
+func (f Frame) label() string {
+	if len(f.Schema.Fields) == 2 {
+		field := f.Schema.Fields[1]
+		if field.Config != nil && field.Config.DisplayNameFromDS != "" {
+			return field.Config.DisplayNameFromDS
+		}
+		if len(field.Labels) > 0 {
+			keys := make([]string, 0, len(field.Labels))
+			for key := range field.Labels {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			values := make([]string, len(keys))
+			for i, key := range keys {
+				values[i] = field.Labels[key]
+			}
+			return field.Name + " " + strings.Join(values, " ")
+		}
+	}
+	return f.Schema.Name
+}
+
+// last is the newest non null value of frame.
+func (f Frame) last() (float64, bool) {
+	if len(f.Data.Values) != 2 {
+		return 0, false
+	}
+	values := f.Data.Values[1]
+	for i := len(values) - 1; i >= 0; i-- {
+		if number, ok := values[i].(float64); ok {
+			return number, true
+		}
+	}
+	return 0, false
+}
+
+// appkubeUnit is a scale of unit names a step of 1000 apart and the step of
+// the grafana unit on it.
+type appkubeUnit struct {
+	names []string
+	start int
+}
+
+var (
+	byteNames     = []string{"B", "KB", "MB", "GB", "TB", "PB"}
+	byteRateNames = []string{"BPS", "KBPS", "MBPS", "GBPS", "TBPS"}
+	bitNames      = []string{"b", "Kb", "Mb", "Gb", "Tb"}
+	bitRateNames  = []string{"bps", "Kbps", "Mbps", "Gbps", "Tbps"}
+	durationNames = []string{"µs", "ms", "s"}
+)
+
+// appkubeUnits maps grafana unit ids to the unit names appkube shows.
+var appkubeUnits = map[string]appkubeUnit{
+	"decbytes":  {byteNames, 0},
+	"deckbytes": {byteNames, 1},
+	"decmbytes": {byteNames, 2},
+	"decgbytes": {byteNames, 3},
+	"dectbytes": {byteNames, 4},
+	"decbits":   {bitNames, 0},
+	"Bps":       {byteRateNames, 0},
+	"KBs":       {byteRateNames, 1},
+	"MBs":       {byteRateNames, 2},
+	"GBs":       {byteRateNames, 3},
+	"bps":       {bitRateNames, 0},
+	"Kbits":     {bitRateNames, 1},
+	"Mbits":     {bitRateNames, 2},
+	"Gbits":     {bitRateNames, 3},
+	"µs":        {durationNames, 0},
+	"ms":        {durationNames, 1},
+	"s":         {durationNames, 2},
+	"percent":   {[]string{"%"}, 0},
+	"cps":       {[]string{"/s"}, 0},
+}
+
+// HumanUnit is the appkube name of a grafana unit id, e.g. MBPS for MBs.
+func HumanUnit(unit string) string {
+	if u, ok := appkubeUnits[unit]; ok {
+		return u.names[u.start]
+	}
+	return ""
+}
+
+// FormatUnit formats value in the grafana unit with a human readable unit,
+// scaling sizes, rates and durations, e.g. 500000000 Bps is "500 MBPS" and
+// 0.5 MBs is "500 KBPS".
+func FormatUnit(value float64, unit string) string {
+	u, ok := appkubeUnits[unit]
+	if !ok {
+		return strconv.FormatFloat(roundValue(value), 'f', -1, 64)
+	}
+	step := u.start
+	for math.Abs(value) >= 1000 && step+1 < len(u.names) {
+		value, step = value/1000, step+1
+	}
+	for value != 0 && math.Abs(value) < 1 && step > 0 {
+		value, step = value*1000, step-1
+	}
+	name := u.names[step]
+	if name == "/s" {
+		return strconv.FormatFloat(roundValue(value), 'f', -1, 64) + name
+	}
+	return strconv.FormatFloat(roundValue(value), 'f', -1, 64) + " " + name
+}
+
+func roundValue(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// appkubeLabel turns a json key or panel name into a label, e.g. InboundTraffic
+// and inbound_traffic are both "Inbound Traffic". Keys with dashes are names
+// such as instance ids or regions and are kept as they are.
+func appkubeLabel(key string) string {
+	if strings.Contains(key, "-") {
+		return key
+	}
+	key = strings.TrimSuffix(key, "_panel")
+	var words []string
+	var word []rune
+	runes := []rune(key)
+	for i, r := range runes {
+		boundary := unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])))
+		if r == '_' || r == ' ' || boundary {
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			if r == '_' || r == ' ' {
+				continue
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	for i, w := range words {
+		if strings.ToLower(w) == w {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// leafLabel is the label of a json key. A bare "Value" is named after the
+// panel.
+func (p *PanelDefinition) leafLabel(key string) string {
+	if strings.EqualFold(key, "value") {
+		return appkubeLabel(p.Name)
+	}
+	return appkubeLabel(key)
+}
+
+// labelledNumber reads a row such as {"eventName":"x","eventCount":3} as a
+// label and a value.
+func labelledNumber(row interface{}) (string, float64, bool) {
+	object, ok := row.(*jsonObject)
+	if !ok {
+		return "", 0, false
+	}
+	label, hasLabel := object.name()
+	for _, key := range object.keys {
+		if number, ok := object.values[key].(float64); ok {
+			if !hasLabel {
+				label = appkubeLabel(key)
+			}
+			return label, number, true
+		}
+	}
+	return "", 0, false
+}
+
+// jsonObject is a decoded json object that remembers the order of its keys.
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+type jsonLeaf struct {
+	key   string
+	value interface{}
+}
+
+// MarshalJSON encodes o with its keys in their original order.
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		keyBytes, _ := json.Marshal(key)
+		valueBytes, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(keyBytes)
+		buffer.WriteByte(':')
+		buffer.Write(valueBytes)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// leaves returns the scalar values of o and of the objects nested in it. The
+// keys of nested values are prefixed with their parent key when o has more
+// than one key, e.g. {"dev":{"Uptime":1},"prod":{"Uptime":2}}.
+func (o *jsonObject) leaves() []jsonLeaf {
+	var leaves []jsonLeaf
+	for _, key := range o.keys {
+		switch v := o.values[key].(type) {
+		case *jsonObject:
+			for _, leaf := range v.leaves() {
+				if len(o.keys) > 1 {
+					leaf.key = key + " " + leaf.key
+				}
+				leaves = append(leaves, leaf)
+			}
+		case []interface{}, nil:
+		default:
+			leaves = append(leaves, jsonLeaf{key: key, value: v})
+		}
+	}
+	return leaves
+}
+
+// rows returns the objects in the lists of o, e.g. the zones of
+// {"total":4,"zones":[{"zone":"us-east-1a","count":2}]}.
+func (o *jsonObject) rows() []interface{} {
+	var rows []interface{}
+	for _, key := range o.keys {
+		if list, ok := o.values[key].([]interface{}); ok {
+			rows = append(rows, list...)
+		}
+	}
+	return rows
+}
+
+// name is the first string value of o that is not a time or a status.
+func (o *jsonObject) name() (string, bool) {
+	for _, key := range o.keys {
+		if s, ok := o.values[key].(string); ok && !isStatusKey(key) {
+			if _, isTime := parseAppkubeTime(s); !isTime {
+				return s, true
+			}
+		}
+	}
+	return "", false
+}
+
+// time is the first time value of o and its key.
+func (o *jsonObject) time() (time.Time, string, bool) {
+	for _, key := range o.keys {
+		if s, ok := o.values[key].(string); ok {
+			if t, ok := parseAppkubeTime(s); ok {
+				return t, key, true
+			}
+		}
+	}
+	return time.Time{}, "", false
+}
+
+func (o *jsonObject) numbers() int {
+	count := 0
+	for _, key := range o.keys {
+		if _, ok := o.values[key].(float64); ok {
+			count++
+		}
+	}
+	return count
+}
+
+// parseAppkubeTime reads the timestamps panels output: those of logs
+// insights results and the minute precision times of the static panels.
+func parseAppkubeTime(s string) (time.Time, bool) {
+	for _, layout := range logsTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	if t, err := time.Parse("2006-01-02 15:04", s); err == nil {
+		return t.UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// appkubeJson decodes the json output of a panel keeping the order of object
+// keys. Output that is not json decodes to nil.
+func appkubeJson(value interface{}) interface{} {
+	var data []byte
+	if s, ok := value.(string); ok {
+		data = []byte(strings.TrimSpace(s))
+	} else {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return nil
+		}
+	}
+	if !json.Valid(data) {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoded, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil
+	}
+	return decoded
+}
+
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := &jsonObject{values: map[string]interface{}{}}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyToken.(string)
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := object.values[key]; !exists {
+				object.keys = append(object.keys, key)
+			}
+			object.values[key] = value
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		values := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		_, err = decoder.Token()
+		return values, err
+	}
+	return token, nil
+}
@@ -0,0 +1,137 @@
+package comman_function_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+)
+
+// metricResult returns a result of metric on instance i-1 queried with stat,
+// its values a minute apart from 05:00 and newest first as cloudwatch
+// returns them, and its query.
+func metricResult(id, metric, stat, unit string, values ...float64) (*cloudwatch.MetricDataResult, comman_function.MetricSeries) {
+	start := time.Date(2026, 10, 17, 5, 0, 0, 0, time.UTC)
+	result := &cloudwatch.MetricDataResult{Id: aws.String(id), StatusCode: aws.String(cloudwatch.StatusCodeComplete)}
+	for i := len(values) - 1; i >= 0; i-- {
+		result.Timestamps = append(result.Timestamps, aws.Time(start.Add(time.Duration(i)*time.Minute)))
+		result.Values = append(result.Values, aws.Float64(values[i]))
+	}
+	series := comman_function.MetricSeries{Namespace: "AWS/EC2", MetricName: metric, Stat: stat, Unit: unit, Period: 60, Dimensions: map[string]string{"InstanceId": "i-1"}}
+	return result, series
+}
+
+// metricPanelResult is a panel result with json output and the frames of
+// results.
+func metricPanelResult(output string, results ...func() (*cloudwatch.MetricDataResult, comman_function.MetricSeries)) *comman_function.PanelResult {
+	result := &comman_function.PanelResult{Json: output, Series: comman_function.SeriesSet{}}
+	metricOutput := &cloudwatch.GetMetricDataOutput{}
+	for _, r := range results {
+		metricResult, series := r()
+		metricOutput.MetricDataResults = append(metricOutput.MetricDataResults, metricResult)
+		result.Series[metricResult] = series
+	}
+	result.Frame = comman_function.MetricFrames(metricOutput, result.Series)
+	return result
+}
+
+func TestRenderAppkube(t *testing.T) {
+	inbound := func() (*cloudwatch.MetricDataResult, comman_function.MetricSeries) {
+		return metricResult("inbound", "NetworkIn", "Average", cloudwatch.StandardUnitBytesSecond, 1000, 2000)
+	}
+	outboundSum := func() (*cloudwatch.MetricDataResult, comman_function.MetricSeries) {
+		return metricResult("outbound", "NetworkOut", "Sum", cloudwatch.StandardUnitBytesSecond, 1500000, 2500000)
+	}
+
+	tests := []struct {
+		name   string
+		panel  comman_function.PanelDefinition
+		result *comman_function.PanelResult
+		want   string
+	}{
+		{
+			name:   "utilization rates and their total",
+			panel:  comman_function.PanelDefinition{Name: "network_utilization_panel", Shape: comman_function.AppkubeUtilization, Unit: "MBs"},
+			result: metricPanelResult(`{"InboundTraffic":5.5,"OutboundTraffic":2.9,"DataTransferred":8.4}`, inbound),
+			want:   `{"items":[{"label":"Inbound Traffic","value":"5.5 MBPS"},{"label":"Outbound Traffic","value":"2.9 MBPS"},{"label":"Data Transferred","value":"8.4 MB"}]}`,
+		},
+		{
+			name:   "utilization of sums",
+			panel:  comman_function.PanelDefinition{Name: "network_utilization_panel", Shape: comman_function.AppkubeUtilization, Unit: "MBs"},
+			result: metricPanelResult(`{"InboundTraffic":1500,"OutboundTraffic":0.25}`, outboundSum),
+			want:   `{"items":[{"label":"Inbound Traffic","value":"1.5 GB"},{"label":"Outbound Traffic","value":"250 KB"}]}`,
+		},
+		{
+			name:   "utilization of frames",
+			panel:  comman_function.PanelDefinition{Name: "network_in_panel", Shape: comman_function.AppkubeUtilization},
+			result: metricPanelResult(``, inbound, outboundSum),
+			want:   `{"items":[{"label":"NetworkIn i-1","value":"2 KBPS"},{"label":"NetworkOut i-1","value":"2.5 MB"}]}`,
+		},
+		{
+			name:   "doughnut",
+			panel:  comman_function.PanelDefinition{Name: "instance_status_panel", Shape: comman_function.AppkubeDoughnut},
+			result: metricPanelResult(`{"total":10,"percentageChange":-5,"changeType":"decrement","running":6,"stopped":4}`),
+			want:   `{"total":10,"increment":false,"change":-5,"items":[{"label":"Running","value":6},{"label":"Stopped","value":4}]}`,
+		},
+		{
+			name:   "doughnut without a total",
+			panel:  comman_function.PanelDefinition{Name: "error_panel", Shape: comman_function.AppkubeDoughnut},
+			result: metricPanelResult(`[{"eventName":"Throttled","count":3},{"eventName":"Denied","count":2}]`),
+			want:   `{"total":5,"increment":true,"change":0,"items":[{"label":"Throttled","value":3},{"label":"Denied","value":2}]}`,
+		},
+		{
+			name:   "time series of frames",
+			panel:  comman_function.PanelDefinition{Name: "network_traffic_panel", Shape: comman_function.AppkubeTimeSeries},
+			result: metricPanelResult(``, inbound, outboundSum),
+			want: `{"series":[` +
+				`{"label":"NetworkIn i-1","unit":"BPS","points":[{"time":"2026-10-17T05:00:00Z","value":1000},{"time":"2026-10-17T05:01:00Z","value":2000}]},` +
+				`{"label":"NetworkOut i-1","unit":"B","points":[{"time":"2026-10-17T05:00:00Z","value":1500000},{"time":"2026-10-17T05:01:00Z","value":2500000}]}]}`,
+		},
+		{
+			name:   "time series of json",
+			panel:  comman_function.PanelDefinition{Name: "latency_panel", Shape: comman_function.AppkubeTimeSeries, Unit: "ms"},
+			result: metricPanelResult(`[{"Timestamp":"2026-10-17T05:01:00Z","Value":12},{"Timestamp":"2026-10-17T05:00:00Z","Value":10}]`),
+			want:   `{"series":[{"label":"Value","unit":"ms","points":[{"time":"2026-10-17T05:00:00Z","value":10},{"time":"2026-10-17T05:01:00Z","value":12}]}]}`,
+		},
+		{
+			name:   "table",
+			panel:  comman_function.PanelDefinition{Name: "error_logs_panel", Shape: comman_function.AppkubeTable},
+			result: metricPanelResult(`[{"name":"a","count":1},{"name":"b","extra":true}]`),
+			want:   `{"columns":["name","count","extra"],"rows":[["a",1,null],["b",null,true]]}`,
+		},
+		{
+			name:   "status",
+			panel:  comman_function.PanelDefinition{Name: "instance_health_panel", Shape: comman_function.AppkubeStatus},
+			result: metricPanelResult(`{"InstanceStatus":"running","SystemStatus":"impaired","Attachment":"failed"}`),
+			want:   `{"items":[{"label":"Instance Status","value":"running","status":"ok"},{"label":"System Status","value":"impaired","status":"warning"}]}`,
+		},
+		{
+			name:   "status of rows",
+			panel:  comman_function.PanelDefinition{Name: "target_health_panel", Shape: comman_function.AppkubeStatus},
+			result: metricPanelResult(`[{"TargetId":"i-1","State":"healthy"},{"TargetId":"i-2","State":"unhealthy"}]`),
+			want:   `{"items":[{"label":"i-1","value":"healthy","status":"ok"},{"label":"i-2","value":"unhealthy","status":"critical"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := tt.panel.RenderAppkube(tt.result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(rendered)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("RenderAppkube =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (&comman_function.PanelDefinition{Name: "cpu_panel"}).RenderAppkube(&comman_function.PanelResult{}); err == nil {
+		t.Error("RenderAppkube rendered a panel without a shape")
+	}
+}
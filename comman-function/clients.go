@@ -0,0 +1,281 @@
+package comman_function
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// ClientProvider creates the aws service clients used by the panels. Panels
+// only see the sdk iface interfaces, so a provider returning fakes runs every
+// panel without network access.
+type ClientProvider interface {
+	CloudWatch(auth model.Auth) cloudwatchiface.CloudWatchAPI
+	CloudWatchLogs(auth model.Auth) cloudwatchlogsiface.CloudWatchLogsAPI
+	EC2(auth model.Auth) ec2iface.EC2API
+	Lambda(auth model.Auth) lambdaiface.LambdaAPI
+	ELBV2(auth model.Auth) elbv2iface.ELBV2API
+	APIGateway(auth model.Auth) apigatewayiface.APIGatewayAPI
+	APIGatewayV2(auth model.Auth) apigatewayv2iface.ApiGatewayV2API
+	AutoScaling(auth model.Auth) autoscalingiface.AutoScalingAPI
+}
+
+// NewAwsClientProvider returns the provider of real sdk clients. Its sessions
+// use the access key of the auth and assume its cross account role, as
+// awsclient.GetClient does, but a failed assume role fails the calls of the
+// clients with an AuthError instead of exiting the process. Sessions are
+// reused per region, access key and role, so the role is assumed once and
+// refreshed before it expires.
+func NewAwsClientProvider() *SessionClientProvider {
+	return NewSessionClientProvider(assumeRoleSession)
+}
+
+// roleSessionName names the sessions of the cross account role.
+const roleSessionName = "awsx-getelementdetails"
+
+func assumeRoleSession(auth model.Auth) *session.Session {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(auth.Region),
+		Credentials: credentials.NewStaticCredentials(auth.AccessKey, auth.SecretKey, ""),
+	})
+	if err != nil {
+		return failedSession(auth, fmt.Errorf("error creating aws session: %w", err))
+	}
+	if auth.CrossAccountRoleArn == "" {
+		return sess
+	}
+	provider := &stscreds.AssumeRoleProvider{
+		Client:          sts.New(sess),
+		RoleARN:         auth.CrossAccountRoleArn,
+		RoleSessionName: roleSessionName,
+		Duration:        time.Hour,
+		ExpiryWindow:    5 * time.Minute,
+	}
+	if auth.ExternalId != "" && auth.ExternalId != "nil" {
+		provider.ExternalID = aws.String(auth.ExternalId)
+	}
+	return sess.Copy(&aws.Config{Credentials: credentials.NewCredentials(authErrorProvider{provider})})
+}
+
+// authErrorProvider reports the failures of the credentials provider it
+// wraps as an AuthError.
+type authErrorProvider struct {
+	credentials.Provider
+}
+
+func (p authErrorProvider) Retrieve() (credentials.Value, error) {
+	value, err := p.Provider.Retrieve()
+	if err != nil {
+		return value, &AuthError{Err: fmt.Errorf("error assuming role: %w", err)}
+	}
+	return value, nil
+}
+
+// failedCredentials fails every call with an AuthError of err.
+type failedCredentials struct {
+	err error
+}
+
+func (c failedCredentials) Retrieve() (credentials.Value, error) {
+	return credentials.Value{}, &AuthError{Err: c.err}
+}
+
+func (c failedCredentials) IsExpired() bool {
+	return true
+}
+
+// failedSession returns a session whose calls fail with an AuthError of err.
+func failedSession(auth model.Auth, err error) *session.Session {
+	return &session.Session{
+		Config:   defaults.Config().WithRegion(auth.Region).WithCredentials(credentials.NewCredentials(failedCredentials{err})),
+		Handlers: defaults.Handlers(),
+	}
+}
+
+// SessionClientProvider creates sdk clients from the sessions returned by
+// newSession. Sessions are reused per region, access key and cross account
+// role.
+type SessionClientProvider struct {
+	newSession func(auth model.Auth) *session.Session
+
+	mu       sync.Mutex
+	sessions map[string]*session.Session
+}
+
+func NewSessionClientProvider(newSession func(auth model.Auth) *session.Session) *SessionClientProvider {
+	return &SessionClientProvider{newSession: newSession, sessions: map[string]*session.Session{}}
+}
+
+func (p *SessionClientProvider) session(auth model.Auth) *session.Session {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := strings.Join([]string{auth.Region, auth.AccessKey, auth.CrossAccountRoleArn, auth.ExternalId}, "|")
+	sess, ok := p.sessions[key]
+	if !ok {
+		sess = p.newSession(auth)
+		p.sessions[key] = sess
+	}
+	return sess
+}
+
+func (p *SessionClientProvider) CloudWatch(auth model.Auth) cloudwatchiface.CloudWatchAPI {
+	return cloudwatch.New(p.session(auth))
+}
+
+func (p *SessionClientProvider) CloudWatchLogs(auth model.Auth) cloudwatchlogsiface.CloudWatchLogsAPI {
+	return cloudwatchlogs.New(p.session(auth))
+}
+
+func (p *SessionClientProvider) EC2(auth model.Auth) ec2iface.EC2API {
+	return ec2.New(p.session(auth))
+}
+
+func (p *SessionClientProvider) Lambda(auth model.Auth) lambdaiface.LambdaAPI {
+	return lambda.New(p.session(auth))
+}
+
+func (p *SessionClientProvider) ELBV2(auth model.Auth) elbv2iface.ELBV2API {
+	return elbv2.New(p.session(auth))
+}
+
+func (p *SessionClientProvider) APIGateway(auth model.Auth) apigatewayiface.APIGatewayAPI {
+	return apigateway.New(p.session(auth))
+}
+
+func (p *SessionClientProvider) APIGatewayV2(auth model.Auth) apigatewayv2iface.ApiGatewayV2API {
+	return apigatewayv2.New(p.session(auth))
+}
+
+func (p *SessionClientProvider) AutoScaling(auth model.Auth) autoscalingiface.AutoScalingAPI {
+	return autoscaling.New(p.session(auth))
+}
+
+var (
+	clientProviderMu      sync.RWMutex
+	defaultClientProvider ClientProvider = NewAwsClientProvider()
+	clientProvider                       = defaultClientProvider
+)
+
+// SetClientProvider replaces the provider every panel gets its clients from
+// and returns a func restoring the previous one. A nil provider restores
+// the one of NewAwsClientProvider.
+func SetClientProvider(provider ClientProvider) (restore func()) {
+	if provider == nil {
+		provider = defaultClientProvider
+	}
+	clientProviderMu.Lock()
+	previous := clientProvider
+	clientProvider = provider
+	clientProviderMu.Unlock()
+	return func() { SetClientProvider(previous) }
+}
+
+// Clients returns the current ClientProvider.
+func Clients() ClientProvider {
+	clientProviderMu.RLock()
+	defer clientProviderMu.RUnlock()
+	return clientProvider
+}
+
+// The client funcs below return the clients of the current provider with the
+// retry policy of their service applied, see SetRetryPolicies.
+
+// CloudWatchClient returns a cloudwatch client of the current provider. Panels
+// use PanelRequest.CloudWatchClient, whose results MetricFrames can label.
+func CloudWatchClient(auth model.Auth) cloudwatchiface.CloudWatchAPI {
+	client := Clients().CloudWatch(auth)
+	applyRetryPolicy(client)
+	return client
+}
+
+func CloudWatchLogsClient(auth model.Auth) cloudwatchlogsiface.CloudWatchLogsAPI {
+	client := Clients().CloudWatchLogs(auth)
+	applyRetryPolicy(client)
+	return client
+}
+
+func EC2Client(auth model.Auth) ec2iface.EC2API {
+	client := Clients().EC2(auth)
+	applyRetryPolicy(client)
+	return client
+}
+
+func LambdaClient(auth model.Auth) lambdaiface.LambdaAPI {
+	client := Clients().Lambda(auth)
+	applyRetryPolicy(client)
+	return client
+}
+
+func ELBV2Client(auth model.Auth) elbv2iface.ELBV2API {
+	client := Clients().ELBV2(auth)
+	applyRetryPolicy(client)
+	return client
+}
+
+func APIGatewayClient(auth model.Auth) apigatewayiface.APIGatewayAPI {
+	client := Clients().APIGateway(auth)
+	applyRetryPolicy(client)
+	return client
+}
+
+func APIGatewayV2Client(auth model.Auth) apigatewayv2iface.ApiGatewayV2API {
+	client := Clients().APIGatewayV2(auth)
+	applyRetryPolicy(client)
+	return client
+}
+
+func AutoScalingClient(auth model.Auth) autoscalingiface.AutoScalingAPI {
+	client := Clients().AutoScaling(auth)
+	applyRetryPolicy(client)
+	return client
+}
+
+// sdkClientOf returns the sdk client of a service client, or nil for a client
+// that is not an sdk client, e.g. a fake.
+func sdkClientOf(serviceClient interface{}) *client.Client {
+	switch c := serviceClient.(type) {
+	case *cloudwatch.CloudWatch:
+		return c.Client
+	case *cloudwatchlogs.CloudWatchLogs:
+		return c.Client
+	case *ec2.EC2:
+		return c.Client
+	case *lambda.Lambda:
+		return c.Client
+	case *elbv2.ELBV2:
+		return c.Client
+	case *apigateway.APIGateway:
+		return c.Client
+	case *apigatewayv2.ApiGatewayV2:
+		return c.Client
+	case *autoscaling.AutoScaling:
+		return c.Client
+	}
+	return nil
+}
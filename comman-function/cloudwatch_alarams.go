@@ -1,7 +1,6 @@
 package comman_function
 
 import (
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"log"
@@ -9,7 +8,7 @@ import (
 )
 
 func GetCloudWatchAlarms(clientAuth *model.Auth, startTime, endTime *time.Time) ([]*cloudwatch.MetricAlarm, error) {
-	svc := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+	svc := CloudWatchClient(*clientAuth)
 
 	// Call DescribeAlarms to get all alarms
 	resp, err := svc.DescribeAlarms(&cloudwatch.DescribeAlarmsInput{})
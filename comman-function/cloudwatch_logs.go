@@ -1,97 +1,36 @@
 package comman_function
 
 import (
-	"fmt"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
+	"context"
 	"github.com/Appkube-awsx/awsx-common/model"
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"log"
 	"strconv"
 	"time"
 )
 
-func GetLogsData(clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, query string, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	params := &cloudwatchlogs.StartQueryInput{
-		LogGroupName: aws.String(logGroupName),
-		StartTime:    aws.Int64(startTime.Unix() * 1000),
-		EndTime:      aws.Int64(endTime.Unix() * 1000),
-		QueryString:  aws.String(query),
-	}
-	if cloudWatchLogs == nil {
-		cloudWatchLogs = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH_LOG).(*cloudwatchlogs.CloudWatchLogs)
-	}
+func GetLogsData(clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, query string, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	return GetLogsDataWithContext(context.Background(), clientAuth, startTime, endTime, logGroupName, query, cloudWatchLogs)
+}
 
-	queryResult, err := cloudWatchLogs.StartQuery(params)
+// GetLogsDataWithContext runs query with RunLogsQuery. The slice holds the
+// final result only.
+func GetLogsDataWithContext(ctx context.Context, clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, query string, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	result, err := RunLogsQuery(ctx, clientAuth, LogsQuery{
+		LogGroupNames: []string{logGroupName},
+		QueryString:   query,
+		StartTime:     startTime,
+		EndTime:       endTime,
+	}, cloudWatchLogs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start query: %v", err)
+		return nil, err
 	}
-
-	queryId := queryResult.QueryId
-	var queryResults []*cloudwatchlogs.GetQueryResultsOutput // Declare queryResults outside the loop
-	for {
-		// Check query status
-		queryStatusInput := &cloudwatchlogs.GetQueryResultsInput{
-			QueryId: queryId,
-		}
-
-		queryResult, err := cloudWatchLogs.GetQueryResults(queryStatusInput)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get query results: %v", err)
-		}
-
-		queryResults = append(queryResults, queryResult)
-
-		if *queryResult.Status != "Complete" {
-			time.Sleep(5 * time.Second) // wait before querying again
-			continue
-		}
-
-		break // exit loop if query is complete
-	}
-
-	return queryResults, nil
+	return []*cloudwatchlogs.GetQueryResultsOutput{result}, nil
 }
 
 func FiltercloudWatchLogs(clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, query string) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	// Initialize CloudWatch Logs client
-	cloudWatchLogs := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH_LOG).(*cloudwatchlogs.CloudWatchLogs)
-
-	// Construct input parameters
-	params := &cloudwatchlogs.StartQueryInput{
-		LogGroupName: aws.String(logGroupName),
-		StartTime:    aws.Int64(startTime.Unix() * 1000),
-		EndTime:      aws.Int64(endTime.Unix() * 1000),
-		QueryString:  aws.String(query),
-	}
-
-	// Start the query
-	queryResult, err := cloudWatchLogs.StartQuery(params)
-	if err != nil {
-		return nil, fmt.Errorf("failed to start query: %v", err)
-	}
-
-	queryId := queryResult.QueryId
-	queryStatus := ""
-	var queryResults []*cloudwatchlogs.GetQueryResultsOutput // Declare queryResults outside the loop
-	for queryStatus != "Complete" {
-		// Check query status
-		queryStatusInput := &cloudwatchlogs.GetQueryResultsInput{
-			QueryId: queryId,
-		}
-
-		queryResult, err := cloudWatchLogs.GetQueryResults(queryStatusInput) // Assign value to queryResults
-		if err != nil {
-			return nil, fmt.Errorf("failed to get query results: %v", err)
-		}
-
-		queryStatus = aws.StringValue(queryResult.Status)
-		time.Sleep(1 * time.Second) // Wait for a second before checking status again
-
-		queryResults = append(queryResults, queryResult)
-	}
-
-	return queryResults, nil
+	return GetLogsData(clientAuth, startTime, endTime, logGroupName, query, nil)
 }
 
 func ProcessQueryResult(results []*cloudwatchlogs.GetQueryResultsOutput) []*cloudwatchlogs.GetQueryResultsOutput {
@@ -4,48 +4,39 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 )
 
-func GetMetricData(clientAuth *model.Auth, instanceID, elementType string, metricName string, startTime, endTime *time.Time, statistic string, dimensionsName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetMetricData(req *PanelRequest, instanceID, elementType string, metricName string, startTime, endTime *time.Time, statistic string, dimensionsName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for instance %s in namespace %s from %v to %v", instanceID, elementType, startTime, endTime)
-	input := &cloudwatch.GetMetricDataInput{
-		EndTime:   endTime,
-		StartTime: startTime,
-		MetricDataQueries: []*cloudwatch.MetricDataQuery{
-			{
-				Id: aws.String("m1"),
-				MetricStat: &cloudwatch.MetricStat{
-
-					Metric: &cloudwatch.Metric{
-						Dimensions: []*cloudwatch.Dimension{
-							{
-								Name:  aws.String(dimensionsName),
-								Value: aws.String(instanceID),
-							},
-						},
-						MetricName: aws.String(metricName),
-						Namespace:  aws.String(elementType),
-					},
-					Period: aws.Int64(300),
-					Stat:   aws.String(statistic),
-				},
-			},
-		},
-	}
-	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+	query := req.MetricQuery(startTime, endTime)
+	id := query.AddMetric("m1", elementType, metricName, statistic, Dimension(dimensionsName, instanceID))
+	result, err := query.Execute(req.ClientAuth, cloudWatchClient)
+	if err != nil {
+		return nil, err
 	}
+	return result.Output(id), nil
+}
 
-	result, err := cloudWatchClient.GetMetricData(input)
+// GetMetricStatistics fetches several statistics of one metric in a single
+// round trip and returns the output of each keyed by statistic.
+func GetMetricStatistics(req *PanelRequest, instanceID, elementType string, metricName string, startTime, endTime *time.Time, statistics []string, dimensionsName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (map[string]*cloudwatch.GetMetricDataOutput, error) {
+	log.Printf("Getting %v of %s for instance %s in namespace %s from %v to %v", statistics, metricName, instanceID, elementType, startTime, endTime)
+	query := req.MetricQuery(startTime, endTime)
+	ids := make(map[string]string, len(statistics))
+	for _, statistic := range statistics {
+		ids[statistic] = query.AddMetric("", elementType, metricName, statistic, Dimension(dimensionsName, instanceID))
+	}
+	result, err := query.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
 		return nil, err
 	}
-
-	return result, nil
+	outputs := make(map[string]*cloudwatch.GetMetricDataOutput, len(statistics))
+	for statistic, id := range ids {
+		outputs[statistic] = result.Output(id)
+	}
+	return outputs, nil
 }
 
 //
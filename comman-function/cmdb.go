@@ -0,0 +1,203 @@
+package comman_function
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+)
+
+// DefaultCmdbCacheTtl is how long a CMDB cloud element is reused before it is
+// looked up again.
+const DefaultCmdbCacheTtl = 10 * time.Minute
+
+// MappedElement is an entry of an element map file. It stands in for the CMDB
+// record of its element id.
+type MappedElement struct {
+	InstanceId string `json:"instanceId"`
+	LogGroup   string `json:"logGroup"`
+	Region     string `json:"region"`
+	Account    string `json:"account"`
+}
+
+// CloudElement returns the CMDB record the entry stands in for. The account
+// is kept as the landing zone, which is the aws account of an element.
+func (m *MappedElement) CloudElement(elementId string) *model.CloudElement {
+	element := &model.CloudElement{
+		InstanceId:  m.InstanceId,
+		LogGroup:    m.LogGroup,
+		LandingZone: m.Account,
+	}
+	fmt.Sscan(elementId, &element.Id)
+	return element
+}
+
+// LoadElementMap reads an element map file, yaml or json, keyed by element id:
+//
+//	"1234":
+//	  instanceId: i-0abc
+//	  logGroup: CloudTrail/DefaultLogGroup
+//	  region: us-east-1
+//	  account: "123456789012"
+func LoadElementMap(path string) (map[string]*MappedElement, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading element map: %w", err)
+	}
+	elements := map[string]*MappedElement{}
+	if strings.HasSuffix(path, ".json") {
+		err = json.Unmarshal(data, &elements)
+	} else {
+		err = UnmarshalYaml(data, &elements)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing element map %s: %w", path, err)
+	}
+	return elements, nil
+}
+
+// CmdbResolver resolves element ids to cloud elements. The element map is
+// consulted first, then the in-process cache, then the cache files in
+// CacheDir and last the CMDB. A TTL of 0 turns both caches off, as do record
+// and replay mode so that every lookup reaches the fixtures.
+type CmdbResolver struct {
+	Elements map[string]*MappedElement
+	TTL      time.Duration
+	// CacheDir keeps looked up elements across runs. Empty keeps them in
+	// process only.
+	CacheDir string
+
+	mu     sync.Mutex
+	cached map[string]*cachedElement
+}
+
+// cachedElement is an element in the in-process cache and the content of a
+// cache file.
+type cachedElement struct {
+	CmdbApiUrl string              `json:"cmdbApiUrl"`
+	ElementId  string              `json:"elementId"`
+	FetchedAt  time.Time           `json:"fetchedAt"`
+	Element    *model.CloudElement `json:"element"`
+}
+
+func NewCmdbResolver(elements map[string]*MappedElement, ttl time.Duration, cacheDir string) *CmdbResolver {
+	return &CmdbResolver{Elements: elements, TTL: ttl, CacheDir: cacheDir}
+}
+
+// Mapped returns the element map entry of elementId, nil when there is none.
+func (r *CmdbResolver) Mapped(elementId string) *MappedElement {
+	return r.Elements[elementId]
+}
+
+// Resolve returns the cloud element of elementId from the CMDB at cmdbApiUrl.
+func (r *CmdbResolver) Resolve(cmdbApiUrl, elementId string) (*model.CloudElement, error) {
+	if mapped := r.Mapped(elementId); mapped != nil {
+		log.Printf("Using element map entry of element %s", elementId)
+		return mapped.CloudElement(elementId), nil
+	}
+	if r.TTL <= 0 || Replaying() || Recording() {
+		return cloudElementLookup(cmdbApiUrl, elementId)
+	}
+
+	key := cmdbCacheKey(cmdbApiUrl, elementId)
+	now := time.Now()
+	r.mu.Lock()
+	cached := r.cached[key]
+	r.mu.Unlock()
+	if cached == nil && r.CacheDir != "" {
+		cached = r.readCacheFile(key)
+	}
+	if cached != nil && now.Sub(cached.FetchedAt) < r.TTL {
+		r.remember(key, cached)
+		log.Printf("Using cached cloud element %s fetched at %s", elementId, cached.FetchedAt.Format(time.RFC3339))
+		return cached.Element, nil
+	}
+
+	element, err := cloudElementLookup(cmdbApiUrl, elementId)
+	if err != nil {
+		return nil, err
+	}
+	cached = &cachedElement{CmdbApiUrl: cmdbApiUrl, ElementId: elementId, FetchedAt: now, Element: element}
+	r.remember(key, cached)
+	if r.CacheDir != "" {
+		r.writeCacheFile(key, cached)
+	}
+	return element, nil
+}
+
+func (r *CmdbResolver) remember(key string, cached *cachedElement) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cached == nil {
+		r.cached = map[string]*cachedElement{}
+	}
+	r.cached[key] = cached
+}
+
+func (r *CmdbResolver) readCacheFile(key string) *cachedElement {
+	data, err := os.ReadFile(filepath.Join(r.CacheDir, key+".json"))
+	if err != nil {
+		return nil
+	}
+	var cached cachedElement
+	if err := json.Unmarshal(data, &cached); err != nil || cached.Element == nil {
+		return nil
+	}
+	return &cached
+}
+
+// writeCacheFile stores cached in the cache dir. A cache that cannot be
+// written is only logged.
+func (r *CmdbResolver) writeCacheFile(key string, cached *cachedElement) {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		log.Printf("error writing cmdb cache: %v", err)
+		return
+	}
+	writeFileAtomic(filepath.Join(r.CacheDir, key+".json"), data)
+}
+
+func cmdbCacheKey(cmdbApiUrl, elementId string) string {
+	sum := sha256.Sum256([]byte(cmdbApiUrl + "\x00" + elementId))
+	return "cloud-element-" + fixtureNameSanitizer.ReplaceAllString(elementId, "_") + "-" + hex.EncodeToString(sum[:8])
+}
+
+// DefaultCmdbCacheDir is the cmdb directory in the user cache dir, empty when
+// the platform has none.
+func DefaultCmdbCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "awsx-getelementdetails", "cmdb")
+}
+
+var (
+	cmdbResolverMu sync.RWMutex
+	cmdbResolver   = NewCmdbResolver(nil, DefaultCmdbCacheTtl, "")
+)
+
+// SetCmdbResolver replaces the resolver GetCloudElement uses and returns a
+// func restoring the previous one.
+func SetCmdbResolver(resolver *CmdbResolver) (restore func()) {
+	cmdbResolverMu.Lock()
+	previous := cmdbResolver
+	cmdbResolver = resolver
+	cmdbResolverMu.Unlock()
+	return func() { SetCmdbResolver(previous) }
+}
+
+// Cmdb returns the current CmdbResolver.
+func Cmdb() *CmdbResolver {
+	cmdbResolverMu.RLock()
+	defer cmdbResolverMu.RUnlock()
+	return cmdbResolver
+}
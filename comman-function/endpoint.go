@@ -0,0 +1,54 @@
+package comman_function
+
+import (
+	"log"
+	"sync"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+var (
+	endpointMu  sync.RWMutex
+	endpointUrl string
+)
+
+// SetEndpointUrl sends every sdk call to url instead of aws, e.g. to the
+// mockaws server or localstack. Clients are signed with the access key of the
+// auth when there is one and are anonymous otherwise. It returns a func
+// restoring the previous url and client provider.
+func SetEndpointUrl(url string) (restore func()) {
+	endpointMu.Lock()
+	previous := endpointUrl
+	endpointUrl = url
+	endpointMu.Unlock()
+
+	restoreClients := SetClientProvider(NewSessionClientProvider(func(auth model.Auth) *session.Session {
+		creds := credentials.AnonymousCredentials
+		if auth.AccessKey != "" {
+			creds = credentials.NewStaticCredentials(auth.AccessKey, auth.SecretKey, "")
+		}
+		return session.Must(session.NewSession(&aws.Config{
+			Region:           aws.String(auth.Region),
+			Endpoint:         aws.String(url),
+			Credentials:      creds,
+			S3ForcePathStyle: aws.Bool(true),
+		}))
+	}))
+	log.Printf("sending aws calls to %s", url)
+	return func() {
+		endpointMu.Lock()
+		endpointUrl = previous
+		endpointMu.Unlock()
+		restoreClients()
+	}
+}
+
+// EndpointUrl returns the url set by SetEndpointUrl.
+func EndpointUrl() string {
+	endpointMu.RLock()
+	defer endpointMu.RUnlock()
+	return endpointUrl
+}
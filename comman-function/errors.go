@@ -0,0 +1,250 @@
+package comman_function
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// Error types, the type of the json error envelope of a failed command.
+const (
+	ErrorTypeValidation = "validation"
+	ErrorTypeAuth       = "auth"
+	ErrorTypeCmdb       = "cmdb"
+	ErrorTypeAwsApi     = "aws_api"
+	ErrorTypeNoData     = "no_data"
+	ErrorTypeInternal   = "internal"
+)
+
+// Exit codes of a failed command by error type.
+const (
+	ExitInternal   = 1
+	ExitValidation = 2
+	ExitAuth       = 3
+	ExitCmdb       = 4
+	ExitAwsApi     = 5
+	ExitNoData     = 6
+)
+
+var exitCodes = map[string]int{
+	ErrorTypeValidation: ExitValidation,
+	ErrorTypeAuth:       ExitAuth,
+	ErrorTypeCmdb:       ExitCmdb,
+	ErrorTypeAwsApi:     ExitAwsApi,
+	ErrorTypeNoData:     ExitNoData,
+	ErrorTypeInternal:   ExitInternal,
+}
+
+// Sentinels matched by errors.Is against a LogsQueryError of the same status.
+var (
+	ErrLogsQueryFailed    = errors.New("logs query failed")
+	ErrLogsQueryCancelled = errors.New("logs query cancelled")
+	ErrLogsQueryTimeout   = errors.New("logs query timed out")
+)
+
+// ValidationError reports invalid inputs, e.g. a malformed flag or an unknown
+// query.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// AuthError reports that no aws credentials could be obtained.
+type AuthError struct {
+	Err error
+}
+
+func (e *AuthError) Error() string {
+	return "authentication failed: " + e.Err.Error()
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// CmdbError reports that a cloud element could not be resolved through the CMDB.
+type CmdbError struct {
+	ElementId string
+	Err       error
+}
+
+func (e *CmdbError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CmdbError) Unwrap() error {
+	return e.Err
+}
+
+// AwsApiError reports a failed aws call: an error answered by aws, which Code
+// and StatusCode are of, a failed logs insights query or a call that could
+// not be sent.
+type AwsApiError struct {
+	Code       string
+	StatusCode int
+	RequestId  string
+	Err        error
+}
+
+func (e *AwsApiError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AwsApiError) Unwrap() error {
+	return e.Err
+}
+
+// NoDataError reports a panel that returned nothing for the inputs.
+type NoDataError struct {
+	Panel string
+}
+
+func (e *NoDataError) Error() string {
+	return fmt.Sprintf("panel %s returned no data", e.Panel)
+}
+
+// asAwsApiError wraps err in an AwsApiError when it holds an aws sdk error or
+// a LogsQueryError and is not typed already.
+func asAwsApiError(err error) error {
+	var typed *AwsApiError
+	if err == nil || errors.As(err, &typed) || ErrorTypeOf(err) != ErrorTypeAwsApi {
+		return err
+	}
+	apiErr := &AwsApiError{Err: err}
+	var reqErr awserr.RequestFailure
+	var awsErr awserr.Error
+	var logsErr *LogsQueryError
+	switch {
+	case errors.As(err, &reqErr):
+		apiErr.Code, apiErr.StatusCode, apiErr.RequestId = reqErr.Code(), reqErr.StatusCode(), reqErr.RequestID()
+	case errors.As(err, &awsErr):
+		apiErr.Code = awsErr.Code()
+	case errors.As(err, &logsErr):
+		apiErr.Code = "LogsQuery" + logsErr.Status
+	}
+	return apiErr
+}
+
+// ErrorTypeOf returns the type of err: the one of the typed error it holds,
+// ErrorTypeAwsApi for aws sdk errors and LogsQueryErrors, else
+// ErrorTypeInternal.
+func ErrorTypeOf(err error) string {
+	var validationErr *ValidationError
+	var authErr *AuthError
+	var cmdbErr *CmdbError
+	var apiErr *AwsApiError
+	var noDataErr *NoDataError
+	switch {
+	case errors.As(err, &validationErr):
+		return ErrorTypeValidation
+	case errors.As(err, &authErr):
+		return ErrorTypeAuth
+	case errors.As(err, &cmdbErr):
+		return ErrorTypeCmdb
+	case errors.As(err, &apiErr):
+		return ErrorTypeAwsApi
+	case errors.As(err, &noDataErr):
+		return ErrorTypeNoData
+	}
+	var awsErr awserr.Error
+	var logsErr *LogsQueryError
+	if errors.As(err, &awsErr) || errors.As(err, &logsErr) {
+		return ErrorTypeAwsApi
+	}
+	return ErrorTypeInternal
+}
+
+// ExitCodeOf returns the exit code of a command failed with err, 0 when err
+// is nil.
+func ExitCodeOf(err error) int {
+	if err == nil {
+		return 0
+	}
+	return exitCodes[ErrorTypeOf(err)]
+}
+
+// ErrorEnvelope is written instead of the panel output when a command fails
+// in json mode.
+type ErrorEnvelope struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes the error of an ErrorEnvelope. Code and StatusCode
+// are the ones of the aws error of aws_api errors.
+type ErrorDetail struct {
+	Type       string `json:"type"`
+	Message    string `json:"message"`
+	ExitCode   int    `json:"exitCode"`
+	Code       string `json:"code,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	ElementId  string `json:"elementId,omitempty"`
+}
+
+// NewErrorEnvelope describes err in an ErrorEnvelope.
+func NewErrorEnvelope(err error) ErrorEnvelope {
+	err = asAwsApiError(err)
+	detail := ErrorDetail{Type: ErrorTypeOf(err), Message: err.Error(), ExitCode: ExitCodeOf(err)}
+	var apiErr *AwsApiError
+	if errors.As(err, &apiErr) {
+		detail.Code, detail.StatusCode = apiErr.Code, apiErr.StatusCode
+	}
+	var cmdbErr *CmdbError
+	if errors.As(err, &cmdbErr) {
+		detail.ElementId = cmdbErr.ElementId
+	}
+	return ErrorEnvelope{Error: detail}
+}
+
+// WriteErrorEnvelope writes the ErrorEnvelope of err to w as json.
+func WriteErrorEnvelope(w io.Writer, err error) error {
+	data, err := json.Marshal(NewErrorEnvelope(err))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// LogsQueryError reports a logs insights query that ended without completing,
+// either with a Failed, Cancelled or Timeout status from CloudWatch or because
+// the caller's context ended, in which case Err is the context error.
+type LogsQueryError struct {
+	QueryId string
+	Status  string
+	Err     error
+}
+
+func (e *LogsQueryError) Error() string {
+	msg := fmt.Sprintf("logs query %s ended with status %s", e.QueryId, e.Status)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *LogsQueryError) Unwrap() error {
+	return e.Err
+}
+
+func (e *LogsQueryError) Is(target error) bool {
+	switch target {
+	case ErrLogsQueryFailed:
+		return e.Status == cloudwatchlogs.QueryStatusFailed
+	case ErrLogsQueryCancelled:
+		return e.Status == cloudwatchlogs.QueryStatusCancelled
+	case ErrLogsQueryTimeout:
+		return e.Status == cloudwatchlogs.QueryStatusTimeout
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package comman_function
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// DefaultConcurrency bounds the calls of a fan out when the request does not
+// set Concurrency. The retry policies rate limit the calls further.
+const DefaultConcurrency = 10
+
+// PartialError is returned by FanOut when some of the items failed. The
+// results of the other items are still returned.
+type PartialError struct {
+	Total  int
+	Errors []error
+}
+
+func (e *PartialError) Error() string {
+	messages := make([]string, 0, 3)
+	for i, err := range e.Errors {
+		if i == 3 {
+			messages = append(messages, fmt.Sprintf("and %d more", len(e.Errors)-i))
+			break
+		}
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d of %d failed: %s", len(e.Errors), e.Total, strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed items.
+func (e *PartialError) Unwrap() []error {
+	return e.Errors
+}
+
+// Is and As match the errors of the failed items. errors.Is and errors.As
+// only walk Unwrap() []error from Go 1.20 on.
+func (e *PartialError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *PartialError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPartial reports whether err is a *PartialError of a fan out some items of
+// which succeeded, so that its results can still be shown.
+func IsPartial(err error) bool {
+	var partial *PartialError
+	return errors.As(err, &partial) && len(partial.Errors) < partial.Total
+}
+
+// FanOut calls fn for every item with at most req.Concurrency calls at once
+// and returns the results of the items that succeeded, in the order of items.
+// fn is called with the context of req; once it is done no more items are
+// started and FanOut returns its error. Otherwise, when items fail the error
+// is a *PartialError, and when all of them fail the results are empty. A
+// panicking fn fails its item only. Results missing some items are reported
+// as partial in the metadata of the panel.
+func FanOut[T, R any](req *PanelRequest, items []T, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	ctx := req.Context()
+	limit := req.Concurrency
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	slots := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("Fan out call panicked: %v\n%s", r, debug.Stack())
+					errs[i] = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			results[i], errs[i] = fn(ctx, item)
+		}(i, item)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	succeeded := make([]R, 0, len(items))
+	partial := &PartialError{Total: len(items)}
+	for i, err := range errs {
+		if err != nil {
+			partial.Errors = append(partial.Errors, err)
+			continue
+		}
+		succeeded = append(succeeded, results[i])
+	}
+	if len(partial.Errors) > 0 {
+		if len(succeeded) > 0 {
+			notePartial(ctx)
+		}
+		return succeeded, partial
+	}
+	return succeeded, nil
+}
@@ -0,0 +1,27 @@
+package comman_function_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+func TestPartialErrorType(t *testing.T) {
+	apiErr := &comman_function.AwsApiError{Code: "Throttling", Err: errors.New("rate exceeded")}
+	err := &comman_function.PartialError{Total: 3, Errors: []error{errors.New("timeout"), apiErr}}
+
+	if got := comman_function.ErrorTypeOf(err); got != comman_function.ErrorTypeAwsApi {
+		t.Errorf("ErrorTypeOf = %s, want %s", got, comman_function.ErrorTypeAwsApi)
+	}
+	if !errors.Is(err, apiErr) {
+		t.Error("errors.Is does not match the error of a failed item")
+	}
+	var target *comman_function.AwsApiError
+	if !err.As(&target) || target != apiErr {
+		t.Errorf("As = %v, want the AwsApiError of the failed item", target)
+	}
+	if err.Is(comman_function.ErrLogsQueryFailed) {
+		t.Error("Is matches an error no item failed with")
+	}
+}
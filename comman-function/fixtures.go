@@ -0,0 +1,345 @@
+package comman_function
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/Appkube-awsx/awsx-common/authenticate"
+	"github.com/Appkube-awsx/awsx-common/cmdb"
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+// ErrFixtureNotFound is returned in replay mode for a call that was not
+// recorded.
+var ErrFixtureNotFound = errors.New("fixture not found")
+
+// fixtureVolatileParams are left out of the request key so a run with a
+// relative time range replays the fixtures recorded at another time.
+var fixtureVolatileParams = []string{"StartTime", "EndTime"}
+
+var fixtureNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// cloudElementLookup fetches a cloud element from the CMDB. Record and replay
+// mode wrap it.
+var cloudElementLookup = cmdb.GetCloudElementData
+
+// fixture is one recorded sdk call.
+type fixture struct {
+	Service     string          `json:"service"`
+	Operation   string          `json:"operation"`
+	Request     json.RawMessage `json:"request"`
+	StatusCode  int             `json:"statusCode"`
+	ContentType string          `json:"contentType,omitempty"`
+	Body        string          `json:"body"`
+}
+
+// cmdbFixture is one recorded CMDB cloud element lookup.
+type cmdbFixture struct {
+	Request  string              `json:"request"`
+	Response *model.CloudElement `json:"response"`
+}
+
+type fixtureStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+var (
+	fixtureMu      sync.RWMutex
+	replayFixtures *fixtureStore
+	recordFixtures *fixtureStore
+)
+
+// EnableRecording records every sdk call and CMDB lookup made from now on to
+// dir, so that EnableReplay can answer them later without network access.
+func EnableRecording(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating fixture dir: %w", err)
+	}
+	store := &fixtureStore{dir: dir}
+	SetClientProvider(&recordingClientProvider{base: Clients(), store: store})
+
+	lookup := cloudElementLookup
+	cloudElementLookup = func(apiUrl, elementId string) (*model.CloudElement, error) {
+		element, err := lookup(apiUrl, elementId)
+		if err != nil {
+			return nil, err
+		}
+		return element, store.write(cmdbFixturePath(elementId), cmdbFixture{Request: cmdbRequest(elementId), Response: element})
+	}
+	fixtureMu.Lock()
+	recordFixtures = store
+	fixtureMu.Unlock()
+	log.Printf("recording aws and cmdb calls to %s", dir)
+	return nil
+}
+
+// EnableReplay answers every sdk call and CMDB lookup from the fixtures in dir
+// recorded by EnableRecording. No credentials are needed.
+func EnableReplay(dir string) error {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("fixture dir %s not found", dir)
+	}
+	store := &fixtureStore{dir: dir}
+	SetClientProvider(NewSessionClientProvider(func(auth model.Auth) *session.Session {
+		sess := session.Must(session.NewSession(&aws.Config{
+			Region:      aws.String(auth.Region),
+			Credentials: credentials.AnonymousCredentials,
+			MaxRetries:  aws.Int(0),
+		}))
+		sess.Handlers.Send.Clear()
+		sess.Handlers.Send.PushBackNamed(request.NamedHandler{Name: "awsx.ReplayFixture", Fn: store.replayHandler})
+		return sess
+	}))
+
+	cloudElementLookup = func(apiUrl, elementId string) (*model.CloudElement, error) {
+		var recorded cmdbFixture
+		if err := store.read(cmdbFixturePath(elementId), &recorded); err != nil {
+			return nil, err
+		}
+		return recorded.Response, nil
+	}
+	fixtureMu.Lock()
+	replayFixtures = store
+	fixtureMu.Unlock()
+	log.Printf("replaying aws and cmdb calls from %s", dir)
+	return nil
+}
+
+// Replaying reports whether EnableReplay is active.
+func Replaying() bool {
+	fixtureMu.RLock()
+	defer fixtureMu.RUnlock()
+	return replayFixtures != nil
+}
+
+// Recording reports whether EnableRecording is active.
+func Recording() bool {
+	fixtureMu.RLock()
+	defer fixtureMu.RUnlock()
+	return recordFixtures != nil
+}
+
+// Authenticate resolves the aws credentials of param. In replay mode, or with
+// an endpoint url, nothing is called: the region and any access key given on
+// the command line are used as they are.
+func Authenticate(param model.CommandParam) (bool, *model.Auth, error) {
+	if Replaying() || EndpointUrl() != "" {
+		region := param.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		return true, &model.Auth{Region: region, AccessKey: param.AccessKey, SecretKey: param.SecretKey}, nil
+	}
+	return authenticate.DoAuthenticate(param)
+}
+
+func cmdbRequest(elementId string) string {
+	return "/cloud-element/search?id=" + url.QueryEscape(elementId)
+}
+
+func cmdbFixturePath(elementId string) string {
+	return filepath.Join("cmdb", "cloud-element-"+fixtureNameSanitizer.ReplaceAllString(elementId, "_")+".json")
+}
+
+// fixtureKey returns the normalized request of r and the path of its fixture.
+func fixtureKey(r *request.Request) (json.RawMessage, string, error) {
+	params, err := json.Marshal(r.Params)
+	if err != nil {
+		return nil, "", fmt.Errorf("error marshalling %s request: %w", r.Operation.Name, err)
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(params, &normalized); err != nil {
+		return nil, "", fmt.Errorf("error normalizing %s request: %w", r.Operation.Name, err)
+	}
+	for _, name := range fixtureVolatileParams {
+		delete(normalized, name)
+	}
+	// json.Marshal sorts map keys, so equal requests give equal keys.
+	key, err := json.Marshal(normalized)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(append([]byte(r.ClientInfo.ServiceName+"/"+r.Operation.Name+"/"), key...))
+	name := r.Operation.Name + "-" + hex.EncodeToString(sum[:6]) + ".json"
+	return key, filepath.Join(fixtureNameSanitizer.ReplaceAllString(r.ClientInfo.ServiceName, "_"), name), nil
+}
+
+func (s *fixtureStore) write(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshalling fixture %s: %w", path, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	full := filepath.Join(s.dir, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return fmt.Errorf("error creating fixture dir: %w", err)
+	}
+	// A repeated call, e.g. a GetQueryResults poll, keeps its last response.
+	if err := os.WriteFile(full, data, 0o644); err != nil {
+		return fmt.Errorf("error writing fixture %s: %w", path, err)
+	}
+	return nil
+}
+
+func (s *fixtureStore) read(path string, value interface{}) error {
+	data, err := os.ReadFile(filepath.Join(s.dir, path))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrFixtureNotFound, path)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading fixture %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("error parsing fixture %s: %w", path, err)
+	}
+	return nil
+}
+
+// recordHandler runs after the sdk sent a request and saves the response.
+func (s *fixtureStore) recordHandler(r *request.Request) {
+	if r.HTTPResponse == nil || r.HTTPResponse.Body == nil {
+		return
+	}
+	body, err := io.ReadAll(r.HTTPResponse.Body)
+	r.HTTPResponse.Body.Close()
+	r.HTTPResponse.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		log.Printf("error reading %s response for recording: %v", r.Operation.Name, err)
+		return
+	}
+	key, path, err := fixtureKey(r)
+	if err == nil {
+		err = s.write(path, fixture{
+			Service:     r.ClientInfo.ServiceName,
+			Operation:   r.Operation.Name,
+			Request:     key,
+			StatusCode:  r.HTTPResponse.StatusCode,
+			ContentType: r.HTTPResponse.Header.Get("Content-Type"),
+			Body:        string(body),
+		})
+	}
+	if err != nil {
+		log.Printf("error recording %s: %v", r.Operation.Name, err)
+	}
+}
+
+// replayHandler replaces the sdk send handler with the recorded response.
+func (s *fixtureStore) replayHandler(r *request.Request) {
+	_, path, err := fixtureKey(r)
+	var recorded fixture
+	if err == nil {
+		err = s.read(path, &recorded)
+	}
+	if err != nil {
+		r.Error = awserr.New("FixtureNotFound", fmt.Sprintf("no fixture for %s.%s", r.ClientInfo.ServiceName, r.Operation.Name), err)
+		r.Retryable = aws.Bool(false)
+		return
+	}
+	header := http.Header{}
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+	r.HTTPResponse = &http.Response{
+		Status:        http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+	}
+}
+
+// sdkHandlers returns the request handlers of an sdk client, or nil for a
+// client that is not an sdk client, e.g. a fake.
+func sdkHandlers(serviceClient interface{}) *request.Handlers {
+	if c := sdkClientOf(serviceClient); c != nil {
+		return &c.Handlers
+	}
+	return nil
+}
+
+// recordingClientProvider adds a recording handler to the clients of base.
+type recordingClientProvider struct {
+	base  ClientProvider
+	store *fixtureStore
+}
+
+func (p *recordingClientProvider) record(client interface{}) {
+	if handlers := sdkHandlers(client); handlers != nil {
+		handlers.Send.PushBackNamed(request.NamedHandler{Name: "awsx.RecordFixture", Fn: p.store.recordHandler})
+	}
+}
+
+func (p *recordingClientProvider) CloudWatch(auth model.Auth) cloudwatchiface.CloudWatchAPI {
+	client := p.base.CloudWatch(auth)
+	p.record(client)
+	return client
+}
+
+func (p *recordingClientProvider) CloudWatchLogs(auth model.Auth) cloudwatchlogsiface.CloudWatchLogsAPI {
+	client := p.base.CloudWatchLogs(auth)
+	p.record(client)
+	return client
+}
+
+func (p *recordingClientProvider) EC2(auth model.Auth) ec2iface.EC2API {
+	client := p.base.EC2(auth)
+	p.record(client)
+	return client
+}
+
+func (p *recordingClientProvider) Lambda(auth model.Auth) lambdaiface.LambdaAPI {
+	client := p.base.Lambda(auth)
+	p.record(client)
+	return client
+}
+
+func (p *recordingClientProvider) ELBV2(auth model.Auth) elbv2iface.ELBV2API {
+	client := p.base.ELBV2(auth)
+	p.record(client)
+	return client
+}
+
+func (p *recordingClientProvider) APIGateway(auth model.Auth) apigatewayiface.APIGatewayAPI {
+	client := p.base.APIGateway(auth)
+	p.record(client)
+	return client
+}
+
+func (p *recordingClientProvider) APIGatewayV2(auth model.Auth) apigatewayv2iface.ApiGatewayV2API {
+	client := p.base.APIGatewayV2(auth)
+	p.record(client)
+	return client
+}
+
+func (p *recordingClientProvider) AutoScaling(auth model.Auth) autoscalingiface.AutoScalingAPI {
+	client := p.base.AutoScaling(auth)
+	p.record(client)
+	return client
+}
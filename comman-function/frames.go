@@ -0,0 +1,335 @@
+package comman_function
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+)
+
+// Frame is a grafana data frame in the data plane json encoding the grafana
+// plugin sdk reads with data.UnmarshalJSON.
+type Frame struct {
+	Schema FrameSchema `json:"schema"`
+	Data   FrameData   `json:"data"`
+}
+
+type FrameSchema struct {
+	Name   string       `json:"name,omitempty"`
+	RefId  string       `json:"refId,omitempty"`
+	Meta   *FrameMeta   `json:"meta,omitempty"`
+	Fields []FrameField `json:"fields"`
+}
+
+type FrameMeta struct {
+	Type        string `json:"type"`
+	TypeVersion [2]int `json:"typeVersion"`
+	// Custom describes the datapoints of the series of the frame.
+	Custom *SeriesMetadata `json:"custom,omitempty"`
+}
+
+type FrameField struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	TypeInfo FrameTypeInfo     `json:"typeInfo"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Config   *FrameFieldConfig `json:"config,omitempty"`
+}
+
+type FrameTypeInfo struct {
+	Frame    string `json:"frame"`
+	Nullable bool   `json:"nullable,omitempty"`
+}
+
+type FrameFieldConfig struct {
+	DisplayNameFromDS string `json:"displayNameFromDS,omitempty"`
+	Unit              string `json:"unit,omitempty"`
+}
+
+// FrameData holds one column per schema field: epoch milliseconds for the
+// time field and numbers for the value field.
+type FrameData struct {
+	Values [][]interface{} `json:"values"`
+}
+
+// metricFrameType is the data plane type of the frames built here: one time
+// field and one number field with labels per frame.
+const metricFrameType = "timeseries-multi"
+
+// cloudWatchGrafanaUnits maps cloudwatch units to grafana unit ids.
+var cloudWatchGrafanaUnits = map[string]string{
+	cloudwatch.StandardUnitSeconds:         "s",
+	cloudwatch.StandardUnitMicroseconds:    "µs",
+	cloudwatch.StandardUnitMilliseconds:    "ms",
+	cloudwatch.StandardUnitBytes:           "decbytes",
+	cloudwatch.StandardUnitKilobytes:       "deckbytes",
+	cloudwatch.StandardUnitMegabytes:       "decmbytes",
+	cloudwatch.StandardUnitGigabytes:       "decgbytes",
+	cloudwatch.StandardUnitTerabytes:       "dectbytes",
+	cloudwatch.StandardUnitBits:            "decbits",
+	cloudwatch.StandardUnitPercent:         "percent",
+	cloudwatch.StandardUnitCount:           "short",
+	cloudwatch.StandardUnitBytesSecond:     "Bps",
+	cloudwatch.StandardUnitKilobytesSecond: "KBs",
+	cloudwatch.StandardUnitMegabytesSecond: "MBs",
+	cloudwatch.StandardUnitGigabytesSecond: "GBs",
+	cloudwatch.StandardUnitBitsSecond:      "bps",
+	cloudwatch.StandardUnitKilobitsSecond:  "Kbits",
+	cloudwatch.StandardUnitMegabitsSecond:  "Mbits",
+	cloudwatch.StandardUnitGigabitsSecond:  "Gbits",
+	cloudwatch.StandardUnitCountSecond:     "cps",
+	cloudwatch.StandardUnitNone:            "none",
+}
+
+// GrafanaUnit returns the grafana unit id of a cloudwatch unit. Without a
+// unit it is guessed from the metric name, e.g. CPUUtilization is a percent
+// and NetworkIn is bytes.
+func GrafanaUnit(cloudWatchUnit, metricName string) string {
+	if unit, ok := cloudWatchGrafanaUnits[cloudWatchUnit]; ok {
+		return unit
+	}
+	name := strings.ToLower(metricName)
+	switch {
+	case strings.Contains(name, "utilization") || strings.Contains(name, "percent"):
+		return "percent"
+	case strings.Contains(name, "bytes") || name == "networkin" || name == "networkout":
+		return "decbytes"
+	case strings.Contains(name, "latency") || strings.Contains(name, "duration"):
+		return "ms"
+	}
+	return ""
+}
+
+// MetricSeries describes the query a MetricDataResult answers.
+type MetricSeries struct {
+	Namespace  string
+	MetricName string
+	Stat       string
+	Unit       string
+	// Period is the period of the query in seconds.
+	Period     int64
+	Dimensions map[string]string
+}
+
+// SeriesSet holds the queries of the metric results of one panel run, so
+// MetricFrames can label the results with their metric and dimensions.
+type SeriesSet map[*cloudwatch.MetricDataResult]MetricSeries
+
+// Of returns the query of result.
+func (s SeriesSet) Of(result *cloudwatch.MetricDataResult) (MetricSeries, bool) {
+	series, ok := s[result]
+	return series, ok
+}
+
+// allSums reports whether every metric series of the set is a Sum.
+func (s SeriesSet) allSums() bool {
+	for _, series := range s {
+		if series.Stat != "Sum" {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// statOf is the stat of the series frame was built from, or "" when the
+// series of its metric and dimensions do not agree on one.
+func (s SeriesSet) statOf(frame Frame) string {
+	if len(frame.Schema.Fields) != 2 {
+		return ""
+	}
+	field := frame.Schema.Fields[1]
+	stat := ""
+	for _, series := range s {
+		if series.MetricName != field.Name || !sameDimensions(series.Dimensions, field.Labels) {
+			continue
+		}
+		if stat != "" && stat != series.Stat {
+			return ""
+		}
+		stat = series.Stat
+	}
+	return stat
+}
+
+func sameDimensions(dimensions, labels map[string]string) bool {
+	if len(dimensions) != len(labels) {
+		return false
+	}
+	for name, value := range dimensions {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// querySeries describes the MetricStat queries by id. Expressions have no
+// metric and are left out.
+func querySeries(queries []*cloudwatch.MetricDataQuery) map[string]MetricSeries {
+	seriesById := map[string]MetricSeries{}
+	for _, query := range queries {
+		if query == nil || query.Id == nil || query.MetricStat == nil || query.MetricStat.Metric == nil {
+			continue
+		}
+		stat, metric := query.MetricStat, query.MetricStat.Metric
+		series := MetricSeries{Dimensions: map[string]string{}}
+		series.Namespace = stringValue(metric.Namespace)
+		series.MetricName = stringValue(metric.MetricName)
+		series.Stat = stringValue(stat.Stat)
+		series.Unit = stringValue(stat.Unit)
+		if stat.Period != nil {
+			series.Period = *stat.Period
+		}
+		for _, dimension := range metric.Dimensions {
+			if dimension != nil && dimension.Name != nil {
+				series.Dimensions[*dimension.Name] = stringValue(dimension.Value)
+			}
+		}
+		seriesById[*query.Id] = series
+	}
+	return seriesById
+}
+
+// requestCloudWatchClient sends the GetMetricData calls of a panel with the
+// context of its request, which collects the query of every result for the
+// frames and metadata of the panel. See PanelRequest.CloudWatchClient.
+type requestCloudWatchClient struct {
+	cloudwatchiface.CloudWatchAPI
+	ctx context.Context
+}
+
+// metricQueryCallKey marks the calls of a MetricQuery, which notes its merged
+// results itself.
+type metricQueryCallKey struct{}
+
+func (c requestCloudWatchClient) GetMetricData(input *cloudwatch.GetMetricDataInput) (*cloudwatch.GetMetricDataOutput, error) {
+	return c.GetMetricDataWithContext(c.ctx, input)
+}
+
+func (c requestCloudWatchClient) GetMetricDataWithContext(ctx context.Context, input *cloudwatch.GetMetricDataInput, opts ...request.Option) (*cloudwatch.GetMetricDataOutput, error) {
+	output, err := c.CloudWatchAPI.GetMetricDataWithContext(ctx, input, opts...)
+	if output != nil && ctx.Value(metricQueryCallKey{}) == nil {
+		noteResults(ctx, input.MetricDataQueries, output.MetricDataResults)
+	}
+	return output, err
+}
+
+// MetricFrames converts the metric output of a panel into grafana frames, one
+// per series, with the refId set to the output key and labelled from series.
+// Values that are not metric output are returned unchanged.
+func MetricFrames(value interface{}, series SeriesSet) interface{} {
+	switch v := value.(type) {
+	case map[string]*cloudwatch.GetMetricDataOutput:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		frames := []Frame{}
+		for _, key := range keys {
+			if v[key] != nil {
+				frames = append(frames, resultFrames(key, v[key].MetricDataResults, series)...)
+			}
+		}
+		return frames
+	case *map[string]*cloudwatch.GetMetricDataOutput:
+		if v == nil {
+			return []Frame{}
+		}
+		return MetricFrames(*v, series)
+	case *cloudwatch.GetMetricDataOutput:
+		if v == nil {
+			return []Frame{}
+		}
+		return resultFrames("", v.MetricDataResults, series)
+	case []*cloudwatch.MetricDataResult:
+		return resultFrames("", v, series)
+	}
+	return value
+}
+
+func resultFrames(refId string, results []*cloudwatch.MetricDataResult, seriesSet SeriesSet) []Frame {
+	frames := make([]Frame, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			frames = append(frames, resultFrame(refId, result, seriesSet))
+		}
+	}
+	return frames
+}
+
+func resultFrame(refId string, result *cloudwatch.MetricDataResult, seriesSet SeriesSet) Frame {
+	if refId == "" {
+		refId = stringValue(result.Id)
+	}
+	series, _ := seriesSet.Of(result)
+	name := series.MetricName
+	if name == "" {
+		name = stringValue(result.Label)
+	}
+	if name == "" {
+		name = refId
+	}
+
+	value := FrameField{
+		Name:     name,
+		Type:     "number",
+		TypeInfo: FrameTypeInfo{Frame: "float64", Nullable: true},
+	}
+	if len(series.Dimensions) > 0 {
+		value.Labels = series.Dimensions
+	}
+	config := FrameFieldConfig{Unit: GrafanaUnit(series.Unit, name)}
+	if label := stringValue(result.Label); label != "" && label != name {
+		config.DisplayNameFromDS = label
+	}
+	if config != (FrameFieldConfig{}) {
+		value.Config = &config
+	}
+
+	// cloudwatch returns the newest datapoint first by default
+	points := make([]int, 0, len(result.Timestamps))
+	for i := range result.Timestamps {
+		if i < len(result.Values) && result.Timestamps[i] != nil {
+			points = append(points, i)
+		}
+	}
+	sort.SliceStable(points, func(a, b int) bool {
+		return result.Timestamps[points[a]].Before(*result.Timestamps[points[b]])
+	})
+	times := make([]interface{}, 0, len(points))
+	values := make([]interface{}, 0, len(points))
+	for _, i := range points {
+		times = append(times, result.Timestamps[i].UnixNano()/int64(time.Millisecond))
+		if result.Values[i] == nil {
+			values = append(values, nil)
+		} else {
+			values = append(values, *result.Values[i])
+		}
+	}
+
+	metadata := describeSeries(refId, result, series.Period)
+	return Frame{
+		Schema: FrameSchema{
+			Name:  name,
+			RefId: refId,
+			Meta:  &FrameMeta{Type: metricFrameType, TypeVersion: [2]int{0, 1}, Custom: &metadata},
+			Fields: []FrameField{
+				{Name: "Time", Type: "time", TypeInfo: FrameTypeInfo{Frame: "time.Time"}},
+				value,
+			},
+		},
+		Data: FrameData{Values: [][]interface{}{times, values}},
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,156 @@
+package comman_function
+
+import (
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Identifiers a panel can be scoped by. Each is named after the CloudWatch
+// dimension it fills.
+const (
+	IdentifierInstanceId           = "InstanceId"
+	IdentifierClusterName          = "ClusterName"
+	IdentifierFunctionName         = "FunctionName"
+	IdentifierDBInstanceIdentifier = "DBInstanceIdentifier"
+	IdentifierLoadBalancer         = "LoadBalancer"
+	IdentifierApiName              = "ApiName"
+	IdentifierStateMachineArn      = "StateMachineArn"
+	IdentifierBucketName           = "BucketName"
+)
+
+// Sources an identity was resolved from.
+const (
+	IdentitySourceFlag       = "flag"
+	IdentitySourceElementMap = "elementMap"
+	IdentitySourceCmdb       = "cmdb"
+)
+
+// identifierFlags are the inputs, flags, query parameters or batch keys, that
+// name each identifier, in order of preference.
+var identifierFlags = map[string][]string{
+	IdentifierInstanceId:           {"instanceId"},
+	IdentifierClusterName:          {"clusterName", "ClusterName"},
+	IdentifierFunctionName:         {"functionName", "FunctionName"},
+	IdentifierDBInstanceIdentifier: {"dbInstanceIdentifier", "DBInstanceIdentifier"},
+	IdentifierLoadBalancer:         {"loadBalancer", "LoadBalancer"},
+	IdentifierApiName:              {"apiName", "ApiName"},
+	IdentifierStateMachineArn:      {"stateMachineArn", "StateMachineArn"},
+	IdentifierBucketName:           {"bucketName", "BucketName"},
+}
+
+// Identity is the resolved identifier of the element a panel reads.
+type Identity struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+var (
+	elementIdentifiersMu sync.RWMutex
+	elementIdentifiers   = map[string]string{}
+)
+
+// RegisterIdentifier declares the identifier of the elements of elementTypes,
+// e.g. FunctionName for Lambda. Element types without one use InstanceId.
+func RegisterIdentifier(elementTypes []string, name string) {
+	if _, ok := identifierFlags[name]; !ok {
+		panic(fmt.Sprintf("unknown identifier %q", name))
+	}
+	elementIdentifiersMu.Lock()
+	defer elementIdentifiersMu.Unlock()
+	for _, elementType := range elementTypes {
+		elementIdentifiers[elementType] = name
+	}
+}
+
+// ElementIdentifier returns the identifier declared for elementType.
+func ElementIdentifier(elementType string) string {
+	elementIdentifiersMu.RLock()
+	defer elementIdentifiersMu.RUnlock()
+	if name, ok := elementIdentifiers[elementType]; ok {
+		return name
+	}
+	return IdentifierInstanceId
+}
+
+// ResolveIdentity returns the identifier name of req. The input named after
+// it wins, then --instanceId, which every element type accepts, and last the
+// instance id of the cloud element from the element map or the CMDB.
+func ResolveIdentity(req *PanelRequest, name string) (*Identity, error) {
+	var identity *Identity
+	if value := req.identifier(name); value != "" {
+		identity = &Identity{Name: name, Value: value, Source: IdentitySourceFlag}
+	} else if req.InstanceId != "" {
+		identity = &Identity{Name: name, Value: req.InstanceId, Source: IdentitySourceFlag}
+	} else {
+		cmdbData, err := GetCloudElement(req)
+		if err != nil {
+			return nil, err
+		}
+		if cmdbData.InstanceId == "" {
+			return nil, &CmdbError{ElementId: req.ElementId, Err: fmt.Errorf("cloud element %s has no instance id to use as %s", req.ElementId, name)}
+		}
+		identity = &Identity{Name: name, Value: cmdbData.InstanceId, Source: IdentitySourceCmdb}
+		if Cmdb().Mapped(req.ElementId) != nil {
+			identity.Source = IdentitySourceElementMap
+		}
+	}
+	log.Printf("Using %s %s from %s", identity.Name, identity.Value, identity.Source)
+	if req.identities != nil {
+		req.identities.add(*identity)
+	}
+	return identity, nil
+}
+
+// ResolveElementIdentity resolves the identifier declared for the element
+// type of req, see RegisterIdentifier.
+func ResolveElementIdentity(req *PanelRequest) (*Identity, error) {
+	return ResolveIdentity(req, ElementIdentifier(req.ElementType))
+}
+
+// identifier returns the input given for the identifier name.
+func (r *PanelRequest) identifier(name string) string {
+	switch name {
+	case IdentifierInstanceId:
+		return r.InstanceId
+	case IdentifierBucketName:
+		if r.BucketName != "" {
+			return r.BucketName
+		}
+	}
+	return r.Identifiers[name]
+}
+
+// Identities returns the identities resolved for req so far, in order and
+// without repeats.
+func (r *PanelRequest) Identities() []Identity {
+	if r.identities == nil {
+		return nil
+	}
+	return r.identities.list()
+}
+
+// identityLog collects the identities resolved while a panel runs. Panels
+// may resolve from several goroutines.
+type identityLog struct {
+	mu         sync.Mutex
+	identities []Identity
+}
+
+func (l *identityLog) add(identity Identity) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, seen := range l.identities {
+		if seen == identity {
+			return
+		}
+	}
+	l.identities = append(l.identities, identity)
+}
+
+func (l *identityLog) list() []Identity {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Identity(nil), l.identities...)
+}
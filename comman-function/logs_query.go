@@ -0,0 +1,155 @@
+package comman_function
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+)
+
+var (
+	// DefaultLogsQueryTimeout bounds a logs insights query whose context has no
+	// deadline.
+	DefaultLogsQueryTimeout = 2 * time.Minute
+	// LogsPollInterval is the first wait between GetQueryResults calls. The wait
+	// doubles after every poll up to LogsPollMaxInterval.
+	LogsPollInterval    = 500 * time.Millisecond
+	LogsPollMaxInterval = 5 * time.Second
+
+	// stopQueryTimeout bounds the StopQuery call sent after the deadline.
+	stopQueryTimeout = 5 * time.Second
+)
+
+// LogsQuery is one logs insights query.
+type LogsQuery struct {
+	LogGroupNames []string
+	QueryString   string
+	StartTime     *time.Time
+	EndTime       *time.Time
+	// Limit caps the returned rows. Zero keeps the limit of the query string.
+	Limit int64
+}
+
+// RunLogsQuery starts query and polls until it completes, backing off between
+// polls. When ctx is done first the query is stopped and a LogsQueryError with
+// status Timeout or Cancelled is returned. The output carries only the final
+// result set and its statistics.
+//
+// A query that ended CacheSettleDelay ago or earlier runs over its window
+// aligned to the minute and its results are kept in the result cache.
+func RunLogsQuery(ctx context.Context, clientAuth *model.Auth, query LogsQuery, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	cache := Results()
+	if cache == nil || query.StartTime == nil || query.EndTime == nil || query.EndTime.After(time.Now().Add(-CacheSettleDelay)) {
+		return runLogsQuery(ctx, clientAuth, query, cloudWatchLogs)
+	}
+	start, end := alignDown(*query.StartTime, 60), alignUp(*query.EndTime, 60)
+	query.StartTime, query.EndTime = &start, &end
+	key := cacheKey(clientAuth, "logs", strings.Join(query.LogGroupNames, ","), query.QueryString,
+		fmt.Sprint(query.Limit), fmt.Sprintf("%d-%d", start.Unix(), end.Unix()))
+	if value, found := cacheGet(cache, key); found {
+		var cached cloudwatchlogs.GetQueryResultsOutput
+		if json.Unmarshal(value, &cached) == nil {
+			log.Printf("Result cache: logs query cached for %v to %v", start, end)
+			return &cached, nil
+		}
+	}
+	result, err := runLogsQuery(ctx, clientAuth, query, cloudWatchLogs)
+	if err != nil {
+		return nil, err
+	}
+	if value, err := json.Marshal(result); err == nil {
+		cache.Put(key, value)
+	}
+	return result, nil
+}
+
+func runLogsQuery(ctx context.Context, clientAuth *model.Auth, query LogsQuery, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultLogsQueryTimeout)
+		defer cancel()
+	}
+	if cloudWatchLogs == nil {
+		cloudWatchLogs = CloudWatchLogsClient(*clientAuth)
+	}
+
+	params := &cloudwatchlogs.StartQueryInput{
+		LogGroupNames: aws.StringSlice(query.LogGroupNames),
+		StartTime:     aws.Int64(query.StartTime.Unix() * 1000),
+		EndTime:       aws.Int64(query.EndTime.Unix() * 1000),
+		QueryString:   aws.String(query.QueryString),
+	}
+	if query.Limit > 0 {
+		params.Limit = aws.Int64(query.Limit)
+	}
+	started, err := cloudWatchLogs.StartQueryWithContext(ctx, params)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextLogsQueryError("", ctx.Err())
+		}
+		return nil, fmt.Errorf("failed to start query: %w", err)
+	}
+	queryId := aws.StringValue(started.QueryId)
+
+	wait := LogsPollInterval
+	for {
+		result, err := cloudWatchLogs.GetQueryResultsWithContext(ctx, &cloudwatchlogs.GetQueryResultsInput{
+			QueryId: started.QueryId,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				stopLogsQuery(cloudWatchLogs, queryId)
+				return nil, contextLogsQueryError(queryId, ctx.Err())
+			}
+			return nil, fmt.Errorf("failed to get query results: %w", err)
+		}
+
+		switch status := aws.StringValue(result.Status); status {
+		case cloudwatchlogs.QueryStatusComplete:
+			return result, nil
+		case cloudwatchlogs.QueryStatusFailed, cloudwatchlogs.QueryStatusCancelled, cloudwatchlogs.QueryStatusTimeout:
+			return nil, &LogsQueryError{QueryId: queryId, Status: status}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			stopLogsQuery(cloudWatchLogs, queryId)
+			return nil, contextLogsQueryError(queryId, ctx.Err())
+		case <-timer.C:
+		}
+		if wait *= 2; wait > LogsPollMaxInterval {
+			wait = LogsPollMaxInterval
+		}
+	}
+}
+
+// stopLogsQuery stops a query that is no longer awaited so it does not keep
+// scanning logs. The caller's context is already done, so it uses its own.
+func stopLogsQuery(cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI, queryId string) {
+	ctx, cancel := context.WithTimeout(context.Background(), stopQueryTimeout)
+	defer cancel()
+	if _, err := cloudWatchLogs.StopQueryWithContext(ctx, &cloudwatchlogs.StopQueryInput{QueryId: aws.String(queryId)}); err != nil {
+		log.Printf("failed to stop logs query %s: %v", queryId, err)
+	}
+}
+
+func contextLogsQueryError(queryId string, err error) *LogsQueryError {
+	status := cloudwatchlogs.QueryStatusCancelled
+	if errors.Is(err, context.DeadlineExceeded) {
+		status = cloudwatchlogs.QueryStatusTimeout
+	}
+	return &LogsQueryError{QueryId: queryId, Status: status, Err: err}
+}
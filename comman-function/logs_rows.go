@@ -0,0 +1,191 @@
+package comman_function
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// logsTimeLayouts are the timestamp formats found in logs insights results:
+// @timestamp and bin() values, and the RFC3339 eventTime of cloudtrail events.
+var logsTimeLayouts = []string{
+	"2006-01-02 15:04:05.000",
+	"2006-01-02 15:04:05",
+	time.RFC3339Nano,
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// DecodeLogsRows decodes the rows of every result into rows, which must be a
+// pointer to a slice of structs. A struct field is filled from the result
+// field named by its logs tag, e.g.
+//
+//	EventName  string    `logs:"eventName"`
+//	Time       time.Time `logs:"@timestamp"`
+//	EventCount int64     `logs:"EventCount"`
+//
+// Fields without a logs tag, or tagged "-", are left alone, as are result
+// fields no struct field asks for. Timestamps and numbers are converted from
+// the strings logs insights returns.
+func DecodeLogsRows(results []*cloudwatchlogs.GetQueryResultsOutput, rows interface{}) error {
+	ptr := reflect.ValueOf(rows)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("decode logs rows: need a pointer to a slice, got %T", rows)
+	}
+	slice := ptr.Elem()
+	elemType := slice.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("decode logs rows: need a slice of structs, got %T", rows)
+	}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		for i, row := range result.Results {
+			elem := reflect.New(elemType)
+			if err := DecodeLogsRow(row, elem.Interface()); err != nil {
+				return fmt.Errorf("row %d: %w", i, err)
+			}
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+	return nil
+}
+
+// DecodeLogsRow decodes one result row into the struct v points to. See
+// DecodeLogsRows for the field tags.
+func DecodeLogsRow(row []*cloudwatchlogs.ResultField, v interface{}) error {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || ptr.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode logs row: need a pointer to a struct, got %T", v)
+	}
+	values := make(map[string]string, len(row))
+	for _, field := range row {
+		if field != nil && field.Field != nil {
+			values[*field.Field] = aws.StringValue(field.Value)
+		}
+	}
+
+	target := ptr.Elem()
+	for i := 0; i < target.NumField(); i++ {
+		structField := target.Type().Field(i)
+		name := structField.Tag.Get("logs")
+		if name == "" || name == "-" || structField.PkgPath != "" {
+			continue
+		}
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		if err := setLogsValue(target.Field(i), value); err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setLogsValue(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Ptr {
+		if value == "" {
+			return nil
+		}
+		elem := reflect.New(field.Type().Elem())
+		if err := setLogsValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if field.Type() == timeType {
+		if value == "" {
+			return nil
+		}
+		t, err := ParseLogsTime(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		if value == "" {
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value == "" {
+			return nil
+		}
+		// Aggregates such as avg() come back as "12.0", so whole floats are
+		// accepted too.
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || f != math.Trunc(f) {
+			return fmt.Errorf("cannot convert %q to an integer", value)
+		}
+		if field.OverflowInt(int64(f)) {
+			return fmt.Errorf("%q overflows %s", value, field.Type())
+		}
+		field.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || f < 0 || f != math.Trunc(f) {
+			return fmt.Errorf("cannot convert %q to an unsigned integer", value)
+		}
+		if field.OverflowUint(uint64(f)) {
+			return fmt.Errorf("%q overflows %s", value, field.Type())
+		}
+		field.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		if value == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// ParseLogsTime parses a logs insights timestamp. Besides the formats of
+// logsTimeLayouts it accepts epoch milliseconds. Times without an offset are
+// UTC.
+func ParseLogsTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range logsTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a timestamp", value)
+}
@@ -0,0 +1,248 @@
+package comman_function
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+)
+
+// metricCacheChunk is the number of buckets in a metric cache entry. Entries
+// cover spans of that many periods aligned to the epoch, so a window that
+// moves along with the clock keeps hitting the spans it shares with earlier
+// windows.
+const metricCacheChunk = 12
+
+// cachedSeries is the cache entry of one metric over one closed span.
+type cachedSeries struct {
+	Label      string      `json:"label,omitempty"`
+	Timestamps []time.Time `json:"timestamps"`
+	Values     []float64   `json:"values"`
+}
+
+// executeCached runs q over its window aligned to the period: the start
+// rounded down and the end rounded up. Each metric is cached per span of
+// metricCacheChunk buckets, keyed by namespace, metric, dimensions,
+// statistic, period and span, once the whole span ended CacheSettleDelay ago
+// or earlier. Only the spans missing from the cache and the open trailing
+// buckets are fetched. Queries with expressions are cached as a whole and
+// only once all their buckets are closed. ok is false when q is not
+// cacheable and has not run.
+func (q *MetricQuery) executeCached(cache ResultCache, clientAuth *model.Auth, cloudWatchClient cloudwatchiface.CloudWatchAPI) (result *MetricQueryResult, ok bool, err error) {
+	period := q.period()
+	for _, query := range q.queries {
+		if query.MetricStat != nil && aws.Int64Value(query.MetricStat.Period) != period {
+			return nil, false, nil
+		}
+	}
+	start := alignDown(*q.StartTime, period)
+	end := alignUp(*q.EndTime, period)
+	closed := alignDown(time.Now().Add(-CacheSettleDelay), period)
+	if closed.After(end) {
+		closed = end
+	}
+	if !closed.After(start) {
+		return nil, false, nil
+	}
+	if q.hasExpressions() {
+		if closed.Before(end) {
+			return nil, false, nil
+		}
+		result, err = q.executeCachedRequest(cache, clientAuth, cloudWatchClient, start, end)
+		return result, true, err
+	}
+
+	span := time.Duration(period*metricCacheChunk) * time.Second
+	var spans []time.Time
+	for s := alignDown(start, period*metricCacheChunk); !s.Add(span).After(closed); s = s.Add(span) {
+		spans = append(spans, s)
+	}
+	if len(spans) == 0 {
+		return nil, false, nil
+	}
+
+	// Each metric is read from the cache up to its first missing span and
+	// fetched from there on. Metrics fetched from the same time share the
+	// requests.
+	cached := map[string][]*cachedSeries{}
+	keys := map[string][]string{}
+	fetchFrom := map[time.Time][]*cloudwatch.MetricDataQuery{}
+	hits := 0
+	for _, query := range q.queries {
+		id := aws.StringValue(query.Id)
+		from := spans[len(spans)-1].Add(span)
+		for _, s := range spans {
+			key := cacheKey(clientAuth, "metric", metricStatKey(query), fmt.Sprintf("%d+%d", s.Unix(), period*metricCacheChunk))
+			keys[id] = append(keys[id], key)
+			if len(keys[id]) > len(cached[id])+1 {
+				continue
+			}
+			if value, found := cacheGet(cache, key); found {
+				var entry cachedSeries
+				if json.Unmarshal(value, &entry) == nil {
+					cached[id] = append(cached[id], &entry)
+					hits++
+					continue
+				}
+			}
+			from = s
+		}
+		if from.Before(end) {
+			fetchFrom[from] = append(fetchFrom[from], query)
+		}
+	}
+	log.Printf("Result cache: %d of %d metric spans cached for %v to %v", hits, len(q.queries)*len(spans), start, closed)
+
+	result = &MetricQueryResult{Results: map[string]*cloudwatch.MetricDataResult{}}
+	froms := make([]time.Time, 0, len(fetchFrom))
+	for from := range fetchFrom {
+		froms = append(froms, from)
+	}
+	sort.Slice(froms, func(i, j int) bool { return froms[i].Before(froms[j]) })
+	fetched := map[string]*cloudwatch.MetricDataResult{}
+	for _, from := range froms {
+		from, queries := from, fetchFrom[from]
+		res, err := q.execute(cloudWatchClient, queries, &from, &end)
+		if err != nil {
+			return nil, true, err
+		}
+		result.Messages = append(result.Messages, res.Messages...)
+		for _, query := range queries {
+			id := aws.StringValue(query.Id)
+			fetched[id] = res.Result(id)
+			if aws.StringValue(fetched[id].StatusCode) != cloudwatch.StatusCodeComplete {
+				continue
+			}
+			for i := len(cached[id]); i < len(spans); i++ {
+				if value, err := json.Marshal(spanSeries(fetched[id], spans[i], spans[i].Add(span))); err == nil {
+					cache.Put(keys[id][i], value)
+				}
+			}
+		}
+	}
+
+	for _, query := range q.queries {
+		id := aws.StringValue(query.Id)
+		res := &cloudwatch.MetricDataResult{Id: aws.String(id), StatusCode: aws.String(cloudwatch.StatusCodeComplete)}
+		for _, entry := range cached[id] {
+			if entry.Label != "" {
+				res.Label = aws.String(entry.Label)
+			}
+			res.Timestamps = append(res.Timestamps, aws.TimeSlice(entry.Timestamps)...)
+			res.Values = append(res.Values, aws.Float64Slice(entry.Values)...)
+		}
+		if live, ok := fetched[id]; ok {
+			if live.Label != nil {
+				res.Label = live.Label
+			}
+			res.Timestamps = append(res.Timestamps, live.Timestamps...)
+			res.Values = append(res.Values, live.Values...)
+			res.Messages = live.Messages
+			if live.StatusCode != nil {
+				res.StatusCode = live.StatusCode
+			}
+		}
+		result.Results[id] = spanResult(res, start, end)
+	}
+	return result, true, nil
+}
+
+// spanSeries is the cache entry of the datapoints of res in [from, to).
+func spanSeries(res *cloudwatch.MetricDataResult, from, to time.Time) *cachedSeries {
+	entry := &cachedSeries{Label: aws.StringValue(res.Label), Timestamps: []time.Time{}, Values: []float64{}}
+	for i, t := range res.Timestamps {
+		if t != nil && i < len(res.Values) && !t.Before(from) && t.Before(to) {
+			entry.Timestamps = append(entry.Timestamps, *t)
+			entry.Values = append(entry.Values, aws.Float64Value(res.Values[i]))
+		}
+	}
+	return entry
+}
+
+// spanResult keeps the datapoints of res in [from, to), newest first.
+func spanResult(res *cloudwatch.MetricDataResult, from, to time.Time) *cloudwatch.MetricDataResult {
+	entry := spanSeries(res, from, to)
+	res.Timestamps = aws.TimeSlice(entry.Timestamps)
+	res.Values = aws.Float64Slice(entry.Values)
+	sortDatapoints(res)
+	return res
+}
+
+// executeCachedRequest caches the result of a whole query, keyed by all its
+// queries and the window.
+func (q *MetricQuery) executeCachedRequest(cache ResultCache, clientAuth *model.Auth, cloudWatchClient cloudwatchiface.CloudWatchAPI, start, end time.Time) (*MetricQueryResult, error) {
+	queries, err := json.Marshal(q.queries)
+	if err != nil {
+		return nil, err
+	}
+	key := cacheKey(clientAuth, "metrics", string(queries), fmt.Sprintf("%d-%d", start.Unix(), end.Unix()))
+	if value, found := cacheGet(cache, key); found {
+		var cached MetricQueryResult
+		if json.Unmarshal(value, &cached) == nil {
+			log.Printf("Result cache: %d metric queries cached for %v to %v", len(q.queries), start, end)
+			return &cached, nil
+		}
+	}
+	result, err := q.execute(cloudWatchClient, q.queries, &start, &end)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range result.Results {
+		if aws.StringValue(res.StatusCode) != cloudwatch.StatusCodeComplete {
+			return result, nil
+		}
+	}
+	if value, err := json.Marshal(result); err == nil {
+		cache.Put(key, value)
+	}
+	return result, nil
+}
+
+// metricStatKey identifies the series a MetricStat query reads.
+func metricStatKey(query *cloudwatch.MetricDataQuery) string {
+	stat := query.MetricStat
+	dimensions := make([]string, 0, len(stat.Metric.Dimensions))
+	for _, dimension := range stat.Metric.Dimensions {
+		dimensions = append(dimensions, aws.StringValue(dimension.Name)+"="+aws.StringValue(dimension.Value))
+	}
+	sort.Strings(dimensions)
+	return strings.Join([]string{
+		aws.StringValue(stat.Metric.Namespace),
+		aws.StringValue(stat.Metric.MetricName),
+		strings.Join(dimensions, ","),
+		aws.StringValue(stat.Stat),
+		aws.StringValue(stat.Unit),
+		fmt.Sprint(aws.Int64Value(stat.Period)),
+		aws.StringValue(query.Label),
+	}, "|")
+}
+
+// sortDatapoints orders the datapoints of res newest first, as GetMetricData
+// returns them.
+func sortDatapoints(res *cloudwatch.MetricDataResult) {
+	sort.Sort(datapointsByTimeDesc{res})
+}
+
+type datapointsByTimeDesc struct {
+	*cloudwatch.MetricDataResult
+}
+
+func (d datapointsByTimeDesc) Len() int {
+	return len(d.Timestamps)
+}
+
+func (d datapointsByTimeDesc) Less(i, j int) bool {
+	return aws.TimeValue(d.Timestamps[i]).After(aws.TimeValue(d.Timestamps[j]))
+}
+
+func (d datapointsByTimeDesc) Swap(i, j int) {
+	d.Timestamps[i], d.Timestamps[j] = d.Timestamps[j], d.Timestamps[i]
+	d.Values[i], d.Values[j] = d.Values[j], d.Values[i]
+}
@@ -0,0 +1,364 @@
+package comman_function
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+)
+
+const (
+	// DefaultPeriod is the period of queries without a time range, in seconds.
+	DefaultPeriod = 300
+	// DefaultMaxDataPoints bounds the datapoints per series when the period is
+	// derived from the time range.
+	DefaultMaxDataPoints = 1440
+
+	// maxQueriesPerRequest is the GetMetricData limit on MetricDataQueries.
+	maxQueriesPerRequest = 500
+)
+
+var metricQueryIdPattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+
+// MetricStatQuery is one metric/statistic/dimension-set combination.
+type MetricStatQuery struct {
+	// Id must be unique within the MetricQuery. An empty Id is generated.
+	Id         string
+	Namespace  string
+	MetricName string
+	Stat       string
+	Dimensions []*cloudwatch.Dimension
+	// Period overrides the period of the MetricQuery for this metric.
+	Period int64
+	Label  string
+	Unit   string
+	// Hidden metrics are only inputs of expressions and are not returned.
+	Hidden bool
+}
+
+// MetricExpressionQuery is a metric math expression over the ids of other
+// queries of the same MetricQuery, e.g. "100 * errors / requests". CloudWatch
+// evaluates it for every timestamp.
+type MetricExpressionQuery struct {
+	// Id must be unique within the MetricQuery. An empty Id is generated.
+	Id         string
+	Expression string
+	Label      string
+	// Period is only used by expressions that search for metrics, e.g. SEARCH.
+	Period int64
+	// Hidden expressions are only inputs of other expressions.
+	Hidden bool
+}
+
+// MetricQuery packs many metric queries over one time range into as few
+// GetMetricData calls as possible.
+type MetricQuery struct {
+	StartTime *time.Time
+	EndTime   *time.Time
+	// Period in seconds. Zero derives it from the time range.
+	Period int64
+	// MaxDataPoints bounds the datapoints per series of a derived period.
+	// Zero is DefaultMaxDataPoints.
+	MaxDataPoints int64
+
+	queries []*cloudwatch.MetricDataQuery
+	ids     map[string]bool
+	err     error
+	ctx     context.Context
+}
+
+// MetricQueryResult holds the merged results of a MetricQuery by query id.
+type MetricQueryResult struct {
+	Results  map[string]*cloudwatch.MetricDataResult
+	Messages []*cloudwatch.MessageData
+	// Series describes the MetricStat queries by id.
+	Series map[string]MetricSeries `json:"-"`
+}
+
+func NewMetricQuery(startTime, endTime *time.Time, period int64) *MetricQuery {
+	return &MetricQuery{StartTime: startTime, EndTime: endTime, Period: period, ids: map[string]bool{}}
+}
+
+// Dimension returns a cloudwatch dimension for use with AddMetric.
+func Dimension(name, value string) *cloudwatch.Dimension {
+	return &cloudwatch.Dimension{Name: aws.String(name), Value: aws.String(value)}
+}
+
+// DerivePeriod returns the period of a range that does not set one: the
+// finest period CloudWatch keeps for the start of the range, see
+// RetentionPeriod, or the smallest multiple of it that keeps the range within
+// maxDataPoints datapoints, DefaultMaxDataPoints when 0.
+func DerivePeriod(startTime, endTime *time.Time, maxDataPoints int64) int64 {
+	if startTime == nil || endTime == nil {
+		return DefaultPeriod
+	}
+	if maxDataPoints <= 0 {
+		maxDataPoints = DefaultMaxDataPoints
+	}
+	finest := RetentionPeriod(*startTime, time.Now())
+	period := PeriodForDataPoints(startTime, endTime, maxDataPoints)
+	return (period + finest - 1) / finest * finest
+}
+
+// RetentionPeriod returns the finest standard resolution period CloudWatch
+// still keeps for datapoints at t: a minute for the last 15 days, 5 minutes
+// for the last 63 days and an hour before that.
+func RetentionPeriod(t, now time.Time) int64 {
+	age := now.Sub(t)
+	switch {
+	case age <= MetricRetention(60):
+		return 60
+	case age <= MetricRetention(300):
+		return 300
+	}
+	return 3600
+}
+
+// PeriodForDataPoints returns the smallest multiple of 60 seconds that keeps
+// the range within maxDataPoints datapoints.
+func PeriodForDataPoints(startTime, endTime *time.Time, maxDataPoints int64) int64 {
+	if startTime == nil || endTime == nil || maxDataPoints <= 0 {
+		return DefaultPeriod
+	}
+	seconds := int64(endTime.Sub(*startTime).Seconds())
+	period := (seconds + maxDataPoints - 1) / maxDataPoints
+	period = (period + 59) / 60 * 60
+	if period < 60 {
+		return 60
+	}
+	return period
+}
+
+// AddMetric adds a MetricStat query and returns its id.
+func (q *MetricQuery) AddMetric(id, namespace, metricName, stat string, dimensions ...*cloudwatch.Dimension) string {
+	return q.Add(MetricStatQuery{Id: id, Namespace: namespace, MetricName: metricName, Stat: stat, Dimensions: dimensions})
+}
+
+// Add adds a MetricStat query and returns its id.
+func (q *MetricQuery) Add(stat MetricStatQuery) string {
+	id := q.register(stat.Id)
+	period := stat.Period
+	if period == 0 {
+		period = q.period()
+	}
+	query := &cloudwatch.MetricDataQuery{
+		Id: aws.String(id),
+		MetricStat: &cloudwatch.MetricStat{
+			Metric: &cloudwatch.Metric{
+				Dimensions: stat.Dimensions,
+				MetricName: aws.String(stat.MetricName),
+				Namespace:  aws.String(stat.Namespace),
+			},
+			Period: aws.Int64(period),
+			Stat:   aws.String(stat.Stat),
+		},
+	}
+	if stat.Label != "" {
+		query.Label = aws.String(stat.Label)
+	}
+	if stat.Unit != "" {
+		query.MetricStat.Unit = aws.String(stat.Unit)
+	}
+	if stat.Hidden {
+		query.ReturnData = aws.Bool(false)
+	}
+	q.queries = append(q.queries, query)
+	return id
+}
+
+// AddExpression adds a metric math expression and returns its id.
+func (q *MetricQuery) AddExpression(id, expression, label string) string {
+	return q.AddExpressionQuery(MetricExpressionQuery{Id: id, Expression: expression, Label: label})
+}
+
+// AddExpressionQuery adds a metric math expression and returns its id.
+func (q *MetricQuery) AddExpressionQuery(expr MetricExpressionQuery) string {
+	id := q.register(expr.Id)
+	if q.err == nil && expr.Expression == "" {
+		q.err = fmt.Errorf("metric query %q has an empty expression", id)
+	}
+	query := &cloudwatch.MetricDataQuery{
+		Id:         aws.String(id),
+		Expression: aws.String(expr.Expression),
+	}
+	if expr.Label != "" {
+		query.Label = aws.String(expr.Label)
+	}
+	if expr.Period > 0 {
+		query.Period = aws.Int64(expr.Period)
+	}
+	if expr.Hidden {
+		query.ReturnData = aws.Bool(false)
+	}
+	q.queries = append(q.queries, query)
+	return id
+}
+
+func (q *MetricQuery) register(id string) string {
+	if id == "" {
+		id = fmt.Sprintf("m%d", len(q.queries)+1)
+		for q.ids[id] {
+			id += "_"
+		}
+	}
+	if q.err == nil {
+		if !metricQueryIdPattern.MatchString(id) {
+			q.err = fmt.Errorf("invalid metric query id %q: ids start with a lower case letter and contain only letters, digits and _", id)
+		} else if q.ids[id] {
+			q.err = fmt.Errorf("duplicate metric query id %q", id)
+		}
+	}
+	q.ids[id] = true
+	return id
+}
+
+func (q *MetricQuery) period() int64 {
+	if q.Period > 0 {
+		return q.Period
+	}
+	return DerivePeriod(q.StartTime, q.EndTime, q.MaxDataPoints)
+}
+
+// Queries returns the queries added so far.
+func (q *MetricQuery) Queries() []*cloudwatch.MetricDataQuery {
+	return q.queries
+}
+
+// Execute runs the queries, following NextToken, and merges the pages by id.
+// Expressions can only refer to queries sent in the same request, so a query
+// with expressions is never split across requests. Closed buckets are served
+// from the result cache when one is set, see executeCached.
+func (q *MetricQuery) Execute(clientAuth *model.Auth, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*MetricQueryResult, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if cloudWatchClient == nil {
+		cloudWatchClient = CloudWatchClient(*clientAuth)
+	}
+
+	if q.hasExpressions() && len(q.queries) > maxQueriesPerRequest {
+		return nil, fmt.Errorf("metric query has %d queries, expressions allow at most %d", len(q.queries), maxQueriesPerRequest)
+	}
+	notePeriod(q.context(), q.period())
+	var result *MetricQueryResult
+	var err error
+	cached := false
+	if cache := Results(); cache != nil && q.StartTime != nil && q.EndTime != nil {
+		result, cached, err = q.executeCached(cache, clientAuth, cloudWatchClient)
+	}
+	if !cached {
+		result, err = q.execute(cloudWatchClient, q.queries, q.StartTime, q.EndTime)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Series = querySeries(q.queries)
+	results := make([]*cloudwatch.MetricDataResult, 0, len(result.Results))
+	for _, res := range result.Results {
+		results = append(results, res)
+	}
+	noteResults(q.context(), q.queries, results)
+	return result, nil
+}
+
+func (q *MetricQuery) execute(cloudWatchClient cloudwatchiface.CloudWatchAPI, queries []*cloudwatch.MetricDataQuery, startTime, endTime *time.Time) (*MetricQueryResult, error) {
+	result := &MetricQueryResult{Results: map[string]*cloudwatch.MetricDataResult{}}
+	for start := 0; start < len(queries); start += maxQueriesPerRequest {
+		end := start + maxQueriesPerRequest
+		if end > len(queries) {
+			end = len(queries)
+		}
+		input := &cloudwatch.GetMetricDataInput{
+			StartTime:         startTime,
+			EndTime:           endTime,
+			MetricDataQueries: queries[start:end],
+		}
+		for {
+			log.Printf("Getting metric data for %d queries from %v to %v", len(input.MetricDataQueries), startTime, endTime)
+			output, err := cloudWatchClient.GetMetricDataWithContext(context.WithValue(q.context(), metricQueryCallKey{}, true), input)
+			if err != nil {
+				return nil, err
+			}
+			result.merge(output)
+			if output.NextToken == nil || *output.NextToken == "" {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+	return result, nil
+}
+
+// context returns the context the queries are sent with, the one of the panel
+// request for queries built by PanelRequest.MetricQuery.
+func (q *MetricQuery) context() context.Context {
+	if q.ctx != nil {
+		return q.ctx
+	}
+	return context.Background()
+}
+
+func (q *MetricQuery) hasExpressions() bool {
+	for _, query := range q.queries {
+		if query.Expression != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MetricQueryResult) merge(output *cloudwatch.GetMetricDataOutput) {
+	r.Messages = append(r.Messages, output.Messages...)
+	for _, res := range output.MetricDataResults {
+		if res.Id == nil {
+			continue
+		}
+		existing, ok := r.Results[*res.Id]
+		if !ok {
+			r.Results[*res.Id] = res
+			continue
+		}
+		existing.Timestamps = append(existing.Timestamps, res.Timestamps...)
+		existing.Values = append(existing.Values, res.Values...)
+		existing.Messages = append(existing.Messages, res.Messages...)
+		existing.StatusCode = res.StatusCode
+	}
+}
+
+// Result returns the merged result of id. A query that returned nothing yields
+// a result without datapoints rather than nil.
+func (r *MetricQueryResult) Result(id string) *cloudwatch.MetricDataResult {
+	if res, ok := r.Results[id]; ok {
+		return res
+	}
+	return &cloudwatch.MetricDataResult{Id: aws.String(id)}
+}
+
+// Output wraps the result of id in the GetMetricDataOutput shape panels return
+// for --responseType=frame.
+func (r *MetricQueryResult) Output(id string) *cloudwatch.GetMetricDataOutput {
+	return &cloudwatch.GetMetricDataOutput{
+		MetricDataResults: []*cloudwatch.MetricDataResult{r.Result(id)},
+		Messages:          r.Messages,
+	}
+}
+
+// FirstValue returns the first value of the first series of output, the
+// latest one in the order GetMetricData returns them. ok is false when output
+// has no datapoints.
+func FirstValue(output *cloudwatch.GetMetricDataOutput) (value float64, ok bool) {
+	if output == nil || len(output.MetricDataResults) == 0 || output.MetricDataResults[0] == nil {
+		return 0, false
+	}
+	values := output.MetricDataResults[0].Values
+	if len(values) == 0 || values[0] == nil {
+		return 0, false
+	}
+	return *values[0], true
+}
@@ -0,0 +1,378 @@
+package comman_function
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// Outputs of --output. They format the value of the response type: json and
+// yaml keep its structure, ndjson, csv and table flatten it into rows.
+const (
+	OutputJson   = "json"
+	OutputNdjson = "ndjson"
+	OutputCsv    = "csv"
+	OutputTable  = "table"
+	OutputYaml   = "yaml"
+)
+
+var Outputs = []string{OutputJson, OutputNdjson, OutputCsv, OutputTable, OutputYaml}
+
+// ValidateOutput reports an unknown --output. An empty output is the default
+// of the response type.
+func ValidateOutput(output string) error {
+	if output == "" {
+		return nil
+	}
+	for _, o := range Outputs {
+		if o == output {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output %q. supported: %s", output, strings.Join(Outputs, ", "))
+}
+
+// OutputFormat is the output of req: --output, else table for the table
+// response type and json for the others.
+func (r *PanelRequest) OutputFormat() string {
+	if r.Output != "" {
+		return r.Output
+	}
+	if r.ResponseType == ResponseTypeTable {
+		return OutputTable
+	}
+	return OutputJson
+}
+
+// OutputPayload returns the value of result for responseType: the frames,
+// the appkube panel or the json output. Panels whose json output is a text
+// rendering, e.g. a table, give their frame value instead when it is written
+// as rows or yaml. Appkube panels written as rows leave out the metadata of
+// the result.
+func (p *PanelDefinition) OutputPayload(result *PanelResult, responseType, output string) (interface{}, error) {
+	switch responseType {
+	case ResponseTypeFrame:
+		return result.Frame, nil
+	case ResponseTypeAppkube:
+		if output != OutputJson && output != OutputYaml && result.Metadata != nil {
+			rows := *result
+			rows.Metadata = nil
+			return p.RenderAppkube(&rows)
+		}
+		return p.RenderAppkube(result)
+	}
+	if s, ok := result.Json.(string); ok && output != OutputJson && result.Frame != nil && !json.Valid([]byte(s)) {
+		return result.Frame, nil
+	}
+	return result.Json, nil
+}
+
+// MetadataPayload is the payload of a request with WithMetadata set: the
+// output of the panel and the metadata of its result.
+type MetadataPayload struct {
+	Result   interface{}     `json:"result"`
+	Metadata *ResultMetadata `json:"metadata"`
+}
+
+// WithMetadataPayload wraps the payload of result in a MetadataPayload for json and
+// yaml output when req asks for it. Json text is embedded as it is. Appkube
+// panels carry their metadata already and rows have no place for it, so
+// their payload is returned unchanged.
+func (r *PanelRequest) WithMetadataPayload(payload interface{}, result *PanelResult, output string) interface{} {
+	if !r.WithMetadata || r.ResponseType == ResponseTypeAppkube || output != OutputJson && output != OutputYaml {
+		return payload
+	}
+	if s, ok := payload.(string); ok && json.Valid([]byte(s)) {
+		payload = json.RawMessage(s)
+	}
+	return &MetadataPayload{Result: payload, Metadata: result.Metadata}
+}
+
+// WriteOutput writes payload to w in the given output. Strings are taken
+// as json text and written as they are for json output.
+func WriteOutput(w io.Writer, payload interface{}, output string) error {
+	switch output {
+	case "", OutputJson:
+		if s, ok := payload.(string); ok {
+			_, err := fmt.Fprintln(w, s)
+			return err
+		}
+		jsonBytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(jsonBytes))
+		return err
+	case OutputYaml:
+		if s, ok := payload.(string); ok && json.Valid([]byte(s)) {
+			payload = json.RawMessage(s)
+		}
+		yamlBytes, err := EncodeYaml(payload)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(yamlBytes)
+		return err
+	}
+	columns, rows := Tabulate(payload)
+	return WriteRows(w, columns, rows, output)
+}
+
+// WriteRows writes rows as ndjson, one object per row, csv with a header
+// line or a text table.
+func WriteRows(w io.Writer, columns []string, rows [][]interface{}, output string) error {
+	switch output {
+	case OutputNdjson:
+		for _, row := range rows {
+			object := &jsonObject{keys: columns, values: map[string]interface{}{}}
+			for i, column := range columns {
+				object.values[column] = row[i]
+			}
+			rowBytes, err := object.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(w, string(rowBytes)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case OutputCsv:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := writer.Write(outputCells(row)); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	case OutputTable:
+		_, err := io.WriteString(w, renderTable(columns, rows))
+		return err
+	}
+	return ValidateOutput(output)
+}
+
+func outputCells(row []interface{}) []string {
+	cells := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			cells[i] = v
+		case float64:
+			cells[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case time.Time:
+			cells[i] = v.Format(time.RFC3339)
+		case *jsonObject, []interface{}:
+			cellBytes, _ := json.Marshal(v)
+			cells[i] = string(cellBytes)
+		default:
+			cells[i] = fmt.Sprint(v)
+		}
+	}
+	return cells
+}
+
+// Tabulate flattens a panel value into rows:
+//
+//   - a list of structs gives one row per struct, see RenderTable
+//   - frames give one row per point, see frameRows
+//   - logs insights results give one row per result
+//   - json objects give one row of their scalar values, repeated for each
+//     row of the list or object they hold; when they hold several, or an
+//     object, a series column names the one a row came from
+//
+// Text that is not json is one row with a value column.
+func Tabulate(payload interface{}) ([]string, [][]interface{}) {
+	switch v := payload.(type) {
+	case nil:
+		return nil, nil
+	case []Frame:
+		return frameRows(v)
+	case AppkubeTablePanel:
+		return v.Columns, v.Rows
+	case []*cloudwatchlogs.GetQueryResultsOutput:
+		return logsResultRows(v)
+	}
+	if columns, rows, ok := structRows(payload); ok {
+		return columns, rows
+	}
+
+	value := appkubeJson(payload)
+	if value == nil {
+		if s, ok := payload.(string); ok && strings.TrimSpace(s) != "" && !json.Valid([]byte(s)) {
+			return []string{"value"}, [][]interface{}{{strings.TrimSpace(s)}}
+		}
+		return nil, nil
+	}
+	var columns []string
+	index := map[string]int{}
+	objects := unnest(value)
+	for _, object := range objects {
+		for _, key := range object.keys {
+			if _, ok := index[key]; !ok {
+				index[key] = len(columns)
+				columns = append(columns, key)
+			}
+		}
+	}
+	rows := make([][]interface{}, len(objects))
+	for i, object := range objects {
+		rows[i] = make([]interface{}, len(columns))
+		for _, key := range object.keys {
+			rows[i][index[key]] = object.values[key]
+		}
+	}
+	return columns, rows
+}
+
+// unnest turns a decoded json value into flat rows, see Tabulate.
+func unnest(value interface{}) []*jsonObject {
+	switch v := value.(type) {
+	case []interface{}:
+		var rows []*jsonObject
+		for _, element := range v {
+			rows = append(rows, unnest(element)...)
+		}
+		return rows
+	case *jsonObject:
+		scalars := &jsonObject{values: map[string]interface{}{}}
+		var children []string
+		for _, key := range v.keys {
+			switch child := v.values[key].(type) {
+			case []interface{}:
+				if len(child) > 0 {
+					children = append(children, key)
+				}
+			case *jsonObject:
+				children = append(children, key)
+			default:
+				scalars.set(key, child)
+			}
+		}
+
+		var rows []*jsonObject
+		for _, key := range children {
+			_, isObject := v.values[key].(*jsonObject)
+			for _, childRow := range unnest(v.values[key]) {
+				row := &jsonObject{values: map[string]interface{}{}}
+				for _, scalar := range scalars.keys {
+					row.set(scalar, scalars.values[scalar])
+				}
+				if isObject || len(children) > 1 {
+					row.set("series", key)
+				}
+				for _, childKey := range childRow.keys {
+					row.set(childKey, childRow.values[childKey])
+				}
+				rows = append(rows, row)
+			}
+		}
+		if len(rows) == 0 && len(scalars.keys) > 0 {
+			rows = append(rows, scalars)
+		}
+		return rows
+	case nil:
+		return nil
+	}
+	row := &jsonObject{values: map[string]interface{}{}}
+	row.set("value", value)
+	return []*jsonObject{row}
+}
+
+func (o *jsonObject) set(key string, value interface{}) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// frameRows gives one row per point of frames with the refId, the metric
+// and the labels of the frame.
+func frameRows(frames []Frame) ([]string, [][]interface{}) {
+	labelSet := map[string]bool{}
+	for _, frame := range frames {
+		for _, field := range frame.Schema.Fields {
+			for key := range field.Labels {
+				labelSet[key] = true
+			}
+		}
+	}
+	labels := make([]string, 0, len(labelSet))
+	for key := range labelSet {
+		labels = append(labels, key)
+	}
+	sort.Strings(labels)
+	columns := append(append([]string{"time", "refId", "metric"}, labels...), "value")
+
+	var rows [][]interface{}
+	for _, frame := range frames {
+		if len(frame.Data.Values) != 2 || len(frame.Schema.Fields) != 2 {
+			continue
+		}
+		field := frame.Schema.Fields[1]
+		for i, t := range frame.Data.Values[0] {
+			ms, ok := t.(int64)
+			if !ok || i >= len(frame.Data.Values[1]) {
+				continue
+			}
+			row := []interface{}{time.UnixMilli(ms).UTC(), frame.Schema.RefId, field.Name}
+			for _, key := range labels {
+				if value, ok := field.Labels[key]; ok {
+					row = append(row, value)
+				} else {
+					row = append(row, nil)
+				}
+			}
+			rows = append(rows, append(row, frame.Data.Values[1][i]))
+		}
+	}
+	return columns, rows
+}
+
+func logsResultRows(queryResults []*cloudwatchlogs.GetQueryResultsOutput) ([]string, [][]interface{}) {
+	var columns []string
+	index := map[string]int{}
+	var results [][]*cloudwatchlogs.ResultField
+	for _, queryResult := range queryResults {
+		if queryResult == nil {
+			continue
+		}
+		for _, result := range queryResult.Results {
+			for _, field := range result {
+				if field == nil || field.Field == nil || *field.Field == "@ptr" {
+					continue
+				}
+				if _, ok := index[*field.Field]; !ok {
+					index[*field.Field] = len(columns)
+					columns = append(columns, *field.Field)
+				}
+			}
+			results = append(results, result)
+		}
+	}
+	rows := make([][]interface{}, len(results))
+	for i, result := range results {
+		rows[i] = make([]interface{}, len(columns))
+		for _, field := range result {
+			if field == nil || field.Field == nil || field.Value == nil {
+				continue
+			}
+			if column, ok := index[*field.Field]; ok {
+				rows[i][column] = *field.Value
+			}
+		}
+	}
+	return columns, rows
+}
@@ -0,0 +1,256 @@
+package comman_function
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/spf13/cobra"
+)
+
+// PanelRequest carries the inputs of a single panel query. It is built from the
+// cobra flags by NewPanelRequest, or filled in directly by Go programs that call
+// panels as a library.
+type PanelRequest struct {
+	ElementId    string
+	ElementType  string
+	InstanceId   string
+	LogGroupName string
+	CmdbApiUrl   string
+	StartTime    *time.Time
+	EndTime      *time.Time
+	Period       int64
+	// MaxDataPoints bounds the datapoints per series when Period is not set.
+	MaxDataPoints int64
+	// Concurrency bounds the aws calls a panel fans out at once, see FanOut.
+	Concurrency  int
+	ResponseType string
+	// Output is the --output the result is written in, see OutputFormat.
+	Output string
+	// WithMetadata writes the metadata of the result along with it, see
+	// WithMetadataPayload.
+	WithMetadata    bool
+	FilterPattern   string
+	BucketName      string
+	LoadBalancerArn string
+	// CloudWatchQueries is the raw query json of the raw_metric_query panel.
+	CloudWatchQueries string
+	// Identifiers holds the per-service identifiers given as input, keyed by
+	// identifier name, e.g. FunctionName. See ResolveIdentity.
+	Identifiers map[string]string
+	ClientAuth  *model.Auth
+
+	// CloudElement is the CMDB record of ElementId. When set, panels use it
+	// instead of looking the element up again.
+	CloudElement *model.CloudElement
+
+	ctx        context.Context
+	identities *identityLog
+}
+
+// Context returns the context of the request, context.Background by default.
+// Long running calls such as logs insights queries stop when it is done.
+func (r *PanelRequest) Context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+	return context.Background()
+}
+
+// WithContext returns a shallow copy of r with its context changed to ctx.
+func (r *PanelRequest) WithContext(ctx context.Context) *PanelRequest {
+	r2 := *r
+	r2.ctx = ctx
+	return &r2
+}
+
+// PanelResult carries the two renderings a panel can produce: the processed
+// json output and the data printed for --responseType=frame, grafana frames
+// for metric panels.
+type PanelResult struct {
+	Json  interface{}
+	Frame interface{}
+	// Identities are the identifiers the panel resolved and their sources.
+	Identities []Identity
+	// Retries counts the aws calls made with the context of the request that
+	// were retried, see RetryPolicy.
+	Retries int64
+	// Metadata describes the data of the result, see ResultMetadata.
+	Metadata *ResultMetadata
+	// Series holds the query of every metric result the panel got, see
+	// MetricFrames.
+	Series SeriesSet
+}
+
+// Panel is implemented by everything the registry can run.
+type Panel interface {
+	Run(req *PanelRequest) (*PanelResult, error)
+}
+
+// PanelFunc lets an ordinary function be used as a Panel.
+type PanelFunc func(req *PanelRequest) (*PanelResult, error)
+
+func (f PanelFunc) Run(req *PanelRequest) (*PanelResult, error) {
+	return f(req)
+}
+
+// NewPanelRequest reads the panel inputs from the flags of cmd, including the
+// persistent flags it inherits. Flags a command does not define are left empty.
+func NewPanelRequest(cmd *cobra.Command, clientAuth *model.Auth) (*PanelRequest, error) {
+	flags := cmd.Flags()
+	return BuildPanelRequest(func(name string) string {
+		value, _ := flags.GetString(name)
+		return value
+	}, clientAuth)
+}
+
+// BuildPanelRequest builds a request from named string inputs using the same
+// names as the command line flags, e.g. url.Values.Get for http query parameters.
+// Invalid inputs are reported as a ValidationError.
+func BuildPanelRequest(get func(name string) string, clientAuth *model.Auth) (*PanelRequest, error) {
+	req, err := buildPanelRequest(get, clientAuth)
+	if err != nil {
+		return nil, &ValidationError{Err: err}
+	}
+	return req, nil
+}
+
+func buildPanelRequest(get func(name string) string, clientAuth *model.Auth) (*PanelRequest, error) {
+	req := &PanelRequest{
+		ElementId:         get("elementId"),
+		ElementType:       get("elementType"),
+		InstanceId:        get("instanceId"),
+		LogGroupName:      get("logGroupName"),
+		CmdbApiUrl:        get("cmdbApiUrl"),
+		ResponseType:      get("responseType"),
+		Output:            get("output"),
+		FilterPattern:     get("filterPattern"),
+		BucketName:        get("bucketName"),
+		LoadBalancerArn:   get("loadBalancerArn"),
+		CloudWatchQueries: get("cloudWatchQueries"),
+		ClientAuth:        clientAuth,
+	}
+	if req.LoadBalancerArn == "" {
+		req.LoadBalancerArn = get("lbID")
+	}
+	for name, flags := range identifierFlags {
+		for _, flag := range flags {
+			if value := get(flag); value != "" && name != IdentifierInstanceId {
+				if req.Identifiers == nil {
+					req.Identifiers = map[string]string{}
+				}
+				req.Identifiers[name] = value
+				break
+			}
+		}
+	}
+	// The region of a mapped element applies unless --zone is given.
+	if mapped := Cmdb().Mapped(req.ElementId); mapped != nil && mapped.Region != "" && get("zone") == "" && clientAuth != nil {
+		auth := *clientAuth
+		auth.Region = mapped.Region
+		req.ClientAuth = &auth
+	}
+	if err := ValidateOutput(req.Output); err != nil {
+		return nil, err
+	}
+
+	if periodStr := get("period"); periodStr != "" {
+		period, err := strconv.ParseInt(periodStr, 10, 64)
+		if err != nil || !validPeriod(period) {
+			return nil, fmt.Errorf("invalid period %q: use 1, 5, 10, 30 or a multiple of 60 seconds", periodStr)
+		}
+		req.Period = period
+	}
+	if maxDataPointsStr := get("maxDataPoints"); maxDataPointsStr != "" {
+		maxDataPoints, err := strconv.ParseInt(maxDataPointsStr, 10, 64)
+		if err != nil || maxDataPoints <= 0 {
+			return nil, fmt.Errorf("invalid maxDataPoints %q: use a positive number", maxDataPointsStr)
+		}
+		req.MaxDataPoints = maxDataPoints
+	}
+	if withMetadataStr := get("withMetadata"); withMetadataStr != "" {
+		withMetadata, err := strconv.ParseBool(withMetadataStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid withMetadata %q: use true or false", withMetadataStr)
+		}
+		req.WithMetadata = withMetadata
+	}
+	if concurrencyStr := get("concurrency"); concurrencyStr != "" {
+		concurrency, err := strconv.Atoi(concurrencyStr)
+		if err != nil || concurrency <= 0 {
+			return nil, fmt.Errorf("invalid concurrency %q: use a positive number", concurrencyStr)
+		}
+		req.Concurrency = concurrency
+	}
+
+	location := time.UTC
+	if timezone := get("timezone"); timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+	}
+	now := time.Now()
+	if startTimeStr := get("startTime"); startTimeStr != "" {
+		startTime, err := ParseTimeExpression(startTimeStr, now, location, false)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing start time: %w", err)
+		}
+		req.StartTime = &startTime
+	}
+	if endTimeStr := get("endTime"); endTimeStr != "" {
+		endTime, err := ParseTimeExpression(endTimeStr, now, location, true)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing end time: %w", err)
+		}
+		req.EndTime = &endTime
+	}
+	if req.StartTime != nil || req.EndTime != nil {
+		startTime, endTime, err := ParseTimes(req)
+		if err == nil {
+			err = ValidateTimeRange(*startTime, *endTime, req.Period, now)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// validPeriod reports whether CloudWatch accepts period: 1, 5, 10 or 30
+// seconds for high resolution metrics, else a multiple of 60.
+func validPeriod(period int64) bool {
+	switch period {
+	case 1, 5, 10, 30:
+		return true
+	}
+	return period > 0 && period%60 == 0
+}
+
+// MetricPeriod returns the period of metric queries over the given range:
+// Period when set, else one derived from the range and MaxDataPoints.
+func (r *PanelRequest) MetricPeriod(startTime, endTime *time.Time) int64 {
+	if r.Period > 0 {
+		return r.Period
+	}
+	return DerivePeriod(startTime, endTime, r.MaxDataPoints)
+}
+
+// MetricQuery returns a MetricQuery over the given range that uses the
+// Period and MaxDataPoints of r.
+func (r *PanelRequest) MetricQuery(startTime, endTime *time.Time) *MetricQuery {
+	query := NewMetricQuery(startTime, endTime, r.Period)
+	query.MaxDataPoints = r.MaxDataPoints
+	query.ctx = r.ctx
+	return query
+}
+
+// CloudWatchClient returns a cloudwatch client for the auth of r whose
+// GetMetricData calls are sent with the context of r, so they are retried,
+// cancelled and labelled in frames like the queries of MetricQuery.
+func (r *PanelRequest) CloudWatchClient() cloudwatchiface.CloudWatchAPI {
+	return requestCloudWatchClient{CloudWatchAPI: CloudWatchClient(*r.ClientAuth), ctx: r.Context()}
+}
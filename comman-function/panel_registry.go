@@ -0,0 +1,315 @@
+package comman_function
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ResponseTypeJson  = "json"
+	ResponseTypeFrame = "frame"
+	ResponseTypeTable = "table"
+
+	// AnyElementType registers a panel that does not depend on the element
+	// type, e.g. raw queries. It matches every --elementType.
+	AnyElementType = "*"
+)
+
+var (
+	JsonResponseTypes      = []string{ResponseTypeJson}
+	JsonFrameResponseTypes = []string{ResponseTypeJson, ResponseTypeFrame}
+	// LogsRowsResponseTypes are the response types of TypedLogsPanel panels.
+	LogsRowsResponseTypes = []string{ResponseTypeJson, ResponseTypeFrame, ResponseTypeTable}
+)
+
+// PanelDefinition describes one --query that AwsxCloudWatchMetricsCmd can dispatch.
+type PanelDefinition struct {
+	Name          string
+	ElementTypes  []string
+	ResponseTypes []string
+	Command       *cobra.Command
+	Panel         Panel
+	// Shape is the appkube panel type of --responseType=appkube, which
+	// panels without a shape do not support.
+	Shape AppkubeShape
+	// Unit is the grafana unit id of the values of the panel, e.g. percent
+	// or Bps. Without it appkube output takes the unit of the frames or
+	// guesses it from the value names.
+	Unit string
+}
+
+// SupportsResponseType reports whether the panel can render the given response type.
+// An empty response type means json.
+func (p *PanelDefinition) SupportsResponseType(responseType string) bool {
+	if responseType == "" {
+		responseType = ResponseTypeJson
+	}
+	if responseType == ResponseTypeAppkube {
+		return p.Shape != ""
+	}
+	for _, rt := range p.ResponseTypes {
+		if rt == responseType {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportedResponseTypes lists the response types the panel can render.
+func (p *PanelDefinition) SupportedResponseTypes() []string {
+	if p.Shape == "" {
+		return p.ResponseTypes
+	}
+	return append(append([]string{}, p.ResponseTypes...), ResponseTypeAppkube)
+}
+
+// MetricPanel adapts the common metric panel signature to a PanelFunc.
+func MetricPanel(fn func(*PanelRequest, cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error)) PanelFunc {
+	return func(req *PanelRequest) (*PanelResult, error) {
+		jsonResp, cloudwatchMetricResp, err := fn(req, req.CloudWatchClient())
+		return &PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+	}
+}
+
+// LogsPanel adapts the common logs insights panel signature to a PanelFunc.
+func LogsPanel(fn func(*PanelRequest, cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error)) PanelFunc {
+	return func(req *PanelRequest) (*PanelResult, error) {
+		queryResults, err := fn(req, nil)
+		return &PanelResult{Json: queryResults}, err
+	}
+}
+
+// TypedLogsPanel adapts a logs insights panel whose result rows decode into
+// row, a struct with logs tags (see DecodeLogsRows). The json and table output
+// are the decoded rows, the frame is the raw query results.
+func TypedLogsPanel(fn func(*PanelRequest, cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error), row interface{}) PanelFunc {
+	rowsType := reflect.SliceOf(reflect.TypeOf(row))
+	return func(req *PanelRequest) (*PanelResult, error) {
+		queryResults, err := fn(req, nil)
+		if err != nil {
+			return nil, err
+		}
+		rows := reflect.New(rowsType)
+		rows.Elem().Set(reflect.MakeSlice(rowsType, 0, 0))
+		if err := DecodeLogsRows(queryResults, rows.Interface()); err != nil {
+			return nil, fmt.Errorf("error decoding logs query results: %w", err)
+		}
+		return &PanelResult{Json: rows.Elem().Interface(), Frame: queryResults}, nil
+	}
+}
+
+type panelKey struct {
+	elementType string
+	name        string
+}
+
+var (
+	panelMu       sync.RWMutex
+	panelsByKey   = map[panelKey]*PanelDefinition{}
+	panelsOrdered []*PanelDefinition
+)
+
+// RegisterPanel adds a panel to the registry. It panics on an incomplete
+// definition or when the name is already registered for one of its element types.
+func RegisterPanel(def PanelDefinition) {
+	if def.Name == "" || def.Panel == nil || len(def.ElementTypes) == 0 {
+		panic(fmt.Sprintf("comman_function: incomplete panel definition %q", def.Name))
+	}
+	if len(def.ResponseTypes) == 0 {
+		def.ResponseTypes = JsonResponseTypes
+	}
+
+	panelMu.Lock()
+	defer panelMu.Unlock()
+
+	p := &def
+	for _, elementType := range def.ElementTypes {
+		key := panelKey{elementType: elementType, name: def.Name}
+		if _, exists := panelsByKey[key]; exists {
+			panic(fmt.Sprintf("comman_function: panel %q already registered for element type %q", def.Name, elementType))
+		}
+		panelsByKey[key] = p
+	}
+	panelsOrdered = append(panelsOrdered, p)
+}
+
+// RegisterPanels registers defs under the given element type aliases. Definitions
+// that declare their own ElementTypes keep them.
+func RegisterPanels(elementTypes []string, defs []PanelDefinition) {
+	for _, def := range defs {
+		if len(def.ElementTypes) == 0 {
+			def.ElementTypes = elementTypes
+		}
+		RegisterPanel(def)
+	}
+}
+
+// LookupPanel finds the panel registered under name for elementType.
+func LookupPanel(elementType, name string) (*PanelDefinition, error) {
+	panelMu.RLock()
+	defer panelMu.RUnlock()
+
+	if p, ok := panelsByKey[panelKey{elementType: elementType, name: name}]; ok {
+		return p, nil
+	}
+	if p, ok := panelsByKey[panelKey{elementType: AnyElementType, name: name}]; ok {
+		return p, nil
+	}
+
+	var available []string
+	for key := range panelsByKey {
+		if key.elementType == elementType {
+			available = append(available, key.name)
+		}
+	}
+	if len(available) == 0 {
+		return nil, &ValidationError{Err: fmt.Errorf("element type %q has no registered panels", elementType)}
+	}
+	sort.Strings(available)
+	return nil, &ValidationError{Err: fmt.Errorf("query %q is not registered for element type %q. available queries: %s", name, elementType, strings.Join(available, ", "))}
+}
+
+// Panels returns every registered panel in registration order.
+func Panels() []*PanelDefinition {
+	panelMu.RLock()
+	defer panelMu.RUnlock()
+
+	panels := make([]*PanelDefinition, len(panelsOrdered))
+	copy(panels, panelsOrdered)
+	return panels
+}
+
+// PanelCommands returns the cobra sub commands attached to registered panels.
+// Each runs its panel with run, so that the sub commands print the same
+// responses and errors as the command run takes them from. Panels of the same
+// name for other element types share the command of the first of them, which
+// takes their flags too; --elementType picks the panel it runs.
+func PanelCommands(run func(cmd *cobra.Command, p *PanelDefinition) error) []*cobra.Command {
+	var commands []*cobra.Command
+	seen := map[*cobra.Command]bool{}
+	byName := map[string]*cobra.Command{}
+	elementTypes := map[*cobra.Command][]string{}
+	shared := map[*cobra.Command]bool{}
+	for _, p := range Panels() {
+		if p.Command == nil || seen[p.Command] {
+			continue
+		}
+		seen[p.Command] = true
+		if first, ok := byName[p.Command.Name()]; ok {
+			first.Flags().AddFlagSet(p.Command.Flags())
+			first.PersistentFlags().AddFlagSet(p.Command.PersistentFlags())
+			elementTypes[first] = append(elementTypes[first], p.ElementTypes...)
+			shared[first] = true
+			continue
+		}
+		byName[p.Command.Name()] = p.Command
+		elementTypes[p.Command] = p.ElementTypes
+		p := p
+		p.Command.Run = nil
+		p.Command.RunE = func(cmd *cobra.Command, args []string) error {
+			return run(cmd, p)
+		}
+		commands = append(commands, p.Command)
+	}
+	for _, cmd := range commands {
+		if shared[cmd] {
+			cmd.Long = strings.TrimSpace(cmd.Long + "\n\nElement types: " + strings.Join(elementTypes[cmd], ", ") + ", picked with --elementType, the first by default.")
+		}
+	}
+	return commands
+}
+
+// ExecutePanel runs the panel registered for elementType/name. It is the entry
+// point for Go programs that use the panels as a library. Metric output in the
+// Frame of the result is converted to grafana frames, see MetricFrames. Errors
+// of aws calls are returned as an AwsApiError, a request without ClientAuth
+// fails with a ValidationError. The Metadata of the result describes its
+// data.
+func ExecutePanel(elementType, name string, req *PanelRequest) (*PanelResult, error) {
+	p, err := LookupPanel(elementType, name)
+	if err != nil {
+		return nil, err
+	}
+	if !p.SupportsResponseType(req.ResponseType) {
+		return nil, &ValidationError{Err: fmt.Errorf("panel %q does not support response type %q. supported: %s", name, req.ResponseType, strings.Join(p.SupportedResponseTypes(), ", "))}
+	}
+	if req.ClientAuth == nil {
+		return nil, &ValidationError{Err: errors.New("the request has no ClientAuth")}
+	}
+
+	if req.identities == nil {
+		req.identities = &identityLog{}
+	}
+	retries := &retryCounter{}
+	notes := &resultNotes{}
+	result, err := p.Panel.Run(req.WithContext(withResultNotes(withRetryCounter(req.Context(), retries), notes)))
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s: %w", name, asAwsApiError(err))
+	}
+	if result != nil {
+		notes.mu.Lock()
+		result.Series = notes.series
+		notes.mu.Unlock()
+		result.Metadata = resultMetadata(result, notes)
+		result.Frame = MetricFrames(result.Frame, result.Series)
+		result.Identities = req.Identities()
+		result.Retries = retries.retries.Load()
+	}
+	return result, nil
+}
+
+// RunPanel executes the panel registered for elementType/name with the inputs
+// given on the command line and prints its result in the requested response
+// type and output. A panel returning nothing or a result without data, see
+// ResultMetadata, fails with a NoDataError.
+func RunPanel(cmd *cobra.Command, clientAuth *model.Auth, elementType, name string) error {
+	req, err := NewPanelRequest(cmd, clientAuth)
+	if err != nil {
+		return err
+	}
+	req.ElementType = elementType
+
+	result, err := ExecutePanel(elementType, name, req)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return &NoDataError{Panel: name}
+	}
+	if result.Retries > 0 {
+		log.Printf("panel %s retried %d aws calls", name, result.Retries)
+	}
+	if m := result.Metadata; m != nil {
+		log.Printf("panel %s: %s, %d datapoints", name, m.Status, m.Datapoints)
+		if m.Status == ResultStatusNoData {
+			return &NoDataError{Panel: name}
+		}
+	}
+	p, err := LookupPanel(elementType, name)
+	if err != nil {
+		return err
+	}
+	output := req.OutputFormat()
+	payload, err := p.OutputPayload(result, req.ResponseType, output)
+	if err != nil {
+		return err
+	}
+	payload = req.WithMetadataPayload(payload, result, output)
+	if err := WriteOutput(os.Stdout, payload, output); err != nil {
+		return fmt.Errorf("error writing %s response as %s: %v", name, output, err)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package comman_function
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+)
+
+// CacheSettleDelay is how long after its end a bucket is still treated as
+// open. CloudWatch keeps adding late datapoints to recent buckets, so only
+// older ones are cached.
+var CacheSettleDelay = 3 * time.Minute
+
+// DefaultResultCacheSize is the number of entries the in-memory cache keeps.
+const DefaultResultCacheSize = 10000
+
+// Modes of --resultCache.
+const (
+	ResultCacheMemory = "memory"
+	ResultCacheDisk   = "disk"
+	ResultCacheOff    = "off"
+)
+
+// ResultCache stores CloudWatch results by key. Implementations must be safe
+// for concurrent use.
+type ResultCache interface {
+	Get(key string) ([]byte, bool)
+	Put(key string, value []byte)
+}
+
+// LRUCache is an in-memory ResultCache that drops the least recently used
+// entry beyond its size.
+type LRUCache struct {
+	size    int
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = DefaultResultCacheSize
+	}
+	return &LRUCache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry).value, true
+}
+
+func (c *LRUCache) Put(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruEntry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// DiskCache is a ResultCache of one file per entry in Dir, shared by every
+// run that uses the same dir.
+type DiskCache struct {
+	Dir string
+}
+
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{Dir: dir}
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	value, err := os.ReadFile(c.path(key))
+	return value, err == nil
+}
+
+func (c *DiskCache) Put(key string, value []byte) {
+	writeFileAtomic(c.path(key), value)
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.Dir, key[:2], key+".json")
+}
+
+// TieredCache looks entries up in Front, then in Back, copying hits from
+// Back to Front. Writes go to both.
+type TieredCache struct {
+	Front ResultCache
+	Back  ResultCache
+}
+
+func (c *TieredCache) Get(key string) ([]byte, bool) {
+	if value, ok := c.Front.Get(key); ok {
+		return value, true
+	}
+	value, ok := c.Back.Get(key)
+	if ok {
+		c.Front.Put(key, value)
+	}
+	return value, ok
+}
+
+func (c *TieredCache) Put(key string, value []byte) {
+	c.Front.Put(key, value)
+	c.Back.Put(key, value)
+}
+
+// NewResultCache returns the cache of mode: an LRUCache of size entries for
+// memory, the same in front of a DiskCache in dir for disk and nil for off.
+func NewResultCache(mode string, size int, dir string) (ResultCache, error) {
+	switch mode {
+	case "", ResultCacheMemory:
+		return NewLRUCache(size), nil
+	case ResultCacheDisk:
+		if dir == "" {
+			dir = DefaultResultCacheDir()
+		}
+		if dir == "" {
+			return nil, errors.New("no result cache dir: set --resultCacheDir")
+		}
+		return &TieredCache{Front: NewLRUCache(size), Back: NewDiskCache(dir)}, nil
+	case ResultCacheOff:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("invalid resultCache %q: use memory, disk or off", mode)
+}
+
+// DefaultResultCacheDir returns the dir of the disk result cache in the user
+// cache dir, empty when there is none.
+func DefaultResultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "awsx-getelementdetails", "results")
+}
+
+// CacheStats counts the lookups of the result cache since the process
+// started.
+type CacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+var (
+	resultCacheMu sync.RWMutex
+	resultCache   ResultCache = NewLRUCache(DefaultResultCacheSize)
+
+	resultCacheHits   atomic.Int64
+	resultCacheMisses atomic.Int64
+)
+
+// SetResultCache replaces the cache of metric and logs results and returns a
+// func restoring the previous one. A nil cache turns caching off.
+func SetResultCache(cache ResultCache) (restore func()) {
+	resultCacheMu.Lock()
+	previous := resultCache
+	resultCache = cache
+	resultCacheMu.Unlock()
+	return func() { SetResultCache(previous) }
+}
+
+// Results returns the current result cache, nil when caching is off or
+// fixtures are recorded or replayed.
+func Results() ResultCache {
+	if Replaying() || Recording() {
+		return nil
+	}
+	resultCacheMu.RLock()
+	defer resultCacheMu.RUnlock()
+	return resultCache
+}
+
+// ResultCacheStats returns the hits and misses of the result cache.
+func ResultCacheStats() CacheStats {
+	return CacheStats{Hits: resultCacheHits.Load(), Misses: resultCacheMisses.Load()}
+}
+
+// cacheGet looks key up in cache and counts the hit or miss.
+func cacheGet(cache ResultCache, key string) ([]byte, bool) {
+	value, ok := cache.Get(key)
+	if ok {
+		resultCacheHits.Add(1)
+	} else {
+		resultCacheMisses.Add(1)
+	}
+	return value, ok
+}
+
+// cacheKey hashes parts, the first of which scopes the key to the account
+// and region of clientAuth and the endpoint the calls go to.
+func cacheKey(clientAuth *model.Auth, parts ...string) string {
+	scope := EndpointUrl()
+	if clientAuth != nil {
+		scope += "|" + clientAuth.Region + "|" + clientAuth.AccessKey + "|" + clientAuth.CrossAccountRoleArn
+	}
+	sum := sha256.Sum256([]byte(scope + "\x00" + strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// alignDown and alignUp round t to a multiple of period seconds.
+func alignDown(t time.Time, period int64) time.Time {
+	return time.Unix(t.Unix()/period*period, 0).UTC()
+}
+
+func alignUp(t time.Time, period int64) time.Time {
+	down := alignDown(t, period)
+	if down.Before(t) {
+		return down.Add(time.Duration(period) * time.Second)
+	}
+	return down
+}
+
+// writeFileAtomic writes data through a temporary file so concurrent runs
+// never read half a file. Errors are logged, a cache is best effort.
+func writeFileAtomic(path string, data []byte) {
+	dir := filepath.Dir(path)
+	err := os.MkdirAll(dir, 0o700)
+	if err == nil {
+		var tmp *os.File
+		if tmp, err = os.CreateTemp(dir, filepath.Base(path)+".*.tmp"); err == nil {
+			_, err = tmp.Write(data)
+			if closeErr := tmp.Close(); err == nil {
+				err = closeErr
+			}
+			if err == nil {
+				err = os.Rename(tmp.Name(), path)
+			}
+			if err != nil {
+				os.Remove(tmp.Name())
+			}
+		}
+	}
+	if err != nil {
+		log.Printf("error writing cache file %s: %v", path, err)
+	}
+}
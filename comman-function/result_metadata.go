@@ -0,0 +1,351 @@
+package comman_function
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+// Statuses of a panel result.
+const (
+	ResultStatusOk      = "ok"
+	ResultStatusNoData  = "no_data"
+	ResultStatusPartial = "partial"
+)
+
+// ResultMetadata describes the data behind a panel result, so that a panel
+// showing 0 can be told apart from one that got no data.
+type ResultMetadata struct {
+	// Status is no_data without datapoints, partial when Partial is set and
+	// ok otherwise.
+	Status string `json:"status"`
+	// Datapoints counts the datapoints of metric series, the rows of logs
+	// queries, else the items of the json output.
+	Datapoints int `json:"datapoints"`
+	// Period is the period in seconds of the metric series.
+	Period int64      `json:"period,omitempty"`
+	First  *time.Time `json:"first,omitempty"`
+	Last   *time.Time `json:"last,omitempty"`
+	// Partial is set when cloudwatch returned part of a series, a logs query
+	// did not complete or some of the calls of a fan out failed.
+	Partial bool             `json:"partial"`
+	Series  []SeriesMetadata `json:"series,omitempty"`
+}
+
+// SeriesMetadata describes one metric series of a panel result.
+type SeriesMetadata struct {
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	Datapoints int        `json:"datapoints"`
+	Period     int64      `json:"period,omitempty"`
+	First      *time.Time `json:"first,omitempty"`
+	Last       *time.Time `json:"last,omitempty"`
+	Partial    bool       `json:"partial"`
+}
+
+// resultNotes collects what the calls made with the context of a panel learn
+// about its data: the period of its metric queries, the query of every
+// metric result, the series every query returned and whether some of its fan
+// out calls failed.
+type resultNotes struct {
+	mu      sync.Mutex
+	period  int64
+	partial bool
+	series  SeriesSet
+	// queried describes the series of the metric queries in the order they
+	// ran, including the ones the panel leaves out of its output.
+	queried []SeriesMetadata
+}
+
+type resultNotesKey struct{}
+
+// withResultNotes returns ctx collecting into notes.
+func withResultNotes(ctx context.Context, notes *resultNotes) context.Context {
+	return context.WithValue(ctx, resultNotesKey{}, notes)
+}
+
+// notePeriod records the period of a metric query run with ctx, keeping the
+// longest one.
+func notePeriod(ctx context.Context, period int64) {
+	if notes, ok := ctx.Value(resultNotesKey{}).(*resultNotes); ok {
+		notes.mu.Lock()
+		if period > notes.period {
+			notes.period = period
+		}
+		notes.mu.Unlock()
+	}
+}
+
+// noteResults records the query of every metric result returned to a panel
+// run with ctx and describes the series of every query that returns data, as
+// no_data when it got no result.
+func noteResults(ctx context.Context, queries []*cloudwatch.MetricDataQuery, results []*cloudwatch.MetricDataResult) {
+	notes, ok := ctx.Value(resultNotesKey{}).(*resultNotes)
+	if !ok {
+		return
+	}
+	seriesById := querySeries(queries)
+	resultsById := map[string]*cloudwatch.MetricDataResult{}
+	notes.mu.Lock()
+	defer notes.mu.Unlock()
+	if notes.series == nil {
+		notes.series = SeriesSet{}
+	}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		id := aws.StringValue(result.Id)
+		if _, ok := resultsById[id]; !ok {
+			resultsById[id] = result
+		}
+		if series, ok := seriesById[id]; ok {
+			notes.series[result] = series
+		}
+	}
+	for _, query := range queries {
+		if query == nil || query.ReturnData != nil && !*query.ReturnData {
+			continue
+		}
+		id := aws.StringValue(query.Id)
+		period := aws.Int64Value(query.Period)
+		if query.MetricStat != nil {
+			period = aws.Int64Value(query.MetricStat.Period)
+		}
+		if result, ok := resultsById[id]; ok {
+			notes.queried = append(notes.queried, describeSeries(id, result, period))
+		} else {
+			notes.queried = append(notes.queried, SeriesMetadata{Name: id, Status: ResultStatusNoData, Period: period})
+		}
+	}
+}
+
+// notePartial records that part of the data of the panel run with ctx is
+// missing.
+func notePartial(ctx context.Context) {
+	if notes, ok := ctx.Value(resultNotesKey{}).(*resultNotes); ok {
+		notes.mu.Lock()
+		notes.partial = true
+		notes.mu.Unlock()
+	}
+}
+
+// resultMetadata describes the data of result: the series of the metric
+// queries of the panel, else the metric series of its frame, else the rows of
+// its logs query results, else the items of its json.
+func resultMetadata(result *PanelResult, notes *resultNotes) *ResultMetadata {
+	notes.mu.Lock()
+	metadata := &ResultMetadata{Period: notes.period, Partial: notes.partial}
+	queried := notes.queried
+	notes.mu.Unlock()
+
+	if len(queried) > 0 {
+		for _, s := range queried {
+			metadata.add(s)
+		}
+	} else if series, ok := metricSeriesOf(result.Frame, result.Series); ok {
+		for _, s := range series {
+			metadata.add(s)
+		}
+	} else if outputs, ok := logsOutputsOf(result.Frame, result.Json); ok {
+		for _, output := range outputs {
+			if output == nil {
+				continue
+			}
+			if status := aws.StringValue(output.Status); status != "" && status != cloudwatchlogs.QueryStatusComplete {
+				metadata.Partial = true
+			}
+			metadata.Datapoints += len(output.Results)
+			for _, row := range output.Results {
+				for _, field := range row {
+					name := aws.StringValue(field.Field)
+					if name != "@timestamp" && !strings.HasPrefix(name, "bin(") {
+						continue
+					}
+					if t, err := ParseLogsTime(aws.StringValue(field.Value)); err == nil {
+						metadata.addTime(t)
+					}
+				}
+			}
+		}
+	} else {
+		metadata.Datapoints = countItems(result.Json)
+	}
+
+	switch {
+	case metadata.Datapoints == 0:
+		metadata.Status = ResultStatusNoData
+	case metadata.Partial:
+		metadata.Status = ResultStatusPartial
+	default:
+		metadata.Status = ResultStatusOk
+	}
+	return metadata
+}
+
+func (m *ResultMetadata) add(s SeriesMetadata) {
+	m.Series = append(m.Series, s)
+	m.Datapoints += s.Datapoints
+	m.Partial = m.Partial || s.Partial
+	if s.Period > m.Period {
+		m.Period = s.Period
+	}
+	if s.First != nil {
+		m.addTime(*s.First)
+	}
+	if s.Last != nil {
+		m.addTime(*s.Last)
+	}
+}
+
+func (m *ResultMetadata) addTime(t time.Time) {
+	if m.First == nil || t.Before(*m.First) {
+		m.First = &t
+	}
+	if m.Last == nil || t.After(*m.Last) {
+		m.Last = &t
+	}
+}
+
+// metricSeriesOf describes the series of the metric output of a panel, in
+// the shapes MetricFrames converts. ok is false for other values.
+func metricSeriesOf(value interface{}, seriesSet SeriesSet) (series []SeriesMetadata, ok bool) {
+	switch v := value.(type) {
+	case map[string]*cloudwatch.GetMetricDataOutput:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		series = []SeriesMetadata{}
+		for _, key := range keys {
+			if v[key] != nil {
+				series = append(series, seriesMetadata(key, v[key].MetricDataResults, seriesSet)...)
+			}
+		}
+		return series, true
+	case *map[string]*cloudwatch.GetMetricDataOutput:
+		if v == nil {
+			return []SeriesMetadata{}, true
+		}
+		return metricSeriesOf(*v, seriesSet)
+	case *cloudwatch.GetMetricDataOutput:
+		if v == nil {
+			return []SeriesMetadata{}, true
+		}
+		return seriesMetadata("", v.MetricDataResults, seriesSet), true
+	case []*cloudwatch.MetricDataResult:
+		return seriesMetadata("", v, seriesSet), true
+	}
+	return nil, false
+}
+
+func seriesMetadata(key string, results []*cloudwatch.MetricDataResult, seriesSet SeriesSet) []SeriesMetadata {
+	series := make([]SeriesMetadata, 0, len(results))
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		name := key
+		if id := aws.StringValue(result.Id); name == "" || len(results) > 1 && id != "" {
+			name = strings.TrimPrefix(key+"/"+id, "/")
+		}
+		query, _ := seriesSet.Of(result)
+		series = append(series, describeSeries(name, result, query.Period))
+	}
+	return series
+}
+
+// describeSeries describes the datapoints of one metric result.
+func describeSeries(name string, result *cloudwatch.MetricDataResult, period int64) SeriesMetadata {
+	s := SeriesMetadata{Name: name, Period: period}
+	if len(result.Timestamps) == 0 {
+		// Values worked out of other series have no timestamps.
+		for _, v := range result.Values {
+			if v != nil {
+				s.Datapoints++
+			}
+		}
+	}
+	for i, t := range result.Timestamps {
+		if t == nil || i >= len(result.Values) || result.Values[i] == nil {
+			continue
+		}
+		s.Datapoints++
+		if s.First == nil || t.Before(*s.First) {
+			s.First = aws.Time(*t)
+		}
+		if s.Last == nil || t.After(*s.Last) {
+			s.Last = aws.Time(*t)
+		}
+	}
+	if status := aws.StringValue(result.StatusCode); status != "" && status != cloudwatch.StatusCodeComplete {
+		s.Partial = true
+	}
+	switch {
+	case s.Datapoints == 0:
+		s.Status = ResultStatusNoData
+	case s.Partial:
+		s.Status = ResultStatusPartial
+	default:
+		s.Status = ResultStatusOk
+	}
+	return s
+}
+
+// logsOutputsOf returns the logs query results of a panel, which logs panels
+// return as their frame or json.
+func logsOutputsOf(values ...interface{}) ([]*cloudwatchlogs.GetQueryResultsOutput, bool) {
+	for _, value := range values {
+		switch v := value.(type) {
+		case []*cloudwatchlogs.GetQueryResultsOutput:
+			return v, true
+		case *cloudwatchlogs.GetQueryResultsOutput:
+			return []*cloudwatchlogs.GetQueryResultsOutput{v}, true
+		}
+	}
+	return nil, false
+}
+
+// countItems counts the items of the json output of a panel: the elements of
+// a list or object, 0 for nothing, 1 for any other value. Json text is
+// decoded first.
+func countItems(value interface{}) int {
+	if s, ok := value.(string); ok {
+		if strings.TrimSpace(s) == "" {
+			return 0
+		}
+		var decoded interface{}
+		if json.Unmarshal([]byte(s), &decoded) != nil {
+			return 1
+		}
+		value = decoded
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		return 0
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return 0
+		}
+		return v.Len()
+	case reflect.Array:
+		return v.Len()
+	}
+	return 1
+}
@@ -0,0 +1,357 @@
+package comman_function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// Services a RetryPolicy can be set for, keyed by the sdk ServiceID of their
+// clients.
+var retryServices = map[string]string{
+	"CloudWatch":                "cloudwatch",
+	"CloudWatch Logs":           "logs",
+	"EC2":                       "ec2",
+	"Lambda":                    "lambda",
+	"Elastic Load Balancing v2": "elbv2",
+	"API Gateway":               "apigateway",
+	"ApiGatewayV2":              "apigatewayv2",
+	"Auto Scaling":              "autoscaling",
+}
+
+// DefaultRetryService names the policy of services without their own.
+const DefaultRetryService = "default"
+
+// retryErrorCodes are retried on top of the throttling and transient errors
+// the sdk retries. Logs insights answers LimitExceededException when too many
+// queries run at once.
+var retryErrorCodes = map[string]bool{
+	"LimitExceededException": true,
+}
+
+// RetryPolicy is how the calls to one aws service are retried and rate
+// limited. Failed calls are retried up to MaxRetries times after a delay that
+// doubles from BaseDelay up to MaxDelay, of which a random half is waited.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	// RateLimits bounds the calls of an operation, e.g. StartQuery, per
+	// region.
+	RateLimits map[string]RateLimit
+}
+
+// RateLimit is a token bucket: Rate calls per second on average and bursts
+// of up to Burst calls.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// DefaultRetryPolicies keep well within the default aws quotas of the calls
+// panels make most.
+var DefaultRetryPolicies = map[string]RetryPolicy{
+	DefaultRetryService: {MaxRetries: 5, BaseDelay: 200 * time.Millisecond, MaxDelay: 20 * time.Second},
+	"cloudwatch": {MaxRetries: 5, BaseDelay: 200 * time.Millisecond, MaxDelay: 20 * time.Second, RateLimits: map[string]RateLimit{
+		"GetMetricData": {Rate: 50, Burst: 50},
+	}},
+	"logs": {MaxRetries: 8, BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second, RateLimits: map[string]RateLimit{
+		"StartQuery":      {Rate: 5, Burst: 5},
+		"GetQueryResults": {Rate: 5, Burst: 10},
+	}},
+	"ec2": {MaxRetries: 5, BaseDelay: 200 * time.Millisecond, MaxDelay: 20 * time.Second, RateLimits: map[string]RateLimit{
+		"DescribeInstances":      {Rate: 20, Burst: 100},
+		"DescribeInstanceStatus": {Rate: 20, Burst: 100},
+	}},
+}
+
+// retryPolicyConfig is one service of a retry config file. Fields left out
+// keep the value of the default policy.
+type retryPolicyConfig struct {
+	MaxRetries *int                 `json:"maxRetries"`
+	BaseDelay  string               `json:"baseDelay"`
+	MaxDelay   string               `json:"maxDelay"`
+	RateLimits map[string]RateLimit `json:"rateLimits"`
+}
+
+// LoadRetryPolicies reads the retry policies of services from a yaml or json
+// file, keyed by service, e.g. logs, with default for every other service.
+// Services left out keep DefaultRetryPolicies.
+func LoadRetryPolicies(path string) (map[string]RetryPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading retry config: %w", err)
+	}
+	configs := map[string]*retryPolicyConfig{}
+	if strings.HasSuffix(path, ".json") {
+		err = json.Unmarshal(data, &configs)
+	} else {
+		err = UnmarshalYaml(data, &configs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing retry config %s: %w", path, err)
+	}
+
+	policies := map[string]RetryPolicy{}
+	for service, policy := range DefaultRetryPolicies {
+		policies[service] = policy
+	}
+	defaults := policies[DefaultRetryService]
+	if config := configs[DefaultRetryService]; config != nil {
+		if defaults, err = config.apply(DefaultRetryService, defaults); err != nil {
+			return nil, err
+		}
+		policies[DefaultRetryService] = defaults
+	}
+	for service, config := range configs {
+		if service == DefaultRetryService || config == nil {
+			continue
+		}
+		if !knownRetryService(service) {
+			return nil, fmt.Errorf("unknown service %q in retry config %s", service, path)
+		}
+		base, ok := policies[service]
+		if !ok {
+			base = defaults
+		}
+		if policies[service], err = config.apply(service, base); err != nil {
+			return nil, err
+		}
+	}
+	return policies, nil
+}
+
+func (c *retryPolicyConfig) apply(service string, policy RetryPolicy) (RetryPolicy, error) {
+	if c.MaxRetries != nil {
+		if *c.MaxRetries < 0 {
+			return policy, fmt.Errorf("invalid maxRetries %d of %s: use 0 or more", *c.MaxRetries, service)
+		}
+		policy.MaxRetries = *c.MaxRetries
+	}
+	for _, delay := range []struct {
+		name  string
+		value string
+		field *time.Duration
+	}{{"baseDelay", c.BaseDelay, &policy.BaseDelay}, {"maxDelay", c.MaxDelay, &policy.MaxDelay}} {
+		if delay.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(delay.value)
+		if err != nil || d <= 0 {
+			return policy, fmt.Errorf("invalid %s %q of %s: use a duration such as 500ms", delay.name, delay.value, service)
+		}
+		*delay.field = d
+	}
+	if c.RateLimits != nil {
+		limits := map[string]RateLimit{}
+		for operation, limit := range policy.RateLimits {
+			limits[operation] = limit
+		}
+		for operation, limit := range c.RateLimits {
+			if limit.Rate < 0 || limit.Burst < 0 {
+				return policy, fmt.Errorf("invalid rate limit of %s %s: use a positive rate and burst", service, operation)
+			}
+			// A rate of 0 lifts the default limit of the operation.
+			if limit.Rate == 0 {
+				delete(limits, operation)
+				continue
+			}
+			limits[operation] = limit
+		}
+		policy.RateLimits = limits
+	}
+	return policy, nil
+}
+
+func knownRetryService(service string) bool {
+	for _, name := range retryServices {
+		if name == service {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	retryPoliciesMu sync.RWMutex
+	retryPolicies   = DefaultRetryPolicies
+
+	tokenBucketsMu sync.Mutex
+	tokenBuckets   = map[string]*tokenBucket{}
+
+	awsRetries atomic.Int64
+)
+
+// SetRetryPolicies replaces the retry policies of the clients created from
+// now on and returns a func restoring the previous ones.
+func SetRetryPolicies(policies map[string]RetryPolicy) (restore func()) {
+	retryPoliciesMu.Lock()
+	previous := retryPolicies
+	retryPolicies = policies
+	retryPoliciesMu.Unlock()
+
+	tokenBucketsMu.Lock()
+	tokenBuckets = map[string]*tokenBucket{}
+	tokenBucketsMu.Unlock()
+	return func() { SetRetryPolicies(previous) }
+}
+
+// RetryPolicyOf returns the policy of service, the default one when it has
+// none.
+func RetryPolicyOf(service string) RetryPolicy {
+	retryPoliciesMu.RLock()
+	defer retryPoliciesMu.RUnlock()
+	if policy, ok := retryPolicies[service]; ok {
+		return policy
+	}
+	return retryPolicies[DefaultRetryService]
+}
+
+// AwsRetries returns the number of aws calls retried since the process
+// started.
+func AwsRetries() int64 {
+	return awsRetries.Load()
+}
+
+// applyRetryPolicy sets the retryer and rate limits of its service on an sdk
+// client. Other clients, e.g. fakes, are left alone, and so are replayed
+// calls.
+func applyRetryPolicy(sdkClient interface{}) {
+	c := sdkClientOf(sdkClient)
+	if c == nil || Replaying() {
+		return
+	}
+	service := retryServices[c.ServiceID]
+	policy := RetryPolicyOf(service)
+	c.Retryer = policyRetryer{service: service, policy: policy}
+	c.Handlers.Sign.PushFrontNamed(request.NamedHandler{Name: "awsx.RateLimit", Fn: func(r *request.Request) {
+		limit, ok := policy.RateLimits[r.Operation.Name]
+		if !ok {
+			return
+		}
+		if err := bucketOf(service, aws.StringValue(r.Config.Region), r.Operation.Name, limit).wait(r.Context()); err != nil {
+			r.Error = awserr.New(request.CanceledErrorCode, "request context canceled", err)
+			r.Retryable = aws.Bool(false)
+		}
+	}})
+}
+
+// policyRetryer is the request.Retryer of a RetryPolicy.
+type policyRetryer struct {
+	service string
+	policy  RetryPolicy
+}
+
+func (p policyRetryer) MaxRetries() int {
+	return p.policy.MaxRetries
+}
+
+func (p policyRetryer) ShouldRetry(r *request.Request) bool {
+	if p.policy.MaxRetries == 0 {
+		return false
+	}
+	if r.Retryable != nil {
+		return *r.Retryable
+	}
+	if err, ok := r.Error.(awserr.Error); ok && retryErrorCodes[err.Code()] {
+		return true
+	}
+	return r.IsErrorRetryable() || r.IsErrorThrottle()
+}
+
+// RetryRules is called once before every retry, so it also counts them.
+func (p policyRetryer) RetryRules(r *request.Request) time.Duration {
+	delay := p.policy.MaxDelay
+	if r.RetryCount < 30 {
+		if backoff := p.policy.BaseDelay << uint(r.RetryCount); backoff > 0 && backoff < delay {
+			delay = backoff
+		}
+	}
+	if delay > 1 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+
+	awsRetries.Add(1)
+	if counter, ok := r.Context().Value(retryCounterKey{}).(*retryCounter); ok {
+		counter.retries.Add(1)
+	}
+	log.Printf("Retrying %s %s in %v (retry %d of %d): %v", p.service, r.Operation.Name, delay.Round(time.Millisecond), r.RetryCount+1, p.policy.MaxRetries, r.Error)
+	return delay
+}
+
+// retryCounter counts the retries of the calls made with a panel context.
+type retryCounter struct {
+	retries atomic.Int64
+}
+
+type retryCounterKey struct{}
+
+// withRetryCounter returns ctx counting the retries of the calls made with it
+// into counter.
+func withRetryCounter(ctx context.Context, counter *retryCounter) context.Context {
+	return context.WithValue(ctx, retryCounterKey{}, counter)
+}
+
+// tokenBucket holds up to burst tokens, refilled at rate tokens a second.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func bucketOf(service, region, operation string, limit RateLimit) *tokenBucket {
+	key := service + "|" + region + "|" + operation
+	tokenBucketsMu.Lock()
+	defer tokenBucketsMu.Unlock()
+	bucket, ok := tokenBuckets[key]
+	if !ok {
+		burst := float64(limit.Burst)
+		if burst < 1 {
+			burst = 1
+		}
+		bucket = &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, last: time.Now()}
+		tokenBuckets[key] = bucket
+	}
+	return bucket
+}
+
+// wait takes a token, waiting for one to be refilled until ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package comman_function
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+)
+
+// failedRequest returns a GetMetricData request that failed with err.
+func failedRequest(t *testing.T, err error, statusCode int) *request.Request {
+	t.Helper()
+	sess, sessErr := session.NewSession(&aws.Config{Region: aws.String("us-east-1"), Credentials: credentials.AnonymousCredentials})
+	if sessErr != nil {
+		t.Fatal(sessErr)
+	}
+	r, _ := cloudwatch.New(sess).GetMetricDataRequest(&cloudwatch.GetMetricDataInput{})
+	r.Error = err
+	r.HTTPResponse = &http.Response{StatusCode: statusCode}
+	return r
+}
+
+func TestPolicyRetryerShouldRetry(t *testing.T) {
+	retryer := policyRetryer{service: "cloudwatch", policy: RetryPolicy{MaxRetries: 3}}
+
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		want       bool
+	}{
+		{name: "cloudwatch throttling", err: awserr.New("Throttling", "Rate exceeded", nil), statusCode: 400, want: true},
+		{name: "logs throttling", err: awserr.New("ThrottlingException", "Rate exceeded", nil), statusCode: 400, want: true},
+		{name: "ec2 request limit", err: awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil), statusCode: 503, want: true},
+		{name: "logs insights concurrency", err: awserr.New("LimitExceededException", "too many queries", nil), statusCode: 400, want: true},
+		{name: "too many requests", err: awserr.NewRequestFailure(awserr.New("TooManyRequestsException", "slow down", nil), 429, "id"), statusCode: 429, want: true},
+		{name: "internal failure", err: awserr.NewRequestFailure(awserr.New("InternalFailure", "oops", nil), 500, "id"), statusCode: 500, want: true},
+		{name: "connection reset", err: errors.New("read: connection reset by peer"), want: true},
+		{name: "access denied", err: awserr.New("AccessDenied", "not allowed", nil), statusCode: 403},
+		{name: "invalid parameter", err: awserr.New("InvalidParameterValue", "bad period", nil), statusCode: 400},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryer.ShouldRetry(failedRequest(t, tt.err, tt.statusCode)); got != tt.want {
+				t.Errorf("ShouldRetry = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	throttled := awserr.New("Throttling", "Rate exceeded", nil)
+	if (policyRetryer{policy: RetryPolicy{MaxRetries: 0}}).ShouldRetry(failedRequest(t, throttled, 400)) {
+		t.Error("a policy without retries retried a throttled call")
+	}
+	r := failedRequest(t, throttled, 400)
+	r.Retryable = aws.Bool(false)
+	if retryer.ShouldRetry(r) {
+		t.Error("retried a call marked as not retryable, e.g. one cancelled by the rate limit")
+	}
+}
+
+func TestPolicyRetryerRetryRules(t *testing.T) {
+	retryer := policyRetryer{service: "cloudwatch", policy: RetryPolicy{MaxRetries: 50, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}}
+	counter := &retryCounter{}
+
+	// The delay doubles from BaseDelay up to MaxDelay, of which a random
+	// half is waited.
+	caps := map[int]time.Duration{0: 100 * time.Millisecond, 1: 200 * time.Millisecond, 3: 800 * time.Millisecond, 4: time.Second, 29: time.Second, 40: time.Second}
+	before := AwsRetries()
+	for retryCount, limit := range caps {
+		for i := 0; i < 20; i++ {
+			r := failedRequest(t, awserr.New("Throttling", "Rate exceeded", nil), 400)
+			r.SetContext(withRetryCounter(context.Background(), counter))
+			r.RetryCount = retryCount
+			if delay := retryer.RetryRules(r); delay < limit/2 || delay > limit {
+				t.Fatalf("retry %d waits %v, want between %v and %v", retryCount, delay, limit/2, limit)
+			}
+		}
+	}
+	if got := counter.retries.Load(); got != int64(20*len(caps)) {
+		t.Errorf("counted %d retries of the panel, want %d", got, 20*len(caps))
+	}
+	if got := AwsRetries() - before; got < int64(20*len(caps)) {
+		t.Errorf("AwsRetries grew by %d, want at least %d", got, 20*len(caps))
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := &tokenBucket{rate: 50, burst: 2, tokens: 2, last: time.Now()}
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := bucket.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 15*time.Millisecond {
+		t.Errorf("the burst took %v, want no wait", elapsed)
+	}
+
+	// Three more calls wait for three tokens refilled at 50 a second.
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		if err := bucket.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Errorf("three calls past the burst took %v, want about 60ms", elapsed)
+	}
+
+	// A call stops waiting when its context is done.
+	slow := &tokenBucket{rate: 0.01, burst: 1, tokens: 0, last: time.Now()}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := slow.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestBucketOf(t *testing.T) {
+	t.Cleanup(SetRetryPolicies(DefaultRetryPolicies))
+	limit := RateLimit{Rate: 5, Burst: 0}
+	bucket := bucketOf("logs", "us-east-1", "StartQuery", limit)
+	if bucket.burst != 1 {
+		t.Errorf("burst = %v, want at least 1", bucket.burst)
+	}
+	if bucketOf("logs", "us-east-1", "StartQuery", limit) != bucket {
+		t.Error("calls of the same operation and region do not share a bucket")
+	}
+	if bucketOf("logs", "eu-west-1", "StartQuery", limit) == bucket {
+		t.Error("calls of another region share the bucket")
+	}
+}
@@ -0,0 +1,38 @@
+package comman_function
+
+// PanelSample is one numeric value of a panel result, named by its series.
+type PanelSample struct {
+	Series string
+	Value  float64
+}
+
+// PanelSamples returns the current values of a panel result: the numbers of
+// its json output, e.g. AverageUsage of {"AverageUsage":29.8}, or else the
+// newest value of each of its frames. Panels that output rows or text and
+// no metric frames have no samples.
+func PanelSamples(result *PanelResult) []PanelSample {
+	if result == nil {
+		return nil
+	}
+	var samples []PanelSample
+	switch v := appkubeJson(result.Json).(type) {
+	case *jsonObject:
+		for _, leaf := range v.leaves() {
+			if number, ok := leaf.value.(float64); ok {
+				samples = append(samples, PanelSample{Series: leaf.key, Value: number})
+			}
+		}
+	case float64:
+		samples = append(samples, PanelSample{Series: "value", Value: v})
+	}
+	if len(samples) > 0 {
+		return samples
+	}
+	frames, _ := result.Frame.([]Frame)
+	for _, frame := range frames {
+		if last, ok := frame.last(); ok {
+			samples = append(samples, PanelSample{Series: frame.label(), Value: last})
+		}
+	}
+	return samples
+}
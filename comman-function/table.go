@@ -0,0 +1,87 @@
+package comman_function
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// RenderTable renders rows, a slice of structs, as a text table with one column
+// per exported field. Columns are named after the json tag of the field.
+func RenderTable(rows interface{}) (string, error) {
+	columns, cells, ok := structRows(rows)
+	if !ok {
+		return "", fmt.Errorf("table output needs a list of rows, got %T", rows)
+	}
+	return renderTable(columns, cells), nil
+}
+
+func renderTable(columns []string, rows [][]interface{}) string {
+	var buffer bytes.Buffer
+	table := tablewriter.NewWriter(&buffer)
+	table.SetHeader(columns)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	for _, row := range rows {
+		table.Append(outputCells(row))
+	}
+	table.Render()
+	return buffer.String()
+}
+
+// structRows tabulates a list of structs with one column per exported field,
+// named after its json tag. Zero times and nil pointers are empty cells.
+func structRows(list interface{}) ([]string, [][]interface{}, bool) {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.Struct {
+		return nil, nil, false
+	}
+	elemType := value.Type().Elem()
+
+	var columns []string
+	var fields []int
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, name)
+		fields = append(fields, i)
+	}
+
+	rows := make([][]interface{}, value.Len())
+	for i := range rows {
+		rows[i] = make([]interface{}, len(fields))
+		for j, field := range fields {
+			rows[i][j] = structCell(value.Index(i).Field(field))
+		}
+	}
+	return columns, rows, true
+}
+
+func structCell(value reflect.Value) interface{} {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if t, ok := value.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return nil
+		}
+		return t.UTC()
+	}
+	return value.Interface()
+}
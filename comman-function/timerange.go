@@ -0,0 +1,166 @@
+package comman_function
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the absolute times ParseTimeExpression reads besides
+// RFC3339. They have no offset and are read in the requested location.
+var timeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+var (
+	epochPattern     = regexp.MustCompile(`^[0-9]{10,}$`)
+	relativeTimeStep = regexp.MustCompile(`^(?:([+-])([0-9]+)|/)([smhdwMy])`)
+)
+
+// ParseTimeExpression reads a --startTime or --endTime value:
+//
+//   - RFC3339, e.g. 2024-03-01T10:00:00Z, or a date and time without offset
+//     in location, e.g. 2024-03-01 10:00:00
+//   - epoch seconds of 10 or 11 digits, e.g. 1709287200, or epoch
+//     milliseconds of 12 digits or more, e.g. 1709287200000
+//   - now, optionally moved by steps such as -6h or +1d and rounded with
+//     /d, e.g. now-6h, now-7d/d or now/w
+//
+// Units are s, m, h, d, w (weeks starting on Monday), M and y. Days and
+// larger units follow the calendar of location. Rounding goes to the start
+// of the unit, or for the end of a range (end true) to the start of the next
+// one, so now-1d/d to now-1d/d is the whole of yesterday.
+func ParseTimeExpression(value string, now time.Time, location *time.Location, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if location == nil {
+		location = time.UTC
+	}
+	if strings.HasPrefix(value, "now") {
+		t := now.In(location)
+		for rest := value[len("now"):]; rest != ""; {
+			step := relativeTimeStep.FindStringSubmatch(rest)
+			if step == nil {
+				return time.Time{}, fmt.Errorf("cannot parse %q: expected a step like -6h or /d at %q", value, rest)
+			}
+			rest = rest[len(step[0]):]
+			if step[1] == "" {
+				t = roundTime(t, step[3], end)
+				continue
+			}
+			n, err := strconv.Atoi(step[2])
+			if err != nil {
+				return time.Time{}, fmt.Errorf("cannot parse %q: %w", value, err)
+			}
+			if step[1] == "-" {
+				n = -n
+			}
+			t = addTime(t, n, step[3])
+		}
+		return t, nil
+	}
+	if epochPattern.MatchString(value) {
+		epoch, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot parse %q as epoch time: %w", value, err)
+		}
+		// Epoch seconds reach 12 digits in the year 5138 and epoch
+		// milliseconds had 12 digits by 1973.
+		if len(value) <= 11 {
+			return time.Unix(epoch, 0).In(location), nil
+		}
+		return time.UnixMilli(epoch).In(location), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as RFC3339, epoch seconds or milliseconds or a relative time like now-6h", value)
+}
+
+func addTime(t time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "s":
+		return t.Add(time.Duration(n) * time.Second)
+	case "m":
+		return t.Add(time.Duration(n) * time.Minute)
+	case "h":
+		return t.Add(time.Duration(n) * time.Hour)
+	case "d":
+		return t.AddDate(0, 0, n)
+	case "w":
+		return t.AddDate(0, 0, 7*n)
+	case "M":
+		return t.AddDate(0, n, 0)
+	}
+	return t.AddDate(n, 0, 0)
+}
+
+func roundTime(t time.Time, unit string, end bool) time.Time {
+	var start time.Time
+	switch unit {
+	case "s":
+		start = t.Truncate(time.Second)
+	case "m":
+		start = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+	case "h":
+		start = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case "d":
+		start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	case "w":
+		start = time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case "M":
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		start = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+	}
+	if end {
+		return addTime(start, 1, unit)
+	}
+	return start
+}
+
+// MetricRetention is how long CloudWatch keeps datapoints of the given
+// period in seconds: 3 hours below a minute, 15 days below 5 minutes, 63
+// days below an hour and 455 days otherwise. A period of 0 is not yet
+// chosen and gets the longest retention.
+func MetricRetention(period int64) time.Duration {
+	const day = 24 * time.Hour
+	switch {
+	case period <= 0:
+		return 455 * day
+	case period < 60:
+		return 3 * time.Hour
+	case period < 300:
+		return 15 * day
+	case period < 3600:
+		return 63 * day
+	}
+	return 455 * day
+}
+
+// ValidateTimeRange rejects a range that does not start before it ends or
+// that starts before CloudWatch retention of period ends.
+func ValidateTimeRange(startTime, endTime time.Time, period int64, now time.Time) error {
+	if !startTime.Before(endTime) {
+		return fmt.Errorf("start time %s is not before end time %s", startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+	}
+	retention := MetricRetention(period)
+	if startTime.Before(now.Add(-retention)) {
+		if period <= 0 {
+			return fmt.Errorf("start time %s is older than the %s CloudWatch keeps metrics", startTime.Format(time.RFC3339), formatRetention(retention))
+		}
+		return fmt.Errorf("start time %s is older than the %s CloudWatch keeps %ds datapoints", startTime.Format(time.RFC3339), formatRetention(retention), period)
+	}
+	return nil
+}
+
+func formatRetention(retention time.Duration) string {
+	if retention < 24*time.Hour {
+		return fmt.Sprintf("%d hours", int(retention.Hours()))
+	}
+	return fmt.Sprintf("%d days", int(retention.Hours()/24))
+}
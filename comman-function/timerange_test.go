@@ -0,0 +1,113 @@
+package comman_function_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+func TestParseTimeExpression(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2024, 3, 6, 15, 30, 45, 0, time.UTC)
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+
+	tests := []struct {
+		value    string
+		location *time.Location
+		end      bool
+		want     time.Time
+	}{
+		{value: "now", want: now},
+		{value: " now ", want: now},
+		{value: "now-6h", want: now.Add(-6 * time.Hour)},
+		{value: "now+1d", want: time.Date(2024, 3, 7, 15, 30, 45, 0, time.UTC)},
+		{value: "now-90s", want: now.Add(-90 * time.Second)},
+		{value: "now-15m", want: now.Add(-15 * time.Minute)},
+		{value: "now-2w", want: time.Date(2024, 2, 21, 15, 30, 45, 0, time.UTC)},
+		{value: "now-1M", want: time.Date(2024, 2, 6, 15, 30, 45, 0, time.UTC)},
+		{value: "now-1y", want: time.Date(2023, 3, 6, 15, 30, 45, 0, time.UTC)},
+		{value: "now-1d-6h", want: time.Date(2024, 3, 5, 9, 30, 45, 0, time.UTC)},
+		{value: "now/d", want: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{value: "now/d", end: true, want: time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC)},
+		{value: "now-1d/d", want: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{value: "now-1d/d", end: true, want: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{value: "now-7d/d", want: time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{value: "now/h", want: time.Date(2024, 3, 6, 15, 0, 0, 0, time.UTC)},
+		{value: "now/w", want: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{value: "now/w", end: true, want: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{value: "now/M", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "now/y", end: true, want: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Days are aligned to the location: it is already 21:00 in Kolkata.
+		{value: "now/d", location: kolkata, want: time.Date(2024, 3, 6, 0, 0, 0, 0, kolkata)},
+		{value: "now+3h/d", location: kolkata, want: time.Date(2024, 3, 7, 0, 0, 0, 0, kolkata)},
+		{value: "2024-03-01T10:00:00Z", want: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{value: "2024-03-01T10:00:00+05:30", location: time.UTC, want: time.Date(2024, 3, 1, 4, 30, 0, 0, time.UTC)},
+		{value: "2024-03-01 10:00:00", location: kolkata, want: time.Date(2024, 3, 1, 10, 0, 0, 0, kolkata)},
+		{value: "2024-03-01", location: kolkata, want: time.Date(2024, 3, 1, 0, 0, 0, 0, kolkata)},
+		{value: "1709287200", want: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{value: "1709287200000", want: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{value: "1709287200123", want: time.Date(2024, 3, 1, 10, 0, 0, 123e6, time.UTC)},
+	}
+	for _, tt := range tests {
+		name := tt.value
+		if tt.end {
+			name += " as end"
+		}
+		if tt.location != nil {
+			name += " in " + tt.location.String()
+		}
+		t.Run(name, func(t *testing.T) {
+			got, err := comman_function.ParseTimeExpression(tt.value, now, tt.location, tt.end)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTimeExpression(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	for _, value := range []string{"", "yesterday", "now-6", "now-6x", "now/", "now-h", "2024-13-01", "170928720"} {
+		if got, err := comman_function.ParseTimeExpression(value, now, nil, false); err == nil {
+			t.Errorf("ParseTimeExpression(%q) = %s, want an error", value, got)
+		}
+	}
+}
+
+func TestValidateTimeRange(t *testing.T) {
+	now := time.Date(2024, 3, 6, 15, 30, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name   string
+		start  time.Time
+		end    time.Time
+		period int64
+		err    string
+	}{
+		{name: "last hour", start: now.Add(-time.Hour), end: now, period: 60},
+		{name: "start after end", start: now, end: now.Add(-time.Hour), err: "is not before end time"},
+		{name: "empty range", start: now, end: now, err: "is not before end time"},
+		{name: "sub minute period within 3 hours", start: now.Add(-2 * time.Hour), end: now, period: 10},
+		{name: "sub minute period before 3 hours", start: now.Add(-4 * time.Hour), end: now, period: 10, err: "older than the 3 hours CloudWatch keeps 10s datapoints"},
+		{name: "minute period within 15 days", start: now.Add(-14 * day), end: now, period: 60},
+		{name: "minute period before 15 days", start: now.Add(-16 * day), end: now, period: 60, err: "older than the 15 days"},
+		{name: "5 minute period within 63 days", start: now.Add(-62 * day), end: now, period: 300},
+		{name: "5 minute period before 63 days", start: now.Add(-64 * day), end: now, period: 300, err: "older than the 63 days"},
+		{name: "hour period before 455 days", start: now.Add(-456 * day), end: now, period: 3600, err: "older than the 455 days"},
+		{name: "unset period before 455 days", start: now.Add(-456 * day), end: now, err: "older than the 455 days CloudWatch keeps metrics"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := comman_function.ValidateTimeRange(tt.start, tt.end, tt.period, now)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("ValidateTimeRange = %v, want nil", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("ValidateTimeRange = %v, want an error containing %q", err, tt.err)
+			}
+		})
+	}
+}
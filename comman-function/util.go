@@ -6,86 +6,78 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/cmdb"
 	"github.com/Appkube-awsx/awsx-common/config"
+	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/spf13/cobra"
 )
 
-func ParseTimes(cmd *cobra.Command) (*time.Time, *time.Time, error) {
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
+// ParseTimes returns the time range of req. The end defaults to now and the
+// start to five minutes before the end.
+func ParseTimes(req *PanelRequest) (*time.Time, *time.Time, error) {
+	startTime, endTime := req.StartTime, req.EndTime
 
-	var startTime, endTime *time.Time
+	if endTime == nil {
+		defaultEndTime := time.Now()
+		endTime = &defaultEndTime
+	}
 
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return nil, nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
+	if startTime == nil {
+		defaultStartTime := endTime.Add(-5 * time.Minute)
 		startTime = &defaultStartTime
 	}
 
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return nil, nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	if !startTime.Before(*endTime) {
+		return nil, nil, fmt.Errorf("start time %s is not before end time %s", startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
 	}
-
 	return startTime, endTime, nil
 }
 
-func GetCmdbData(cmd *cobra.Command) (string, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-
-	if elementId != "" {
-		log.Println("Getting cloud-element data from CMDB")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("Using default CMDB URL")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("CMDB URL: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", fmt.Errorf("error getting cloud element data: %v", err)
-		}
-		return cmdbData.InstanceId, nil
+// GetCloudElement returns the cloud element of req.ElementId from the element
+// map, the CMDB cache or the CMDB, see CmdbResolver. An element already set on
+// req.CloudElement is returned without resolving it again. A request without
+// an element id fails with a ValidationError.
+func GetCloudElement(req *PanelRequest) (*model.CloudElement, error) {
+	if req.CloudElement != nil {
+		return req.CloudElement, nil
+	}
+	elementId := req.ElementId
+	if elementId == "" {
+		return nil, &ValidationError{Err: errors.New("element ID is required")}
 	}
 
-	return "", errors.New("element ID is required")
+	log.Println("Getting cloud-element data from CMDB")
+	apiUrl := req.CmdbApiUrl
+	if apiUrl == "" {
+		apiUrl = config.CmdbUrl
+	}
+	cmdbData, err := Cmdb().Resolve(apiUrl, elementId)
+	if err != nil {
+		return nil, &CmdbError{ElementId: elementId, Err: fmt.Errorf("error getting cloud element data: %w", err)}
+	}
+	return cmdbData, nil
 }
 
-func GetCmdbLogsData(cmd *cobra.Command) (string, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-
-	if elementId != "" {
-		log.Println("Getting cloud-element data from CMDB")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("Using default CMDB URL")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("CMDB URL: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", fmt.Errorf("error getting cloud element data: %v", err)
-		}
-		return cmdbData.LogGroup, nil
+// GetCmdbData returns the identifier of the element of req, e.g. its
+// FunctionName for Lambda, from the inputs or the CMDB. See ResolveIdentity.
+func GetCmdbData(req *PanelRequest) (string, error) {
+	identity, err := ResolveElementIdentity(req)
+	if err != nil {
+		return "", err
 	}
+	return identity.Value, nil
+}
 
-	return "", errors.New("element ID is required")
+// GetCmdbLogsData returns the log group of req: --logGroupName when given,
+// else the one of its cloud element.
+func GetCmdbLogsData(req *PanelRequest) (string, error) {
+	if req.LogGroupName != "" {
+		return req.LogGroupName, nil
+	}
+	cmdbData, err := GetCloudElement(req)
+	if err != nil {
+		return "", err
+	}
+	return cmdbData.LogGroup, nil
 }
 
 func InitAwsCmdFlags(cmd *cobra.Command) {
@@ -94,6 +86,13 @@ func InitAwsCmdFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().String("ebsvolume2Id", "", "ebs volume 2 id")
 	cmd.PersistentFlags().String("elementId", "", "element id")
 	cmd.PersistentFlags().String("cmdbApiUrl", "", "cmdb api")
+	cmd.PersistentFlags().String("elementMap", "", "yaml or json file mapping element ids to instanceId, logGroup, region and account")
+	cmd.PersistentFlags().String("cmdbCacheTtl", "", "how long cmdb lookups are cached, e.g. 30m. 0 turns the cache off")
+	cmd.PersistentFlags().String("cmdbCacheDir", "", "dir of the cmdb cache shared across runs. defaults to the user cache dir")
+	cmd.PersistentFlags().String("resultCache", "", "cache of metric and logs results. memory/disk/off, memory by default")
+	cmd.PersistentFlags().String("resultCacheSize", "", "most results kept in memory")
+	cmd.PersistentFlags().String("resultCacheDir", "", "dir of the disk result cache. defaults to the user cache dir")
+	cmd.PersistentFlags().String("retryConfig", "", "yaml or json file of the retries and rate limits of each aws service")
 	cmd.PersistentFlags().String("vaultUrl", "", "vault end point")
 	cmd.PersistentFlags().String("vaultToken", "", "vault token")
 	cmd.PersistentFlags().String("accountId", "", "aws account number")
@@ -108,13 +107,20 @@ func InitAwsCmdFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().String("instanceId", "", "instance id")
 	cmd.PersistentFlags().String("clusterName", "", "cluster name")
 	cmd.PersistentFlags().String("query", "", "query")
-	cmd.PersistentFlags().String("startTime", "", "start time")
-	cmd.PersistentFlags().String("endTime", "", "endcl time")
-	cmd.PersistentFlags().String("responseType", "", "response type. json/frame")
+	cmd.PersistentFlags().String("startTime", "", "start time. RFC3339, epoch seconds or milliseconds or relative, e.g. now-6h")
+	cmd.PersistentFlags().String("endTime", "", "end time. RFC3339, epoch seconds or milliseconds or relative, e.g. now or now-1d/d")
+	cmd.PersistentFlags().String("timezone", "", "time zone of day aligned times, e.g. Asia/Kolkata")
+	cmd.PersistentFlags().String("period", "", "metric period in seconds. derived from the time range when empty")
+	cmd.PersistentFlags().String("maxDataPoints", "", "most datapoints per series when the period is derived")
+	cmd.PersistentFlags().String("concurrency", "", "most aws calls a panel makes at once")
+	cmd.PersistentFlags().String("responseType", "", "response type. json/frame/table/appkube")
+	cmd.PersistentFlags().String("output", "", "output format. json/ndjson/csv/table/yaml")
 	cmd.PersistentFlags().String("logGroupName", "", "log group name")
 	cmd.PersistentFlags().String("ApiName", "", "api name")
 	cmd.PersistentFlags().String("FunctionName", "", "function name")
 	cmd.PersistentFlags().String("LoadBalancer", "", "loadbalancer name")
 	cmd.PersistentFlags().String("DBInstanceIdentifier", "", "dbinstance identifier name")
+	cmd.PersistentFlags().String("stateMachineArn", "", "state machine arn")
+	cmd.PersistentFlags().String("bucketName", "", "s3 bucket name")
 
 }
@@ -0,0 +1,81 @@
+package comman_function
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeYaml parses a yaml document into the shapes encoding/json produces
+// with UseNumber: mappings decode to map[string]interface{}, sequences to
+// []interface{} and scalars to string, json.Number, bool or nil. An empty
+// document decodes to nil.
+func DecodeYaml(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("yaml: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// UnmarshalYaml decodes yaml into v through its json representation, so v uses
+// the usual json struct tags.
+func UnmarshalYaml(data []byte, v interface{}) error {
+	value, err := DecodeYaml(data)
+	if err != nil {
+		return err
+	}
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonBytes, v)
+}
+
+// EncodeYaml writes value as block style yaml through its json
+// representation, keeping the order of object keys. Json is yaml, so the
+// json is read into a node tree whose flow style and quoting are dropped and
+// the encoder quotes only the strings that would read as another type.
+func EncodeYaml(value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
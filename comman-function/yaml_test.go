@@ -0,0 +1,101 @@
+package comman_function_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+func TestYamlRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		yaml  string
+	}{
+		{
+			name:  "nested maps keep their key order",
+			value: `{"panel":{"name":"cpu","period":300,"ratio":0.5},"enabled":true,"empty":{}}`,
+			yaml:  "panel:\n  name: cpu\n  period: 300\n  ratio: 0.5\nenabled: true\nempty: {}\n",
+		},
+		{
+			name:  "lists",
+			value: `{"ids":["i-1","i-2"],"points":[{"t":1,"v":null}],"none":[]}`,
+			yaml:  "ids:\n  - i-1\n  - i-2\npoints:\n  - t: 1\n    v: null\nnone: []\n",
+		},
+		{
+			name:  "strings that read as other types are quoted",
+			value: `{"bool":"true","number":"300","null":"null","colon":"a: b","comment":"#x","dash":"- x","empty":"","plain":"Data Transferred"}`,
+			yaml:  "bool: \"true\"\nnumber: \"300\"\n\"null\": \"null\"\ncolon: 'a: b'\ncomment: '#x'\ndash: '- x'\nempty: \"\"\nplain: Data Transferred\n",
+		},
+		{
+			name:  "multi line strings",
+			value: `{"query":"fields @message\n| filter @message like /ERROR/\n| limit 20"}`,
+			yaml:  "query: |-\n  fields @message\n  | filter @message like /ERROR/\n  | limit 20\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := comman_function.EncodeYaml(json.RawMessage(tt.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(encoded) != tt.yaml {
+				t.Errorf("EncodeYaml =\n%s\nwant\n%s", encoded, tt.yaml)
+			}
+
+			decoded, err := comman_function.DecodeYaml(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, decodeJson(t, tt.value)) {
+				t.Errorf("DecodeYaml = %#v, want %#v", decoded, decodeJson(t, tt.value))
+			}
+		})
+	}
+}
+
+func TestDecodeYaml(t *testing.T) {
+	doc := `# element map
+i-123:
+  elementType: EC2   # trailing comment
+  "instanceId": 'i-123'
+  tags: [web, "prod"]
+  note: >
+    folded
+    text
+`
+	var elements map[string]struct {
+		ElementType string   `json:"elementType"`
+		InstanceId  string   `json:"instanceId"`
+		Tags        []string `json:"tags"`
+		Note        string   `json:"note"`
+	}
+	if err := comman_function.UnmarshalYaml([]byte(doc), &elements); err != nil {
+		t.Fatal(err)
+	}
+	element := elements["i-123"]
+	if element.ElementType != "EC2" || element.InstanceId != "i-123" || !reflect.DeepEqual(element.Tags, []string{"web", "prod"}) || element.Note != "folded text\n" {
+		t.Errorf("UnmarshalYaml = %+v", element)
+	}
+
+	if value, err := comman_function.DecodeYaml([]byte("# nothing\n")); err != nil || value != nil {
+		t.Errorf("DecodeYaml of an empty document = %v, %v, want nil", value, err)
+	}
+	if _, err := comman_function.DecodeYaml([]byte("a:\n\tb: 1\n")); err == nil {
+		t.Error("DecodeYaml accepted tab indentation")
+	}
+}
+
+func decodeJson(t *testing.T, value string) interface{} {
+	t.Helper()
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
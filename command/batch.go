@@ -0,0 +1,72 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/batch"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/spf13/cobra"
+)
+
+var AwsxBatchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "run many panels in one invocation",
+	Long: `batch runs the panels listed in a json or yaml file (--file, "-" for stdin)
+concurrently, authenticating once and resolving each CMDB element once, and
+prints one json document keyed by panel name, or the rows of every panel
+with --output ndjson, csv or table. When panels fail their errors are in the
+output and batch exits with the code of the error of the first of them.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		workers, _ := cmd.Flags().GetInt("workers")
+		output, _ := cmd.Flags().GetString("output")
+		if err := comman_function.ValidateOutput(output); err != nil {
+			return &comman_function.ValidationError{Err: err}
+		}
+
+		data, err := readBatchFile(file)
+		if err != nil {
+			return &comman_function.ValidationError{Err: fmt.Errorf("error reading batch file: %w", err)}
+		}
+		requests, err := batch.Parse(data)
+		if err != nil {
+			return &comman_function.ValidationError{Err: fmt.Errorf("error parsing batch file: %w", err)}
+		}
+
+		authFlag, clientAuth, err := comman_function.Authenticate(commandParam(cmd))
+		if err == nil && !authFlag {
+			err = errors.New("no aws credentials found")
+		}
+		if err != nil {
+			return &comman_function.AuthError{Err: err}
+		}
+
+		results := batch.Run(requests, clientAuth, workers)
+		stats := comman_function.ResultCacheStats()
+		log.Printf("Result cache: %d hits, %d misses", stats.Hits, stats.Misses)
+		if err := batch.Write(os.Stdout, requests, results, output); err != nil {
+			return fmt.Errorf("error writing batch results: %w", err)
+		}
+		return batch.Failed(requests, results)
+	},
+}
+
+func readBatchFile(file string) ([]byte, error) {
+	switch file {
+	case "":
+		return nil, fmt.Errorf("--file is required")
+	case "-":
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(file)
+}
+
+func init() {
+	AwsxBatchCmd.Flags().String("file", "", "json or yaml file listing the panels to run, - for stdin")
+	AwsxBatchCmd.Flags().Int("workers", 4, "number of panels run concurrently")
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/batch"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/Appkube-awsx/awsx-getelementdetails/exporter"
+	"github.com/spf13/cobra"
+)
+
+var AwsxExporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "export panels as prometheus metrics",
+	Long: `exporter runs the panels listed in a batch file (--file) every --interval and
+serves their latest values on /metrics as gauges labelled by element type,
+element id and panel. With --remoteWriteUrl every run is also pushed with
+prometheus remote write.`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		addr, _ := cmd.Flags().GetString("addr")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		window, _ := cmd.Flags().GetDuration("window")
+		remoteWriteUrl, _ := cmd.Flags().GetString("remoteWriteUrl")
+		workers, _ := cmd.Flags().GetInt("workers")
+
+		data, err := readBatchFile(file)
+		if err != nil {
+			log.Printf("Error reading exporter file: %v\n", err)
+			return
+		}
+		requests, err := batch.Parse(data)
+		if err != nil {
+			log.Printf("Error parsing exporter file: %v\n", err)
+			return
+		}
+
+		authFlag, clientAuth, err := comman_function.Authenticate(commandParam(cmd))
+		if err != nil || !authFlag {
+			log.Printf("Error during authentication: %v\n", err)
+			return
+		}
+
+		e := exporter.NewExporter(requests, clientAuth)
+		e.Workers = workers
+		e.Window = window
+		e.RemoteWriteUrl = remoteWriteUrl
+		go e.Run(context.Background(), interval)
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", e)
+		srv := &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		log.Printf("serving metrics of %d panels on %s/metrics", len(requests), addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("error serving metrics: %v", err)
+		}
+	},
+}
+
+func init() {
+	AwsxExporterCmd.Flags().String("file", "", "json or yaml batch file listing the panels to export, - for stdin")
+	AwsxExporterCmd.Flags().String("addr", ":9464", "http listen address of /metrics")
+	AwsxExporterCmd.Flags().Duration("interval", time.Minute, "time between panel runs")
+	AwsxExporterCmd.Flags().Duration("window", 15*time.Minute, "time range of panels without startTime and endTime")
+	AwsxExporterCmd.Flags().String("remoteWriteUrl", "", "prometheus remote write url every run is pushed to")
+	AwsxExporterCmd.Flags().Int("workers", 4, "number of panels run concurrently")
+}
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/mockaws"
+	"github.com/spf13/cobra"
+)
+
+var AwsxMockAwsCmd = &cobra.Command{
+	Use:   "mockaws",
+	Short: "serve mock cloudwatch, logs, ec2 and cmdb endpoints",
+	Long: `mockaws starts a local stand-in for the CloudWatch, CloudWatch Logs, EC2
+and CMDB endpoints with synthetic data. Run panels against it with
+
+  --endpointUrl http://127.0.0.1:4566 --cmdbApiUrl http://127.0.0.1:4566`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, _ := cmd.Flags().GetString("addr")
+		polls, _ := cmd.Flags().GetInt("polls")
+		throttle, _ := cmd.Flags().GetInt("throttle")
+
+		mock := mockaws.NewServer()
+		mock.Polls = polls
+		mock.Throttle = throttle
+		srv := &http.Server{
+			Addr:              addr,
+			Handler:           mock,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		log.Printf("serving mock aws on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("error serving mock aws: %v", err)
+		}
+	},
+}
+
+func init() {
+	AwsxMockAwsCmd.Flags().String("addr", "127.0.0.1:4566", "http listen address, :4566 to listen on every interface")
+	AwsxMockAwsCmd.Flags().Int("polls", 1, "GetQueryResults calls a logs query stays Scheduled and then Running")
+	AwsxMockAwsCmd.Flags().Int("throttle", 0, "answer every nth aws call with a ThrottlingException. 0 never throttles")
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/server"
+	"github.com/spf13/cobra"
+)
+
+var AwsxServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "serve panels over http",
+	Long: `serve starts an http server exposing every registered panel:
+
+  GET /v1/panels
+  GET /v1/elements/{elementType}/panels/{query}?elementId=&startTime=&endTime=&responseType=&output=
+
+aws credentials and the cmdb given to serve are used for every request.
+requests may only assume the roles of --allowedRoleArns.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		allowedRoleArns, _ := cmd.Flags().GetStringSlice("allowedRoleArns")
+		s := server.NewServer(commandParam(cmd))
+		s.AllowedRoleArns = allowedRoleArns
+
+		srv := &http.Server{
+			Addr:              addr,
+			Handler:           s.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		log.Printf("serving panels on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("error serving panels: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	AwsxServeCmd.Flags().String("addr", "127.0.0.1:8080", "http listen address. the server does not authenticate its callers")
+	AwsxServeCmd.Flags().StringSlice("allowedRoleArns", nil, "cross account roles requests may assume with crossAccountRoleArn")
+}
@@ -1,168 +1,212 @@
 package controller
 
-//import (
-//	"encoding/json"
-//	"fmt"
-//	"github.com/Appkube-awsx/awsx-common/awsclient"
-//	"github.com/Appkube-awsx/awsx-common/model"
-//	"github.com/aws/aws-sdk-go/aws"
-//	"github.com/aws/aws-sdk-go/service/cloudwatch"
-//	"strings"
-//	"time"
-//)
-//
-//// Existing Dimension and MetricQueryInput structures...
-//type Dimension struct {
-//	Name  string
-//	Value string
-//}
-//type TimeRange struct {
-//	From     string `json:"From"`
-//	To       string `json:"To"`
-//	TimeZone string `json:"TimeZone"`
-//}
-//
-//type InnerQuery struct {
-//	Namespace  string      `json:"Namespace"`
-//	MetricName string      `json:"MetricName"`
-//	Period     int64       `json:"Period"`
-//	Stat       string      `json:"Stat"`
-//	Dimensions []Dimension `json:"Dimensions"`
-//}
-//
-//type OuterQuery struct {
-//	RefID        string       `json:"RefID"`
-//	MaxDataPoint int          `json:"MaxDataPoint"`
-//	Interval     int          `json:"Interval"`
-//	TimeRange    TimeRange    `json:"TimeRange"`
-//	Query        []InnerQuery `json:"Query"`
-//}
-//
-//func GetMetricData(clientAuth *model.Auth, cloudWatchQueries string) (*cloudwatch.GetMetricDataOutput, error) {
-//	var outerQuery []OuterQuery
-//	err := json.Unmarshal([]byte(cloudWatchQueries), &outerQuery)
-//	if err != nil {
-//		fmt.Println("Error parsing JSON input:", err)
-//		return nil, err
-//	}
-//
-//	// Create the metric queries dynamically
-//	queries := make([]*cloudwatch.MetricDataQuery, len(outerQuery))
-//	for i, outerQueryInput := range outerQuery {
-//		for _, queryInput := range outerQueryInput.Query {
-//			if queryInput.Dimensions == nil {
-//				queryInput.Dimensions = make([]Dimension, 0)
-//			}
-//
-//			query := &cloudwatch.MetricDataQuery{
-//				Id:         aws.String(strings.ToLower(outerQueryInput.RefID)),
-//				ReturnData: aws.Bool(true),
-//				MetricStat: &cloudwatch.MetricStat{
-//					Metric: &cloudwatch.Metric{
-//						Namespace:  aws.String(queryInput.Namespace),
-//						MetricName: aws.String(queryInput.MetricName),
-//						Dimensions: buildDimensions(queryInput.Dimensions),
-//					},
-//					Period: aws.Int64(queryInput.Period),
-//					Stat:   aws.String(queryInput.Stat),
-//				},
-//			}
-//			queries[i] = query
-//		}
-//	}
-//
-//	cloudWatchClient := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
-//
-//	// Specify the request input with multiple queries
-//	input := &cloudwatch.GetMetricDataInput{
-//		MetricDataQueries: queries,
-//		StartTime:         aws.Time(time.Now().Add(time.Duration(-1) * time.Hour)),
-//		EndTime:           aws.Time(time.Now()),
-//	}
-//
-//	// Make the request to CloudWatch
-//	result, err := cloudWatchClient.GetMetricData(input)
-//	if err != nil {
-//		fmt.Println("Error:", err)
-//		return nil, err
-//	}
-//
-//	// Process the result
-//	for _, metricDataResult := range result.MetricDataResults {
-//		for i, timestamp := range metricDataResult.Timestamps {
-//			fmt.Printf("Data for Metric at Timestamp %v: %f\n", *timestamp, *metricDataResult.Values[i])
-//		}
-//	}
-//
-//	return result, nil
-//}
-//
-//// Below method is being used in awsx-api end point.
-//func GetMetricDataWithSingleQuery(clientAuth *model.Auth, cloudWatchQueries string) (*cloudwatch.GetMetricDataOutput, error) {
-//	var outerQuery OuterQuery
-//	err := json.Unmarshal([]byte(cloudWatchQueries), &outerQuery)
-//	if err != nil {
-//		fmt.Println("Error parsing JSON input:", err)
-//		return nil, err
-//	}
-//
-//	// Create the metric queries dynamically
-//	queries := make([]*cloudwatch.MetricDataQuery, 1)
-//	for _, queryInput := range outerQuery.Query {
-//		if queryInput.Dimensions == nil {
-//			queryInput.Dimensions = make([]Dimension, 0)
-//		}
-//
-//		query := &cloudwatch.MetricDataQuery{
-//			Id:         aws.String(strings.ToLower(outerQuery.RefID)),
-//			ReturnData: aws.Bool(true),
-//			MetricStat: &cloudwatch.MetricStat{
-//				Metric: &cloudwatch.Metric{
-//					Namespace:  aws.String(queryInput.Namespace),
-//					MetricName: aws.String(queryInput.MetricName),
-//					Dimensions: buildDimensions(queryInput.Dimensions),
-//				},
-//				Period: aws.Int64(queryInput.Period),
-//				Stat:   aws.String(queryInput.Stat),
-//			},
-//		}
-//		queries[0] = query
-//	}
-//
-//	cloudWatchClient := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
-//
-//	// Specify the request input with multiple queries
-//	input := &cloudwatch.GetMetricDataInput{
-//		MetricDataQueries: queries,
-//		StartTime:         aws.Time(time.Now().Add(time.Duration(-1) * time.Hour)),
-//		EndTime:           aws.Time(time.Now()),
-//	}
-//
-//	// Make the request to CloudWatch
-//	result, err := cloudWatchClient.GetMetricData(input)
-//	if err != nil {
-//		fmt.Println("Error:", err)
-//		return nil, err
-//	}
-//
-//	// Process the result
-//	for _, metricDataResult := range result.MetricDataResults {
-//		for i, timestamp := range metricDataResult.Timestamps {
-//			fmt.Printf("Data for Metric at Timestamp %v: %f\n", *timestamp, *metricDataResult.Values[i])
-//		}
-//	}
-//
-//	return result, nil
-//}
-//
-//func buildDimensions(dimensions []Dimension) []*cloudwatch.Dimension {
-//	var cloudWatchDimensions []*cloudwatch.Dimension
-//	for _, d := range dimensions {
-//		dimension := &cloudwatch.Dimension{
-//			Name:  aws.String(d.Name),
-//			Value: aws.String(d.Value),
-//		}
-//		cloudWatchDimensions = append(cloudWatchDimensions, dimension)
-//	}
-//	return cloudWatchDimensions
-//}
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+)
+
+type Dimension struct {
+	Name  string
+	Value string
+}
+
+// TimeRange bounds one query. From and To are RFC3339 times. Times without an
+// offset, e.g. 2024-01-02T15:04:05, are read in TimeZone.
+type TimeRange struct {
+	From     string `json:"From"`
+	To       string `json:"To"`
+	TimeZone string `json:"TimeZone"`
+}
+
+type InnerQuery struct {
+	Namespace  string      `json:"Namespace"`
+	MetricName string      `json:"MetricName"`
+	Period     int64       `json:"Period"`
+	Stat       string      `json:"Stat"`
+	Dimensions []Dimension `json:"Dimensions"`
+}
+
+// OuterQuery is one grafana style query. Its metrics are either listed under
+// Query or given inline next to RefID. A metric without a Period gets one that
+// fits MaxDataPoint datapoints into the time range. Interval is accepted for
+// compatibility and not used.
+type OuterQuery struct {
+	RefID        string       `json:"RefID"`
+	MaxDataPoint int64        `json:"MaxDataPoint"`
+	Interval     int          `json:"Interval"`
+	TimeRange    TimeRange    `json:"TimeRange"`
+	Query        []InnerQuery `json:"Query"`
+	InnerQuery
+}
+
+func (o *OuterQuery) queries() []InnerQuery {
+	if len(o.Query) == 0 && o.MetricName != "" {
+		return []InnerQuery{o.InnerQuery}
+	}
+	return o.Query
+}
+
+// ParseQueries reads the --cloudWatchQueries json, a list of queries or a
+// single query.
+func ParseQueries(cloudWatchQueries string) ([]OuterQuery, error) {
+	trimmed := bytes.TrimSpace([]byte(cloudWatchQueries))
+	if len(trimmed) == 0 {
+		return nil, errors.New("cloudWatchQueries is required")
+	}
+
+	var outerQueries []OuterQuery
+	if trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &outerQueries); err != nil {
+			return nil, fmt.Errorf("error parsing cloudWatchQueries: %w", err)
+		}
+	} else {
+		var outerQuery OuterQuery
+		if err := json.Unmarshal(trimmed, &outerQuery); err != nil {
+			return nil, fmt.Errorf("error parsing cloudWatchQueries: %w", err)
+		}
+		outerQueries = append(outerQueries, outerQuery)
+	}
+
+	refIDs := map[string]bool{}
+	for _, outerQuery := range outerQueries {
+		if outerQuery.RefID == "" {
+			return nil, errors.New("every query needs a RefID")
+		}
+		if refIDs[outerQuery.RefID] {
+			return nil, fmt.Errorf("duplicate RefID %q", outerQuery.RefID)
+		}
+		refIDs[outerQuery.RefID] = true
+		if len(outerQuery.queries()) == 0 {
+			return nil, fmt.Errorf("query %s has no metrics", outerQuery.RefID)
+		}
+		for _, queryInput := range outerQuery.queries() {
+			if queryInput.Namespace == "" || queryInput.MetricName == "" {
+				return nil, fmt.Errorf("query %s: Namespace and MetricName are required", outerQuery.RefID)
+			}
+		}
+	}
+	return outerQueries, nil
+}
+
+// GetMetricData runs the raw queries of req and returns their results keyed by
+// RefID. Queries that share a time range are sent in one request. A query
+// without a TimeRange uses the start and end time of req, or the last hour.
+// Malformed queries and time ranges are reported as a ValidationError.
+func GetMetricData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (map[string]*cloudwatch.GetMetricDataOutput, error) {
+	outerQueries, err := ParseQueries(req.CloudWatchQueries)
+	if err != nil {
+		return nil, &comman_function.ValidationError{Err: err}
+	}
+
+	defaultEnd := time.Now()
+	defaultStart := defaultEnd.Add(-time.Hour)
+	if req.StartTime != nil {
+		defaultStart = *req.StartTime
+	}
+	if req.EndTime != nil {
+		defaultEnd = *req.EndTime
+	}
+
+	type ref struct {
+		refID string
+		ids   []string
+	}
+	var rangeOrder []string
+	metricQueries := map[string]*comman_function.MetricQuery{}
+	refs := map[string][]ref{}
+	for i, outerQuery := range outerQueries {
+		startTime, endTime, err := timeRange(outerQuery.TimeRange, defaultStart, defaultEnd)
+		if err != nil {
+			return nil, &comman_function.ValidationError{Err: fmt.Errorf("query %s: %w", outerQuery.RefID, err)}
+		}
+		key := startTime.String() + "|" + endTime.String()
+		metricQuery, ok := metricQueries[key]
+		if !ok {
+			metricQuery = req.MetricQuery(startTime, endTime)
+			metricQueries[key] = metricQuery
+			rangeOrder = append(rangeOrder, key)
+		}
+
+		r := ref{refID: outerQuery.RefID}
+		for j, queryInput := range outerQuery.queries() {
+			period := queryInput.Period
+			if period == 0 && outerQuery.MaxDataPoint > 0 {
+				period = comman_function.DerivePeriod(startTime, endTime, outerQuery.MaxDataPoint)
+			}
+			stat := queryInput.Stat
+			if stat == "" {
+				stat = "Average"
+			}
+			// RefIDs are not always valid query ids, so the ids are positional.
+			r.ids = append(r.ids, metricQuery.Add(comman_function.MetricStatQuery{
+				Id:         fmt.Sprintf("q%d_%d", i, j),
+				Namespace:  queryInput.Namespace,
+				MetricName: queryInput.MetricName,
+				Stat:       stat,
+				Dimensions: buildDimensions(queryInput.Dimensions),
+				Period:     period,
+			}))
+		}
+		refs[key] = append(refs[key], r)
+	}
+
+	frame := make(map[string]*cloudwatch.GetMetricDataOutput, len(outerQueries))
+	for _, key := range rangeOrder {
+		result, err := metricQueries[key].Execute(req.ClientAuth, cloudWatchClient)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range refs[key] {
+			output := &cloudwatch.GetMetricDataOutput{Messages: result.Messages}
+			for _, id := range r.ids {
+				output.MetricDataResults = append(output.MetricDataResults, result.Result(id))
+			}
+			frame[r.refID] = output
+		}
+	}
+	return frame, nil
+}
+
+func timeRange(tr TimeRange, startTime, endTime time.Time) (*time.Time, *time.Time, error) {
+	location := time.UTC
+	if tr.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(tr.TimeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid TimeZone %q: %w", tr.TimeZone, err)
+		}
+	}
+	now := time.Now()
+	if tr.From != "" {
+		from, err := comman_function.ParseTimeExpression(tr.From, now, location, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid TimeRange.From: %w", err)
+		}
+		startTime = from
+	}
+	if tr.To != "" {
+		to, err := comman_function.ParseTimeExpression(tr.To, now, location, true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid TimeRange.To: %w", err)
+		}
+		endTime = to
+	}
+	if !startTime.Before(endTime) {
+		return nil, nil, fmt.Errorf("time range start %v is not before end %v", startTime, endTime)
+	}
+	return &startTime, &endTime, nil
+}
+
+func buildDimensions(dimensions []Dimension) []*cloudwatch.Dimension {
+	var cloudWatchDimensions []*cloudwatch.Dimension
+	for _, d := range dimensions {
+		cloudWatchDimensions = append(cloudWatchDimensions, comman_function.Dimension(d.Name, d.Value))
+	}
+	return cloudWatchDimensions
+}
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+func init() {
+	comman_function.RegisterPanel(comman_function.PanelDefinition{
+		Name:          "raw_metric_query",
+		ElementTypes:  []string{comman_function.AnyElementType},
+		ResponseTypes: comman_function.JsonFrameResponseTypes,
+		Shape:         comman_function.AppkubeTimeSeries,
+		Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+			frame, err := GetMetricData(req, nil)
+			return &comman_function.PanelResult{Json: frame, Frame: frame}, err
+		}),
+	})
+}
@@ -0,0 +1,238 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/batch"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+const (
+	valueMetric          = "awsx_panel_value"
+	successMetric        = "awsx_panel_success"
+	datapointsMetric     = "awsx_panel_datapoints"
+	lastCollectionMetric = "awsx_exporter_last_collection_timestamp_seconds"
+	cacheHitsMetric      = "awsx_result_cache_hits_total"
+	cacheMissesMetric    = "awsx_result_cache_misses_total"
+	retriesMetric        = "awsx_aws_retries_total"
+)
+
+var metricHelp = map[string]string{
+	valueMetric:          "Latest value of a panel series.",
+	successMetric:        "Whether the last run of the panel succeeded.",
+	datapointsMetric:     "Datapoints behind the values of the last run of the panel, 0 when it got no data.",
+	lastCollectionMetric: "Time the panels were last run.",
+	cacheHitsMetric:      "Lookups served from the result cache.",
+	cacheMissesMetric:    "Lookups not found in the result cache.",
+	retriesMetric:        "Aws calls retried after throttling or a transient error.",
+}
+
+// metricTypes holds the type of the metrics that are not gauges.
+var metricTypes = map[string]string{
+	cacheHitsMetric:   "counter",
+	cacheMissesMetric: "counter",
+	retriesMetric:     "counter",
+}
+
+// Sample is one value with its labels, the metric name excluded.
+type Sample struct {
+	Metric string
+	Labels map[string]string
+	Value  float64
+}
+
+// Exporter runs a set of panels on an interval and keeps the values of the
+// last run for /metrics and remote write.
+type Exporter struct {
+	Requests   []batch.Request
+	ClientAuth *model.Auth
+	Workers    int
+	// Window is the time range of panels that do not set startTime and endTime.
+	Window time.Duration
+	// RemoteWriteUrl, when set, receives the samples of every run.
+	RemoteWriteUrl string
+
+	mu          sync.RWMutex
+	samples     []Sample
+	collectedAt time.Time
+}
+
+func NewExporter(requests []batch.Request, clientAuth *model.Auth) *Exporter {
+	return &Exporter{Requests: requests, ClientAuth: clientAuth, Workers: 4, Window: 15 * time.Minute}
+}
+
+// Collect runs every panel once and replaces the samples of the previous run.
+// A failed panel reports awsx_panel_success 0 and no values.
+func (e *Exporter) Collect() []Sample {
+	now := time.Now().UTC()
+	requests := make([]batch.Request, len(e.Requests))
+	for i, request := range e.Requests {
+		requests[i] = batch.Request{}
+		for k, v := range request {
+			requests[i][k] = v
+		}
+		if requests[i]["startTime"] == "" && requests[i]["endTime"] == "" {
+			requests[i]["startTime"] = now.Add(-e.Window).Format(time.RFC3339)
+			requests[i]["endTime"] = now.Format(time.RFC3339)
+		}
+	}
+
+	results := batch.Run(requests, e.ClientAuth, e.Workers)
+	var samples []Sample
+	for _, request := range requests {
+		result := results[request["name"]]
+		labels := map[string]string{
+			"element_type": request["elementType"],
+			"element_id":   request["elementId"],
+			"panel":        request["query"],
+		}
+		if request["name"] != request["query"] {
+			labels["name"] = request["name"]
+		}
+
+		success := 1.0
+		if result == nil || result.Error != "" {
+			success = 0
+			if result != nil {
+				log.Printf("exporter: panel %s failed: %s", request["name"], result.Error)
+			}
+		}
+		samples = append(samples, Sample{Metric: successMetric, Labels: labels, Value: success})
+		if success == 0 {
+			continue
+		}
+		if result.Metadata != nil {
+			samples = append(samples, Sample{Metric: datapointsMetric, Labels: labels, Value: float64(result.Metadata.Datapoints)})
+		}
+		for _, panelSample := range comman_function.PanelSamples(result.Panel) {
+			seriesLabels := map[string]string{"series": panelSample.Series}
+			for k, v := range labels {
+				seriesLabels[k] = v
+			}
+			samples = append(samples, Sample{Metric: valueMetric, Labels: seriesLabels, Value: panelSample.Value})
+		}
+	}
+	samples = append(samples, Sample{Metric: lastCollectionMetric, Labels: map[string]string{}, Value: float64(now.Unix())})
+	stats := comman_function.ResultCacheStats()
+	samples = append(samples,
+		Sample{Metric: cacheHitsMetric, Labels: map[string]string{}, Value: float64(stats.Hits)},
+		Sample{Metric: cacheMissesMetric, Labels: map[string]string{}, Value: float64(stats.Misses)},
+		Sample{Metric: retriesMetric, Labels: map[string]string{}, Value: float64(comman_function.AwsRetries())})
+
+	e.mu.Lock()
+	e.samples, e.collectedAt = samples, now
+	e.mu.Unlock()
+	return samples
+}
+
+// Run collects every interval until ctx is done, pushing each run to the
+// remote write url when one is set.
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		samples := e.Collect()
+		log.Printf("exporter: collected %d samples from %d panels", len(samples), len(e.Requests))
+		if e.RemoteWriteUrl != "" {
+			if err := RemoteWrite(ctx, e.RemoteWriteUrl, samples, e.collectedAtTime()); err != nil {
+				log.Printf("exporter: remote write failed: %v", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Exporter) collectedAtTime() time.Time {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.collectedAt
+}
+
+// ServeHTTP writes the samples of the last run in the prometheus text
+// exposition format.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	samples := e.samples
+	e.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(Exposition(samples))
+}
+
+// Exposition formats samples in the prometheus text format, grouped by metric
+// with HELP and TYPE lines and sorted by labels.
+func Exposition(samples []Sample) []byte {
+	byMetric := map[string][]string{}
+	for _, sample := range samples {
+		byMetric[sample.Metric] = append(byMetric[sample.Metric], sample.Metric+formatLabels(sample.Labels)+" "+formatValue(sample.Value))
+	}
+	metrics := make([]string, 0, len(byMetric))
+	for metric := range byMetric {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	var buffer bytes.Buffer
+	for _, metric := range metrics {
+		lines := byMetric[metric]
+		sort.Strings(lines)
+		if help, ok := metricHelp[metric]; ok {
+			fmt.Fprintf(&buffer, "# HELP %s %s\n", metric, help)
+		}
+		metricType, ok := metricTypes[metric]
+		if !ok {
+			metricType = "gauge"
+		}
+		fmt.Fprintf(&buffer, "# TYPE %s %s\n", metric, metricType)
+		for _, line := range lines {
+			buffer.WriteString(line)
+			buffer.WriteByte('\n')
+		}
+	}
+	return buffer.Bytes()
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + labelEscaper.Replace(labels[name]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
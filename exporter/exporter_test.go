@@ -0,0 +1,159 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/model/textparse"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+var testSamples = []Sample{
+	{Metric: valueMetric, Labels: map[string]string{"element_type": "EC2", "element_id": "1", "panel": "cpu_utilization_panel", "series": "CurrentUsage"}, Value: 15.55},
+	{Metric: valueMetric, Labels: map[string]string{"element_type": "EC2", "element_id": "1", "panel": "cpu_utilization_panel", "series": "MaxUsage"}, Value: 46.21},
+	{Metric: successMetric, Labels: map[string]string{"element_type": "EC2", "element_id": "1", "panel": "cpu_utilization_panel"}, Value: 1},
+	{Metric: successMetric, Labels: map[string]string{"element_type": "Lambda", "element_id": "2", "panel": "error_panel", "name": "lambda \"errors\"\n\\"}, Value: 0},
+	{Metric: datapointsMetric, Labels: map[string]string{"element_type": "EC2", "element_id": "1", "panel": "cpu_utilization_panel"}, Value: 180},
+	{Metric: valueMetric, Labels: map[string]string{"element_type": "EC2", "element_id": "1", "panel": "cpu_utilization_panel", "series": "Ratio"}, Value: math.NaN()},
+	{Metric: lastCollectionMetric, Labels: map[string]string{}, Value: 1792195200},
+	{Metric: cacheHitsMetric, Labels: map[string]string{}, Value: 12},
+	{Metric: cacheMissesMetric, Labels: map[string]string{}, Value: 3},
+	{Metric: retriesMetric, Labels: map[string]string{}, Value: math.Inf(1)},
+}
+
+func TestExposition(t *testing.T) {
+	got := Exposition(testSamples)
+
+	golden := filepath.Join("testdata", "exposition.txt")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("exposition differs from %s:\n%s", golden, got)
+	}
+
+	parser := textparse.NewPromParser(got)
+	series := 0
+	for {
+		entry, err := parser.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("prometheus cannot parse the exposition: %v", err)
+		}
+		if entry == textparse.EntrySeries {
+			series++
+		}
+	}
+	if series != len(testSamples) {
+		t.Errorf("parsed %d series, want %d", series, len(testSamples))
+	}
+}
+
+func TestRemoteWrite(t *testing.T) {
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	at := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+	samples := testSamples[:3]
+	if err := RemoteWrite(context.Background(), server.URL, samples, at); err != nil {
+		t.Fatal(err)
+	}
+	if header.Get("Content-Encoding") != "snappy" || header.Get("Content-Type") != "application/x-protobuf" {
+		t.Errorf("headers = %v, want a snappy compressed protobuf", header)
+	}
+
+	data, err := snappy.Decode(nil, body)
+	if err != nil {
+		t.Fatalf("snappy decode: %v", err)
+	}
+	var request prompb.WriteRequest
+	if err := request.Unmarshal(data); err != nil {
+		t.Fatalf("unmarshal WriteRequest: %v", err)
+	}
+
+	labels := func(pairs ...string) []prompb.Label {
+		var labels []prompb.Label
+		for i := 0; i < len(pairs); i += 2 {
+			labels = append(labels, prompb.Label{Name: pairs[i], Value: pairs[i+1]})
+		}
+		return labels
+	}
+	timestamp := at.UnixNano() / int64(time.Millisecond)
+	want := []prompb.TimeSeries{
+		{
+			Labels:  labels("__name__", valueMetric, "element_id", "1", "element_type", "EC2", "panel", "cpu_utilization_panel", "series", "CurrentUsage"),
+			Samples: []prompb.Sample{{Value: 15.55, Timestamp: timestamp}},
+		},
+		{
+			Labels:  labels("__name__", valueMetric, "element_id", "1", "element_type", "EC2", "panel", "cpu_utilization_panel", "series", "MaxUsage"),
+			Samples: []prompb.Sample{{Value: 46.21, Timestamp: timestamp}},
+		},
+		{
+			Labels:  labels("__name__", successMetric, "element_id", "1", "element_type", "EC2", "panel", "cpu_utilization_panel"),
+			Samples: []prompb.Sample{{Value: 1, Timestamp: timestamp}},
+		},
+	}
+	if !reflect.DeepEqual(request.Timeseries, want) {
+		t.Errorf("timeseries = %+v, want %+v", request.Timeseries, want)
+	}
+}
+
+func TestRemoteWriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of order sample", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := RemoteWrite(context.Background(), server.URL, testSamples, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "out of order sample") {
+		t.Errorf("err = %v, want the response of the endpoint", err)
+	}
+}
+
+func TestSnappyEncode(t *testing.T) {
+	// Larger than one 65536 byte literal, with every literal length encoding.
+	for _, size := range []int{0, 1, 60, 61, 256, 257, 65536, 65537, 200000} {
+		src := make([]byte, size)
+		for i := range src {
+			src[i] = byte(i * 7)
+		}
+		got, err := snappy.Decode(nil, snappyEncode(src))
+		if err != nil {
+			t.Errorf("decode %d bytes: %v", size, err)
+			continue
+		}
+		if !bytes.Equal(got, src) {
+			t.Errorf("decode %d bytes: round trip differs", size)
+		}
+	}
+}
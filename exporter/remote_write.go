@@ -0,0 +1,118 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// remoteWriteTimeout bounds one push to the remote write url.
+const remoteWriteTimeout = 30 * time.Second
+
+// RemoteWrite pushes samples, all stamped with at, to a prometheus remote
+// write endpoint as a snappy compressed WriteRequest.
+func RemoteWrite(ctx context.Context, url string, samples []Sample, at time.Time) error {
+	body := snappyEncode(writeRequest(samples, at.UnixNano()/int64(time.Millisecond)))
+
+	ctx, cancel := context.WithTimeout(ctx, remoteWriteTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	req.Header.Set("User-Agent", "awsx-getelementdetails-exporter")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("remote write to %s returned %s: %s", url, resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// writeRequest encodes the prometheus.WriteRequest protobuf message:
+//
+//	WriteRequest { repeated TimeSeries timeseries = 1; }
+//	TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; }
+//	Label        { string name = 1; string value = 2; }
+//	Sample       { double value = 1; int64 timestamp = 2; }
+//
+// Labels are sorted by name, __name__ first, as remote write requires.
+func writeRequest(samples []Sample, timestampMs int64) []byte {
+	var request []byte
+	for _, sample := range samples {
+		labels := map[string]string{"__name__": sample.Metric}
+		for name, value := range sample.Labels {
+			labels[name] = value
+		}
+		names := make([]string, 0, len(labels))
+		for name := range labels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var series []byte
+		for _, name := range names {
+			var label []byte
+			label = appendBytesField(label, 1, []byte(name))
+			label = appendBytesField(label, 2, []byte(labels[name]))
+			series = appendBytesField(series, 1, label)
+		}
+		var point []byte
+		point = appendVarint(point, 1<<3|1)
+		point = binary.LittleEndian.AppendUint64(point, math.Float64bits(sample.Value))
+		point = appendVarint(point, 2<<3|0)
+		point = appendVarint(point, uint64(timestampMs))
+		series = appendBytesField(series, 2, point)
+
+		request = appendBytesField(request, 1, series)
+	}
+	return request
+}
+
+func appendBytesField(b []byte, field int, value []byte) []byte {
+	b = appendVarint(b, uint64(field)<<3|2)
+	b = appendVarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	return binary.AppendUvarint(b, v)
+}
+
+// snappyEncode writes src in the snappy block format as literals only. The
+// output is not smaller than src but any snappy decoder reads it, which is
+// all remote write needs from us.
+func snappyEncode(src []byte) []byte {
+	dst := binary.AppendUvarint(nil, uint64(len(src)))
+	for len(src) > 0 {
+		n := len(src)
+		if n > 65536 {
+			n = 65536
+		}
+		switch length := n - 1; {
+		case length < 60:
+			dst = append(dst, byte(length)<<2)
+		case length < 1<<8:
+			dst = append(dst, 60<<2, byte(length))
+		default:
+			dst = append(dst, 61<<2, byte(length), byte(length>>8))
+		}
+		dst = append(dst, src[:n]...)
+		src = src[n:]
+	}
+	return dst
+}
@@ -0,0 +1,63 @@
+package fakes
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+)
+
+// APIGateway answers rest api and stage calls from memory.
+type APIGateway struct {
+	apigatewayiface.APIGatewayAPI
+
+	RestApis []*apigateway.RestApi
+	// Stages holds the stages by rest api id.
+	Stages map[string][]*apigateway.Stage
+	// Err, when set, is returned by every call.
+	Err error
+}
+
+func (f *APIGateway) GetRestApis(input *apigateway.GetRestApisInput) (*apigateway.GetRestApisOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &apigateway.GetRestApisOutput{Items: f.RestApis}, nil
+}
+
+func (f *APIGateway) GetRestApisWithContext(_ aws.Context, input *apigateway.GetRestApisInput, _ ...request.Option) (*apigateway.GetRestApisOutput, error) {
+	return f.GetRestApis(input)
+}
+
+func (f *APIGateway) GetStages(input *apigateway.GetStagesInput) (*apigateway.GetStagesOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &apigateway.GetStagesOutput{Item: f.Stages[aws.StringValue(input.RestApiId)]}, nil
+}
+
+func (f *APIGateway) GetStagesWithContext(_ aws.Context, input *apigateway.GetStagesInput, _ ...request.Option) (*apigateway.GetStagesOutput, error) {
+	return f.GetStages(input)
+}
+
+// APIGatewayV2 answers GetApis for http and websocket apis from memory.
+type APIGatewayV2 struct {
+	apigatewayv2iface.ApiGatewayV2API
+
+	Apis []*apigatewayv2.Api
+	// Err, when set, is returned by every call.
+	Err error
+}
+
+func (f *APIGatewayV2) GetApis(input *apigatewayv2.GetApisInput) (*apigatewayv2.GetApisOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &apigatewayv2.GetApisOutput{Items: f.Apis}, nil
+}
+
+func (f *APIGatewayV2) GetApisWithContext(_ aws.Context, input *apigatewayv2.GetApisInput, _ ...request.Option) (*apigatewayv2.GetApisOutput, error) {
+	return f.GetApis(input)
+}
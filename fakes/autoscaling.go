@@ -0,0 +1,41 @@
+package fakes
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+)
+
+// AutoScaling answers auto scaling group and launch configuration calls from
+// memory.
+type AutoScaling struct {
+	autoscalingiface.AutoScalingAPI
+
+	Groups               []*autoscaling.Group
+	LaunchConfigurations []*autoscaling.LaunchConfiguration
+	// Err, when set, is returned by every call.
+	Err error
+}
+
+func (f *AutoScaling) DescribeAutoScalingGroups(input *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: f.Groups}, nil
+}
+
+func (f *AutoScaling) DescribeAutoScalingGroupsWithContext(_ aws.Context, input *autoscaling.DescribeAutoScalingGroupsInput, _ ...request.Option) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	return f.DescribeAutoScalingGroups(input)
+}
+
+func (f *AutoScaling) DescribeLaunchConfigurations(input *autoscaling.DescribeLaunchConfigurationsInput) (*autoscaling.DescribeLaunchConfigurationsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &autoscaling.DescribeLaunchConfigurationsOutput{LaunchConfigurations: f.LaunchConfigurations}, nil
+}
+
+func (f *AutoScaling) DescribeLaunchConfigurationsWithContext(_ aws.Context, input *autoscaling.DescribeLaunchConfigurationsInput, _ ...request.Option) (*autoscaling.DescribeLaunchConfigurationsOutput, error) {
+	return f.DescribeLaunchConfigurations(input)
+}
@@ -0,0 +1,198 @@
+package fakes
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+)
+
+// Datapoint is one value of a fake metric.
+type Datapoint struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// CloudWatch answers GetMetricData, GetMetricStatistics and DescribeAlarms
+// from in-memory series. A metric returns the same datapoints for every
+// statistic; datapoints outside the requested time range are dropped.
+type CloudWatch struct {
+	cloudwatchiface.CloudWatchAPI
+
+	// Alarms are returned by DescribeAlarms.
+	Alarms []*cloudwatch.MetricAlarm
+	// Err, when set, is returned by every call.
+	Err error
+	// PageSize, when above zero, is the most datapoints GetMetricData returns
+	// per query at once; a NextToken leads to the rest.
+	PageSize int
+
+	mu               sync.Mutex
+	series           map[string][]Datapoint
+	expressions      map[string][]Datapoint
+	metricDataInputs []*cloudwatch.GetMetricDataInput
+}
+
+func metricKey(namespace, metricName, dimensionValue string) string {
+	return namespace + "|" + metricName + "|" + dimensionValue
+}
+
+// SetMetric sets the datapoints of a metric for any dimensions.
+func (f *CloudWatch) SetMetric(namespace, metricName string, points ...Datapoint) {
+	f.SetDimensionMetric(namespace, metricName, "", points...)
+}
+
+// SetDimensionMetric sets the datapoints of a metric for queries with a
+// dimension of the given value, e.g. one instance id. It takes precedence over
+// SetMetric.
+func (f *CloudWatch) SetDimensionMetric(namespace, metricName, dimensionValue string, points ...Datapoint) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.series == nil {
+		f.series = map[string][]Datapoint{}
+	}
+	f.series[metricKey(namespace, metricName, dimensionValue)] = points
+}
+
+// SetExpression sets the datapoints returned for the expression query id.
+func (f *CloudWatch) SetExpression(id string, points ...Datapoint) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.expressions == nil {
+		f.expressions = map[string][]Datapoint{}
+	}
+	f.expressions[id] = points
+}
+
+// MetricDataInputs returns the GetMetricData inputs received so far.
+func (f *CloudWatch) MetricDataInputs() []*cloudwatch.GetMetricDataInput {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*cloudwatch.GetMetricDataInput(nil), f.metricDataInputs...)
+}
+
+func (f *CloudWatch) points(metric *cloudwatch.Metric) []Datapoint {
+	namespace, metricName := aws.StringValue(metric.Namespace), aws.StringValue(metric.MetricName)
+	for _, dimension := range metric.Dimensions {
+		if points, ok := f.series[metricKey(namespace, metricName, aws.StringValue(dimension.Value))]; ok {
+			return points
+		}
+	}
+	return f.series[metricKey(namespace, metricName, "")]
+}
+
+func inRange(points []Datapoint, startTime, endTime *time.Time) []Datapoint {
+	var selected []Datapoint
+	for _, point := range points {
+		if startTime != nil && point.Timestamp.Before(*startTime) {
+			continue
+		}
+		if endTime != nil && !point.Timestamp.Before(*endTime) {
+			continue
+		}
+		selected = append(selected, point)
+	}
+	return selected
+}
+
+func (f *CloudWatch) GetMetricData(input *cloudwatch.GetMetricDataInput) (*cloudwatch.GetMetricDataOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// The caller may reuse input for the next page.
+	received := *input
+	f.metricDataInputs = append(f.metricDataInputs, &received)
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	offset := 0
+	if input.NextToken != nil {
+		var err error
+		if offset, err = strconv.Atoi(*input.NextToken); err != nil {
+			return nil, fmt.Errorf("invalid NextToken %q", *input.NextToken)
+		}
+	}
+	output := &cloudwatch.GetMetricDataOutput{}
+	for _, query := range input.MetricDataQueries {
+		if query.ReturnData != nil && !*query.ReturnData {
+			continue
+		}
+		var points []Datapoint
+		if query.MetricStat != nil && query.MetricStat.Metric != nil {
+			points = f.points(query.MetricStat.Metric)
+		} else {
+			points = f.expressions[aws.StringValue(query.Id)]
+		}
+		result := &cloudwatch.MetricDataResult{
+			Id:         query.Id,
+			Label:      query.Label,
+			StatusCode: aws.String(cloudwatch.StatusCodeComplete),
+			Timestamps: []*time.Time{},
+			Values:     []*float64{},
+		}
+		points = inRange(points, input.StartTime, input.EndTime)
+		if f.PageSize > 0 {
+			if len(points) > offset+f.PageSize {
+				output.NextToken = aws.String(strconv.Itoa(offset + f.PageSize))
+				points = points[offset : offset+f.PageSize]
+			} else if len(points) > offset {
+				points = points[offset:]
+			} else {
+				points = nil
+			}
+		}
+		for _, point := range points {
+			result.Timestamps = append(result.Timestamps, aws.Time(point.Timestamp))
+			result.Values = append(result.Values, aws.Float64(point.Value))
+		}
+		output.MetricDataResults = append(output.MetricDataResults, result)
+	}
+	return output, nil
+}
+
+func (f *CloudWatch) GetMetricDataWithContext(_ aws.Context, input *cloudwatch.GetMetricDataInput, _ ...request.Option) (*cloudwatch.GetMetricDataOutput, error) {
+	return f.GetMetricData(input)
+}
+
+func (f *CloudWatch) GetMetricStatistics(input *cloudwatch.GetMetricStatisticsInput) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	metric := &cloudwatch.Metric{Namespace: input.Namespace, MetricName: input.MetricName, Dimensions: input.Dimensions}
+	output := &cloudwatch.GetMetricStatisticsOutput{Label: input.MetricName}
+	for _, point := range inRange(f.points(metric), input.StartTime, input.EndTime) {
+		output.Datapoints = append(output.Datapoints, &cloudwatch.Datapoint{
+			Timestamp:   aws.Time(point.Timestamp),
+			Average:     aws.Float64(point.Value),
+			Maximum:     aws.Float64(point.Value),
+			Minimum:     aws.Float64(point.Value),
+			Sum:         aws.Float64(point.Value),
+			SampleCount: aws.Float64(1),
+			Unit:        input.Unit,
+		})
+	}
+	return output, nil
+}
+
+func (f *CloudWatch) GetMetricStatisticsWithContext(_ aws.Context, input *cloudwatch.GetMetricStatisticsInput, _ ...request.Option) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	return f.GetMetricStatistics(input)
+}
+
+func (f *CloudWatch) DescribeAlarms(input *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &cloudwatch.DescribeAlarmsOutput{MetricAlarms: f.Alarms}, nil
+}
+
+func (f *CloudWatch) DescribeAlarmsWithContext(_ aws.Context, input *cloudwatch.DescribeAlarmsInput, _ ...request.Option) (*cloudwatch.DescribeAlarmsOutput, error) {
+	return f.DescribeAlarms(input)
+}
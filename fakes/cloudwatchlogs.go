@@ -0,0 +1,128 @@
+package fakes
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+)
+
+// CloudWatchLogs answers logs insights queries with fixed rows.
+type CloudWatchLogs struct {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+
+	// Results are the rows of every query.
+	Results [][]*cloudwatchlogs.ResultField
+	// Status is the final status of every query, Complete when empty.
+	Status string
+	// Polls is the number of GetQueryResults calls answered with Running
+	// before the final status.
+	Polls int
+	// Events are returned by FilterLogEvents.
+	Events []*cloudwatchlogs.FilteredLogEvent
+	// Err, when set, is returned by every call.
+	Err error
+
+	mu      sync.Mutex
+	queries []*cloudwatchlogs.StartQueryInput
+	polls   map[string]int
+	stopped []string
+}
+
+// Row builds a result row from alternating field names and values.
+func Row(fieldsAndValues ...string) []*cloudwatchlogs.ResultField {
+	var row []*cloudwatchlogs.ResultField
+	for i := 0; i+1 < len(fieldsAndValues); i += 2 {
+		row = append(row, &cloudwatchlogs.ResultField{
+			Field: aws.String(fieldsAndValues[i]),
+			Value: aws.String(fieldsAndValues[i+1]),
+		})
+	}
+	return row
+}
+
+// Queries returns the StartQuery inputs received so far.
+func (f *CloudWatchLogs) Queries() []*cloudwatchlogs.StartQueryInput {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*cloudwatchlogs.StartQueryInput(nil), f.queries...)
+}
+
+// Stopped returns the ids of the queries stopped so far.
+func (f *CloudWatchLogs) Stopped() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.stopped...)
+}
+
+func (f *CloudWatchLogs) StartQuery(input *cloudwatchlogs.StartQueryInput) (*cloudwatchlogs.StartQueryOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	f.queries = append(f.queries, input)
+	return &cloudwatchlogs.StartQueryOutput{QueryId: aws.String(fmt.Sprintf("query-%d", len(f.queries)))}, nil
+}
+
+func (f *CloudWatchLogs) StartQueryWithContext(_ aws.Context, input *cloudwatchlogs.StartQueryInput, _ ...request.Option) (*cloudwatchlogs.StartQueryOutput, error) {
+	return f.StartQuery(input)
+}
+
+func (f *CloudWatchLogs) GetQueryResults(input *cloudwatchlogs.GetQueryResultsInput) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if f.polls == nil {
+		f.polls = map[string]int{}
+	}
+	queryId := aws.StringValue(input.QueryId)
+	f.polls[queryId]++
+	if f.polls[queryId] <= f.Polls {
+		return &cloudwatchlogs.GetQueryResultsOutput{Status: aws.String(cloudwatchlogs.QueryStatusRunning)}, nil
+	}
+
+	status := f.Status
+	if status == "" {
+		status = cloudwatchlogs.QueryStatusComplete
+	}
+	output := &cloudwatchlogs.GetQueryResultsOutput{
+		Status:     aws.String(status),
+		Statistics: &cloudwatchlogs.QueryStatistics{RecordsMatched: aws.Float64(float64(len(f.Results)))},
+	}
+	if status == cloudwatchlogs.QueryStatusComplete {
+		output.Results = f.Results
+	}
+	return output, nil
+}
+
+func (f *CloudWatchLogs) GetQueryResultsWithContext(_ aws.Context, input *cloudwatchlogs.GetQueryResultsInput, _ ...request.Option) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+	return f.GetQueryResults(input)
+}
+
+func (f *CloudWatchLogs) StopQuery(input *cloudwatchlogs.StopQueryInput) (*cloudwatchlogs.StopQueryOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = append(f.stopped, aws.StringValue(input.QueryId))
+	return &cloudwatchlogs.StopQueryOutput{Success: aws.Bool(true)}, nil
+}
+
+func (f *CloudWatchLogs) StopQueryWithContext(_ aws.Context, input *cloudwatchlogs.StopQueryInput, _ ...request.Option) (*cloudwatchlogs.StopQueryOutput, error) {
+	return f.StopQuery(input)
+}
+
+func (f *CloudWatchLogs) FilterLogEvents(input *cloudwatchlogs.FilterLogEventsInput) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &cloudwatchlogs.FilterLogEventsOutput{Events: f.Events}, nil
+}
+
+func (f *CloudWatchLogs) FilterLogEventsWithContext(_ aws.Context, input *cloudwatchlogs.FilterLogEventsInput, _ ...request.Option) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	return f.FilterLogEvents(input)
+}
@@ -0,0 +1,129 @@
+package fakes
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// EC2 answers describe calls from in-memory instances. DescribeInstances
+// honours InstanceIds and the instance-id, instance-state-name and
+// instance-type filters.
+type EC2 struct {
+	ec2iface.EC2API
+
+	Instances        []*ec2.Instance
+	InstanceStatuses []*ec2.InstanceStatus
+	SecurityGroups   []*ec2.SecurityGroup
+	Snapshots        []*ec2.Snapshot
+	// Err, when set, is returned by every call.
+	Err error
+}
+
+func (f *EC2) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	var instances []*ec2.Instance
+	for _, instance := range f.Instances {
+		if matchesInstance(instance, input.InstanceIds, input.Filters) {
+			instances = append(instances, instance)
+		}
+	}
+	output := &ec2.DescribeInstancesOutput{}
+	if len(instances) > 0 {
+		output.Reservations = []*ec2.Reservation{{Instances: instances}}
+	}
+	return output, nil
+}
+
+func (f *EC2) DescribeInstancesWithContext(_ aws.Context, input *ec2.DescribeInstancesInput, _ ...request.Option) (*ec2.DescribeInstancesOutput, error) {
+	return f.DescribeInstances(input)
+}
+
+func (f *EC2) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	output, err := f.DescribeInstances(input)
+	if err != nil {
+		return err
+	}
+	fn(output, true)
+	return nil
+}
+
+func (f *EC2) DescribeInstancesPagesWithContext(_ aws.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+	return f.DescribeInstancesPages(input, fn)
+}
+
+func (f *EC2) DescribeInstanceStatus(input *ec2.DescribeInstanceStatusInput) (*ec2.DescribeInstanceStatusOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	ids := stringSet(input.InstanceIds)
+	output := &ec2.DescribeInstanceStatusOutput{}
+	for _, status := range f.InstanceStatuses {
+		if len(ids) == 0 || ids[aws.StringValue(status.InstanceId)] {
+			output.InstanceStatuses = append(output.InstanceStatuses, status)
+		}
+	}
+	return output, nil
+}
+
+func (f *EC2) DescribeInstanceStatusWithContext(_ aws.Context, input *ec2.DescribeInstanceStatusInput, _ ...request.Option) (*ec2.DescribeInstanceStatusOutput, error) {
+	return f.DescribeInstanceStatus(input)
+}
+
+func (f *EC2) DescribeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: f.SecurityGroups}, nil
+}
+
+func (f *EC2) DescribeSecurityGroupsWithContext(_ aws.Context, input *ec2.DescribeSecurityGroupsInput, _ ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return f.DescribeSecurityGroups(input)
+}
+
+func (f *EC2) DescribeSnapshots(input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &ec2.DescribeSnapshotsOutput{Snapshots: f.Snapshots}, nil
+}
+
+func (f *EC2) DescribeSnapshotsWithContext(_ aws.Context, input *ec2.DescribeSnapshotsInput, _ ...request.Option) (*ec2.DescribeSnapshotsOutput, error) {
+	return f.DescribeSnapshots(input)
+}
+
+func matchesInstance(instance *ec2.Instance, instanceIds []*string, filters []*ec2.Filter) bool {
+	if ids := stringSet(instanceIds); len(ids) > 0 && !ids[aws.StringValue(instance.InstanceId)] {
+		return false
+	}
+	for _, filter := range filters {
+		var value string
+		switch aws.StringValue(filter.Name) {
+		case "instance-id":
+			value = aws.StringValue(instance.InstanceId)
+		case "instance-state-name":
+			if instance.State != nil {
+				value = aws.StringValue(instance.State.Name)
+			}
+		case "instance-type":
+			value = aws.StringValue(instance.InstanceType)
+		default:
+			continue
+		}
+		if !stringSet(filter.Values)[value] {
+			return false
+		}
+	}
+	return true
+}
+
+func stringSet(values []*string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[aws.StringValue(value)] = true
+	}
+	return set
+}
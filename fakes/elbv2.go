@@ -0,0 +1,55 @@
+package fakes
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+)
+
+// ELBV2 answers load balancer and target group calls from memory.
+type ELBV2 struct {
+	elbv2iface.ELBV2API
+
+	LoadBalancers []*elbv2.LoadBalancer
+	TargetGroups  []*elbv2.TargetGroup
+	// TargetHealth holds the target health descriptions by target group arn.
+	TargetHealth map[string][]*elbv2.TargetHealthDescription
+	// Err, when set, is returned by every call.
+	Err error
+}
+
+func (f *ELBV2) DescribeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &elbv2.DescribeLoadBalancersOutput{LoadBalancers: f.LoadBalancers}, nil
+}
+
+func (f *ELBV2) DescribeLoadBalancersWithContext(_ aws.Context, input *elbv2.DescribeLoadBalancersInput, _ ...request.Option) (*elbv2.DescribeLoadBalancersOutput, error) {
+	return f.DescribeLoadBalancers(input)
+}
+
+func (f *ELBV2) DescribeTargetGroups(input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &elbv2.DescribeTargetGroupsOutput{TargetGroups: f.TargetGroups}, nil
+}
+
+func (f *ELBV2) DescribeTargetGroupsWithContext(_ aws.Context, input *elbv2.DescribeTargetGroupsInput, _ ...request.Option) (*elbv2.DescribeTargetGroupsOutput, error) {
+	return f.DescribeTargetGroups(input)
+}
+
+func (f *ELBV2) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &elbv2.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: f.TargetHealth[aws.StringValue(input.TargetGroupArn)],
+	}, nil
+}
+
+func (f *ELBV2) DescribeTargetHealthWithContext(_ aws.Context, input *elbv2.DescribeTargetHealthInput, _ ...request.Option) (*elbv2.DescribeTargetHealthOutput, error) {
+	return f.DescribeTargetHealth(input)
+}
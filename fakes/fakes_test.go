@@ -0,0 +1,283 @@
+package fakes_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/Appkube-awsx/awsx-getelementdetails/fakes"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/ApiGateway"
+	"github.com/Appkube-awsx/awsx-getelementdetails/handler/EC2"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/spf13/cobra"
+)
+
+const instanceId = "i-0123456789abcdef0"
+
+// setup installs provider as the client provider with the result cache off
+// and returns a request for the EC2 instance over the last hour.
+func setup(t *testing.T, provider *fakes.Provider) *comman_function.PanelRequest {
+	t.Helper()
+	t.Cleanup(comman_function.SetClientProvider(provider))
+	t.Cleanup(comman_function.SetResultCache(nil))
+
+	endTime := time.Now().UTC().Truncate(time.Minute)
+	startTime := endTime.Add(-time.Hour)
+	return &comman_function.PanelRequest{
+		ElementType:  "EC2",
+		InstanceId:   instanceId,
+		LogGroupName: "CloudTrail/DefaultLogGroup",
+		StartTime:    &startTime,
+		EndTime:      &endTime,
+		ClientAuth:   &model.Auth{Region: "us-east-1"},
+	}
+}
+
+func TestMetricPanel(t *testing.T) {
+	provider := fakes.NewProvider()
+	req := setup(t, provider)
+	provider.CloudWatchClient.SetDimensionMetric("AWS/EC2", "CPUUtilization", instanceId,
+		fakes.Datapoint{Timestamp: req.EndTime.Add(-10 * time.Minute), Value: 42},
+		fakes.Datapoint{Timestamp: req.EndTime.Add(-20 * time.Minute), Value: 21},
+	)
+	provider.CloudWatchClient.SetDimensionMetric("AWS/EC2", "CPUUtilization", "i-other",
+		fakes.Datapoint{Timestamp: req.EndTime.Add(-10 * time.Minute), Value: 99},
+	)
+
+	result, err := comman_function.ExecutePanel("EC2", "cpu_utilization_panel", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var usage map[string]float64
+	if err := json.Unmarshal([]byte(result.Json.(string)), &usage); err != nil {
+		t.Fatalf("json output %v: %v", result.Json, err)
+	}
+	want := map[string]float64{"CurrentUsage": 42, "AverageUsage": 42, "MaxUsage": 42}
+	if !reflect.DeepEqual(usage, want) {
+		t.Errorf("json output = %v, want %v", usage, want)
+	}
+	if result.Metadata == nil || result.Metadata.Status != comman_function.ResultStatusOk || result.Metadata.Datapoints != 6 {
+		t.Errorf("metadata = %+v, want status ok with 6 datapoints", result.Metadata)
+	}
+
+	frames, ok := result.Frame.([]comman_function.Frame)
+	if !ok || len(frames) != 3 {
+		t.Fatalf("frame output = %#v, want 3 frames", result.Frame)
+	}
+	for _, frame := range frames {
+		value := frame.Schema.Fields[1]
+		if value.Name != "CPUUtilization" || value.Labels["InstanceId"] != instanceId {
+			t.Errorf("frame %s field = %+v, want CPUUtilization labelled with InstanceId %s", frame.Schema.RefId, value, instanceId)
+		}
+	}
+
+	inputs := provider.CloudWatchClient.MetricDataInputs()
+	if len(inputs) != 1 {
+		t.Fatalf("%d GetMetricData calls, want 1", len(inputs))
+	}
+	for _, query := range inputs[0].MetricDataQueries {
+		dimensions := query.MetricStat.Metric.Dimensions
+		if len(dimensions) != 1 || aws.StringValue(dimensions[0].Value) != instanceId {
+			t.Errorf("query %s dimensions = %v, want InstanceId %s", aws.StringValue(query.Id), dimensions, instanceId)
+		}
+	}
+}
+
+func TestMetricPanelNoData(t *testing.T) {
+	provider := fakes.NewProvider()
+	req := setup(t, provider)
+
+	result, err := comman_function.ExecutePanel("EC2", "cpu_utilization_panel", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The panel leaves the series out of its output, the metadata still
+	// describes every query.
+	if result.Json != "{}" {
+		t.Errorf("json output = %v, want {}", result.Json)
+	}
+	if result.Metadata == nil || result.Metadata.Status != comman_function.ResultStatusNoData {
+		t.Fatalf("metadata = %+v, want status no_data", result.Metadata)
+	}
+	var names []string
+	for _, series := range result.Metadata.Series {
+		names = append(names, series.Name)
+		if series.Status != comman_function.ResultStatusNoData || series.Period == 0 {
+			t.Errorf("series %+v, want no_data with a period", series)
+		}
+	}
+	if want := []string{"currentUsage", "averageUsage", "maxUsage"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("series = %v, want %v", names, want)
+	}
+}
+
+func TestRunPanelNoData(t *testing.T) {
+	setup(t, fakes.NewProvider())
+	for _, c := range []struct{ elementType, name, flag, value string }{
+		{"EC2", "cpu_utilization_panel", "instanceId", instanceId},
+		{"ApiGateway", "uptime_percentage_panel", "apiName", "orders-api"},
+	} {
+		cmd := &cobra.Command{}
+		cmd.Flags().String(c.flag, c.value, "")
+		err := comman_function.RunPanel(cmd, &model.Auth{Region: "us-east-1"}, c.elementType, c.name)
+		if got := comman_function.ExitCodeOf(err); got != comman_function.ExitNoData {
+			t.Errorf("%s/%s: err = %v with exit code %d, want %d", c.elementType, c.name, err, got, comman_function.ExitNoData)
+		}
+	}
+}
+
+func TestLogsPanel(t *testing.T) {
+	provider := fakes.NewProvider()
+	req := setup(t, provider)
+	provider.CloudWatchLogsClient.Results = [][]*cloudwatchlogs.ResultField{
+		fakes.Row("bin(1mo)", "2024-05-01 00:00:00.000", "InstanceCount", "3"),
+		fakes.Row("bin(1mo)", "2024-04-01 00:00:00.000", "InstanceCount", "5"),
+	}
+	provider.CloudWatchLogsClient.Polls = 1
+
+	result, err := comman_function.ExecutePanel("EC2", "instance_stop_count_panel", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []EC2.InstanceStopCountRow{
+		{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), InstanceCount: 3},
+		{Time: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), InstanceCount: 5},
+	}
+	if rows, ok := result.Json.([]EC2.InstanceStopCountRow); !ok || !reflect.DeepEqual(rows, want) {
+		t.Errorf("json output = %#v, want %#v", result.Json, want)
+	}
+
+	queries := provider.CloudWatchLogsClient.Queries()
+	if len(queries) != 1 {
+		t.Fatalf("%d logs queries, want 1", len(queries))
+	}
+	if got := aws.StringValueSlice(queries[0].LogGroupNames); len(got) != 1 || got[0] != req.LogGroupName {
+		t.Errorf("log groups = %v, want %s", got, req.LogGroupName)
+	}
+	if !strings.Contains(aws.StringValue(queries[0].QueryString), `eventName=="StopInstances"`) {
+		t.Errorf("query %q does not filter StopInstances events", aws.StringValue(queries[0].QueryString))
+	}
+	if got, want := aws.Int64Value(queries[0].StartTime), req.StartTime.Unix()*1000; got != want {
+		t.Errorf("query start = %d, want %d", got, want)
+	}
+}
+
+func TestDescribePanel(t *testing.T) {
+	provider := fakes.NewProvider()
+	req := setup(t, provider)
+	provider.EC2Client.Instances = []*ec2.Instance{
+		{
+			InstanceId:   aws.String(instanceId),
+			InstanceType: aws.String("t3.micro"),
+			Placement:    &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+			State:        &ec2.InstanceState{Name: aws.String("running")},
+		},
+		{
+			InstanceId:   aws.String("i-other"),
+			InstanceType: aws.String("m5.large"),
+			Placement:    &ec2.Placement{AvailabilityZone: aws.String("us-east-1b")},
+			State:        &ec2.InstanceState{Name: aws.String("stopped")},
+		},
+	}
+	provider.EC2Client.InstanceStatuses = []*ec2.InstanceStatus{{
+		InstanceId:     aws.String(instanceId),
+		InstanceStatus: &ec2.InstanceStatusSummary{Status: aws.String("ok")},
+	}}
+	provider.CloudWatchClient.Alarms = []*cloudwatch.MetricAlarm{{AlarmName: aws.String(instanceId + "-cpu")}}
+
+	result, err := comman_function.ExecutePanel("EC2", "instance_status_panel", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := EC2.InstanceInfo{
+		InstanceID:         instanceId,
+		InstanceType:       "t3.micro",
+		AvailabilityZone:   "us-east-1a",
+		State:              "running",
+		SystemChecksStatus: "Passed",
+		CustomAlert:        true,
+		HealthPercentage:   100,
+	}
+	if info, ok := result.Json.(EC2.InstanceInfo); !ok || info != want {
+		t.Errorf("json output = %#v, want %#v", result.Json, want)
+	}
+}
+
+func TestPanelError(t *testing.T) {
+	provider := fakes.NewProvider()
+	req := setup(t, provider)
+	provider.EC2Client.Err = errorString("UnauthorizedOperation")
+
+	_, err := comman_function.ExecutePanel("EC2", "instance_status_panel", req)
+	if err == nil || !strings.Contains(err.Error(), "UnauthorizedOperation") {
+		t.Fatalf("err = %v, want the error of the ec2 call", err)
+	}
+}
+
+func TestPanelWithoutAuth(t *testing.T) {
+	req := setup(t, fakes.NewProvider())
+	req.ClientAuth = nil
+
+	_, err := comman_function.ExecutePanel("EC2", "cpu_utilization_panel", req)
+	if got := comman_function.ErrorTypeOf(err); got != comman_function.ErrorTypeValidation {
+		t.Fatalf("err = %v of type %s, want a validation error", err, got)
+	}
+}
+
+type errorString string
+
+func (e errorString) Error() string { return string(e) }
+
+func TestMetricCache(t *testing.T) {
+	provider := fakes.NewProvider()
+	setup(t, provider)
+	now := time.Now().UTC().Truncate(time.Minute)
+	var points []fakes.Datapoint
+	for i := 1; i <= 6*60; i++ {
+		points = append(points, fakes.Datapoint{Timestamp: now.Add(-time.Duration(i) * time.Minute), Value: float64(i)})
+	}
+	provider.CloudWatchClient.SetDimensionMetric("AWS/EC2", "CPUUtilization", instanceId, points...)
+
+	run := func(startTime, endTime time.Time) *cloudwatch.MetricDataResult {
+		t.Helper()
+		q := comman_function.NewMetricQuery(&startTime, &endTime, 60)
+		q.AddMetric("cpu", "AWS/EC2", "CPUUtilization", "Average", comman_function.Dimension("InstanceId", instanceId))
+		result, err := q.Execute(&model.Auth{Region: "us-east-1"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Result("cpu")
+	}
+
+	// A window moved by a few minutes reads the spans it shares with the
+	// first one from the cache and gives the datapoints of an uncached run.
+	startTime, endTime := now.Add(-5*time.Hour), now.Add(-time.Minute)
+	want := run(startTime.Add(7*time.Minute), endTime.Add(7*time.Minute))
+	uncached := len(provider.CloudWatchClient.MetricDataInputs())
+
+	t.Cleanup(comman_function.SetResultCache(comman_function.NewLRUCache(0)))
+	run(startTime, endTime)
+	got := run(startTime.Add(7*time.Minute), endTime.Add(7*time.Minute))
+	if !reflect.DeepEqual(got.Timestamps, want.Timestamps) || !reflect.DeepEqual(got.Values, want.Values) {
+		t.Errorf("cached datapoints = %v %v, want %v %v", aws.TimeValueSlice(got.Timestamps), aws.Float64ValueSlice(got.Values), aws.TimeValueSlice(want.Timestamps), aws.Float64ValueSlice(want.Values))
+	}
+
+	inputs := provider.CloudWatchClient.MetricDataInputs()[uncached:]
+	if len(inputs) != 2 {
+		t.Fatalf("%d GetMetricData calls, want 2", len(inputs))
+	}
+	if from := aws.TimeValue(inputs[1].StartTime); from.Before(now.Add(-time.Hour)) {
+		t.Errorf("moved window fetched from %v, want only the spans after %v", from, now.Add(-time.Hour))
+	}
+}
@@ -0,0 +1,56 @@
+package fakes
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+// Lambda answers ListFunctions and GetAccountSettings from memory.
+type Lambda struct {
+	lambdaiface.LambdaAPI
+
+	Functions       []*lambda.FunctionConfiguration
+	AccountSettings *lambda.GetAccountSettingsOutput
+	// Err, when set, is returned by every call.
+	Err error
+}
+
+func (f *Lambda) ListFunctions(input *lambda.ListFunctionsInput) (*lambda.ListFunctionsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return &lambda.ListFunctionsOutput{Functions: f.Functions}, nil
+}
+
+func (f *Lambda) ListFunctionsWithContext(_ aws.Context, input *lambda.ListFunctionsInput, _ ...request.Option) (*lambda.ListFunctionsOutput, error) {
+	return f.ListFunctions(input)
+}
+
+func (f *Lambda) ListFunctionsPages(input *lambda.ListFunctionsInput, fn func(*lambda.ListFunctionsOutput, bool) bool) error {
+	output, err := f.ListFunctions(input)
+	if err != nil {
+		return err
+	}
+	fn(output, true)
+	return nil
+}
+
+func (f *Lambda) ListFunctionsPagesWithContext(_ aws.Context, input *lambda.ListFunctionsInput, fn func(*lambda.ListFunctionsOutput, bool) bool, _ ...request.Option) error {
+	return f.ListFunctionsPages(input, fn)
+}
+
+func (f *Lambda) GetAccountSettings(input *lambda.GetAccountSettingsInput) (*lambda.GetAccountSettingsOutput, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if f.AccountSettings == nil {
+		return &lambda.GetAccountSettingsOutput{}, nil
+	}
+	return f.AccountSettings, nil
+}
+
+func (f *Lambda) GetAccountSettingsWithContext(_ aws.Context, input *lambda.GetAccountSettingsInput, _ ...request.Option) (*lambda.GetAccountSettingsOutput, error) {
+	return f.GetAccountSettings(input)
+}
@@ -0,0 +1,124 @@
+package fakes_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/Appkube-awsx/awsx-getelementdetails/fakes"
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestDerivePeriod(t *testing.T) {
+	now := time.Now().UTC()
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name          string
+		age           time.Duration
+		length        time.Duration
+		maxDataPoints int64
+		want          int64
+	}{
+		{name: "last hour", age: time.Hour, length: time.Hour, want: 60},
+		{name: "last day", age: day, length: day, want: 60},
+		{name: "last week", age: 7 * day, length: 7 * day, want: 420},
+		{name: "hour 20 days ago", age: 20 * day, length: time.Hour, want: 300},
+		{name: "hour 100 days ago", age: 100 * day, length: time.Hour, want: 3600},
+		{name: "last 30 days", age: 30 * day, length: 30 * day, want: 1800},
+		{name: "last 100 days", age: 100 * day, length: 100 * day, want: 7200},
+		// 3600s / 7 is 515s, which rounds up to 540s and, where only 5 minute
+		// datapoints are kept, to 600s.
+		{name: "max datapoints rounded to minutes", age: time.Hour, length: time.Hour, maxDataPoints: 7, want: 540},
+		{name: "max datapoints rounded to the retention period", age: 20 * day, length: time.Hour, maxDataPoints: 7, want: 600},
+		{name: "max datapoints above the range", age: time.Hour, length: time.Hour, maxDataPoints: 100000, want: 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startTime := now.Add(-tt.age)
+			endTime := startTime.Add(tt.length)
+			if got := comman_function.DerivePeriod(&startTime, &endTime, tt.maxDataPoints); got != tt.want {
+				t.Errorf("DerivePeriod = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	if got := comman_function.DerivePeriod(nil, nil, 0); got != comman_function.DefaultPeriod {
+		t.Errorf("DerivePeriod without a range = %d, want %d", got, comman_function.DefaultPeriod)
+	}
+}
+
+func TestMetricQuerySplit(t *testing.T) {
+	provider := fakes.NewProvider()
+	req := setup(t, provider)
+	for i := 0; i < 1201; i++ {
+		provider.CloudWatchClient.SetDimensionMetric("AWS/EC2", "CPUUtilization", fmt.Sprintf("i-%d", i),
+			fakes.Datapoint{Timestamp: req.EndTime.Add(-time.Minute), Value: float64(i)})
+	}
+
+	q := comman_function.NewMetricQuery(req.StartTime, req.EndTime, 60)
+	ids := make([]string, 1201)
+	for i := range ids {
+		ids[i] = q.AddMetric("", "AWS/EC2", "CPUUtilization", "Average", comman_function.Dimension("InstanceId", fmt.Sprintf("i-%d", i)))
+	}
+	result, err := q.Execute(&model.Auth{Region: "us-east-1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := provider.CloudWatchClient.MetricDataInputs()
+	var sizes []int
+	for _, input := range inputs {
+		sizes = append(sizes, len(input.MetricDataQueries))
+	}
+	if fmt.Sprint(sizes) != "[500 500 201]" {
+		t.Errorf("GetMetricData calls with %v queries, want [500 500 201]", sizes)
+	}
+	for i, id := range ids {
+		values := result.Result(id).Values
+		if len(values) != 1 || aws.Float64Value(values[0]) != float64(i) {
+			t.Fatalf("result of %s = %v, want [%d]", id, aws.Float64ValueSlice(values), i)
+		}
+	}
+
+	// Expressions can only refer to queries of the same request.
+	q.AddExpression("total", "SUM(METRICS())", "")
+	if _, err := q.Execute(&model.Auth{Region: "us-east-1"}, nil); err == nil {
+		t.Error("Execute split a query with expressions")
+	}
+}
+
+func TestMetricQueryPages(t *testing.T) {
+	provider := fakes.NewProvider()
+	req := setup(t, provider)
+	provider.CloudWatchClient.PageSize = 2
+	var points []fakes.Datapoint
+	for i := 5; i >= 1; i-- {
+		points = append(points, fakes.Datapoint{Timestamp: req.EndTime.Add(-time.Duration(i) * time.Minute), Value: float64(i)})
+	}
+	provider.CloudWatchClient.SetDimensionMetric("AWS/EC2", "CPUUtilization", instanceId, points...)
+	provider.CloudWatchClient.SetDimensionMetric("AWS/EC2", "NetworkIn", instanceId, points[:1]...)
+
+	q := comman_function.NewMetricQuery(req.StartTime, req.EndTime, 60)
+	q.AddMetric("cpu", "AWS/EC2", "CPUUtilization", "Average", comman_function.Dimension("InstanceId", instanceId))
+	q.AddMetric("network", "AWS/EC2", "NetworkIn", "Sum", comman_function.Dimension("InstanceId", instanceId))
+	result, err := q.Execute(&model.Auth{Region: "us-east-1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tokens []string
+	for _, input := range provider.CloudWatchClient.MetricDataInputs() {
+		tokens = append(tokens, aws.StringValue(input.NextToken))
+	}
+	if fmt.Sprint(tokens) != "[ 2 4]" {
+		t.Errorf("GetMetricData calls with NextToken %q, want \"\", \"2\" and \"4\"", tokens)
+	}
+	if got := aws.Float64ValueSlice(result.Result("cpu").Values); fmt.Sprint(got) != "[5 4 3 2 1]" {
+		t.Errorf("merged cpu values = %v, want [5 4 3 2 1]", got)
+	}
+	if got := aws.Float64ValueSlice(result.Result("network").Values); fmt.Sprint(got) != "[5]" {
+		t.Errorf("merged network values = %v, want [5]", got)
+	}
+}
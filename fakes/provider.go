@@ -0,0 +1,84 @@
+// Package fakes holds in-memory implementations of the aws sdk interfaces the
+// panels use, so panels can run without network access:
+//
+//	provider := fakes.NewProvider()
+//	provider.CloudWatchClient.SetMetric("AWS/EC2", "CPUUtilization", fakes.Datapoint{Timestamp: t, Value: 42})
+//	defer comman_function.SetClientProvider(provider)()
+//
+// Every fake embeds its sdk interface; calling a method the fake does not
+// implement panics, which points at the method to add.
+package fakes
+
+import (
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+// Provider is a comman_function.ClientProvider handing out the same fakes
+// for every auth.
+type Provider struct {
+	CloudWatchClient     *CloudWatch
+	CloudWatchLogsClient *CloudWatchLogs
+	EC2Client            *EC2
+	LambdaClient         *Lambda
+	ELBV2Client          *ELBV2
+	APIGatewayClient     *APIGateway
+	APIGatewayV2Client   *APIGatewayV2
+	AutoScalingClient    *AutoScaling
+}
+
+// NewProvider returns a Provider with empty fakes.
+func NewProvider() *Provider {
+	return &Provider{
+		CloudWatchClient:     &CloudWatch{},
+		CloudWatchLogsClient: &CloudWatchLogs{},
+		EC2Client:            &EC2{},
+		LambdaClient:         &Lambda{},
+		ELBV2Client:          &ELBV2{},
+		APIGatewayClient:     &APIGateway{},
+		APIGatewayV2Client:   &APIGatewayV2{},
+		AutoScalingClient:    &AutoScaling{},
+	}
+}
+
+func (p *Provider) CloudWatch(model.Auth) cloudwatchiface.CloudWatchAPI {
+	return p.CloudWatchClient
+}
+
+func (p *Provider) CloudWatchLogs(model.Auth) cloudwatchlogsiface.CloudWatchLogsAPI {
+	return p.CloudWatchLogsClient
+}
+
+func (p *Provider) EC2(model.Auth) ec2iface.EC2API {
+	return p.EC2Client
+}
+
+func (p *Provider) Lambda(model.Auth) lambdaiface.LambdaAPI {
+	return p.LambdaClient
+}
+
+func (p *Provider) ELBV2(model.Auth) elbv2iface.ELBV2API {
+	return p.ELBV2Client
+}
+
+func (p *Provider) APIGateway(model.Auth) apigatewayiface.APIGatewayAPI {
+	return p.APIGatewayClient
+}
+
+func (p *Provider) APIGatewayV2(model.Auth) apigatewayv2iface.ApiGatewayV2API {
+	return p.APIGatewayV2Client
+}
+
+func (p *Provider) AutoScaling(model.Auth) autoscalingiface.AutoScalingAPI {
+	return p.AutoScalingClient
+}
+
+var _ comman_function.ClientProvider = (*Provider)(nil)
@@ -5,10 +5,20 @@ go 1.19
 require (
 	github.com/Appkube-awsx/awsx-common v1.4.1
 	github.com/aws/aws-sdk-go v1.53.10
+	github.com/golang/snappy v0.0.4
+	github.com/prometheus/prometheus v0.40.7
 	github.com/spf13/cobra v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require github.com/mattn/go-runewidth v0.0.9 // indirect
+require (
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/grafana/regexp v0.0.0-20221005093135-b4c2bcb0a4b6 // indirect
+	github.com/mattn/go-runewidth v0.0.9 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/prometheus/common v0.37.1 // indirect
+)
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
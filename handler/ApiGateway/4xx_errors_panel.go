@@ -2,10 +2,9 @@ package ApiGateway
 
 import (
 	"fmt"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -21,54 +20,26 @@ var AwsxApi4xxErrorCmd = &cobra.Command{
 	Use:   "api_4xxerror_panel",
 	Short: "get 4xxerror metrics data",
 	Long:  `command to get 4xxerror metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetApi4xxErrorData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting API 4xx error data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetApi4xxErrorData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetApi4xxErrorData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data
-	metricValue, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/ApiGateway", "4XXError", startTime, endTime, "Sum", "ApiName", cloudWatchClient)
-	//metricValue, err := GetApi4xxErrorMetricValue(clientAuth, ApiName, startTime, endTime, "Sum", cloudWatchClient)
+	metricValue, err := comman_function.GetMetricData(req, instanceId, "AWS/ApiGateway", "4XXError", startTime, endTime, "Sum", "ApiName", cloudWatchClient)
+	//metricValue, err := GetApi4xxErrorMetricValue(req.ClientAuth, ApiName, startTime, endTime, "Sum", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting 4xx error metric value: ", err)
 		return "", nil, err
@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -22,53 +21,25 @@ var AwsxApiCacheMissCmd = &cobra.Command{
 	Use:   "cache_miss_count_panel",
 	Short: "get cache miss count metrics data",
 	Long:  `command to get cache miss count metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetApiCacheMissData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting API cache miss count data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetApiCacheMissData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetApiCacheMissData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data
-	metricValue, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/ApiGateway", "CacheMissCount", startTime, endTime, "Sum", "ApiName", cloudWatchClient)
+	metricValue, err := comman_function.GetMetricData(req, instanceId, "AWS/ApiGateway", "CacheMissCount", startTime, endTime, "Sum", "ApiName", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting API cache miss count metric value: ", err)
 		return "", nil, err
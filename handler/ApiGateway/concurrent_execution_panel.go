@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,47 +15,27 @@ var AwsxApiConcurrentExecutionCmd = &cobra.Command{
 	Use:   "concurrent_execution_panel",
 	Short: "Get concurrent execution metrics data",
 	Long:  `Command to get concurrent execution metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running failed event panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetConcurrentExecutionData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// ConcurrentExecutionRow is one row of the concurrent_execution_panel query.
+type ConcurrentExecutionRow struct {
+	ConcurrentExecutionCount int64 `json:"concurrentExecutionCount" logs:"ConcurrentExecutionCount"`
 }
 
-func GetConcurrentExecutionData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetConcurrentExecutionData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message | filter eventSource = "apigateway.amazonaws.com" | parse @message "*START RequestId: *" as requestId | stats count() as ConcurrentExecutionCount | sort @timestamp desc`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message | filter eventSource = "apigateway.amazonaws.com" | parse @message "*START RequestId: *" as requestId | stats count() as ConcurrentExecutionCount | sort @timestamp desc`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQueryResultsss(results)
 
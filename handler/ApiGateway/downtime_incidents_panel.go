@@ -3,11 +3,11 @@ package ApiGateway
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,49 +15,27 @@ var AwsxApiDowntimeIncidentsCmd = &cobra.Command{
 	Use:   "downtime_incidents",
 	Short: "Get downtime incidents data",
 	Long:  `Command to get downtime incidents data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running downtime incidents command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			results, err := GetDowntimeIncidentsData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Printf("Error getting downtime incidents data: %v\n", err)
-				return
-			}
-			// Print the results
-			// for _, result := range results {
-			fmt.Println(results)
-			// }
-		}
-	},
+// DowntimeIncidentRow is one row of the downtime_incident_panel query.
+type DowntimeIncidentRow struct {
+	Time         time.Time `json:"time" logs:"@timestamp"`
+	EventType    string    `json:"eventType" logs:"eventType"`
+	ErrorMessage string    `json:"errorMessage" logs:"errorMessage"`
 }
 
-func GetDowntimeIncidentsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetDowntimeIncidentsData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventType, errorMessage| filter eventSource = 'apigateway.amazonaws.com'| sort @timestamp desc`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventType, errorMessage| filter eventSource = 'apigateway.amazonaws.com'| sort @timestamp desc`, cloudWatchLogs)
 	if err != nil {
 		return nil, err
 	}
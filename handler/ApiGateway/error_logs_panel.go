@@ -4,11 +4,11 @@ import (
 	"fmt"
 
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -17,47 +17,31 @@ var AwsxApiErrorLogsCmd = &cobra.Command{
 	Use:   "error_logs_panel",
 	Short: "Get error logs metrics data",
 	Long:  `Command to get error logs metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running error logs panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetErrorLogsData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// ErrorLogRow is one row of the error_logs_panel query.
+type ErrorLogRow struct {
+	EventTime     time.Time `json:"eventTime" logs:"eventTime"`
+	HttpMethod    string    `json:"httpMethod" logs:"requestParameters.httpMethod"`
+	ErrorMessage  string    `json:"errorMessage" logs:"errorMessage"`
+	ErrorCount    int64     `json:"errorCount" logs:"errorCode"`
+	ResponseCount int64     `json:"responseCount" logs:"ResponseTime"`
 }
 
-func GetErrorLogsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetErrorLogsData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventType, eventSource, errorCode, errorMessage| filter eventSource = 'apigateway.amazonaws.com'| filter eventName ="GetMethod"| filter ispresent(responseElements) or ispresent(errorCode)| filter requestParameters.httpMethod != ""| stats count(errorMessage) as errorCode,count(eventTime) as ResponseTime by eventTime,errorMessage,requestParameters.httpMethod`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventType, eventSource, errorCode, errorMessage| filter eventSource = 'apigateway.amazonaws.com'| filter eventName ="GetMethod"| filter ispresent(responseElements) or ispresent(errorCode)| filter requestParameters.httpMethod != ""| stats count(errorMessage) as errorCode,count(eventTime) as ResponseTime by eventTime,errorMessage,requestParameters.httpMethod`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQueryResult(results)
 
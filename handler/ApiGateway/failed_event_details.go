@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,47 +15,28 @@ var AwsxApiFailedEventCmd = &cobra.Command{
 	Use:   "failed_event_panel",
 	Short: "Get failed event metrics data",
 	Long:  `Command to get failed event metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running failed event panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetFailedEventData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// FailedEventRow is one row of the failed_event_details query.
+type FailedEventRow struct {
+	EventType    string `json:"eventType" logs:"eventType"`
+	ErrorMessage string `json:"errorMessage" logs:"errorMessage"`
 }
 
-func GetFailedEventData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetFailedEventData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message | filter eventSource = 'apigateway.amazonaws.com' | filter ispresent(errorMessage) | display eventType, errorMessage`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message | filter eventSource = 'apigateway.amazonaws.com' | filter ispresent(errorMessage) | display eventType, errorMessage`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQueryResultss(results)
 
@@ -4,11 +4,11 @@ import (
 	"fmt"
 
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -17,47 +17,28 @@ var AwsxApiIntegrationCountCmd = &cobra.Command{
 	Use:   "integration_count_panel",
 	Short: "Get integration count metrics data",
 	Long:  `Command to get integration count metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running integration count panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetIntegrationCountData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// IntegrationCountRow is one row of the integration_count_panel query.
+type IntegrationCountRow struct {
+	Time             time.Time `json:"time" logs:"bin(1d)"`
+	IntegrationCount int64     `json:"integrationCount" logs:"integrationCount"`
 }
 
-func GetIntegrationCountData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetIntegrationCountData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter @message like /integration/| stats count() as integrationCount by bin(1d)`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter @message like /integration/| stats count() as integrationCount by bin(1d)`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQueryResultzz(results)
 
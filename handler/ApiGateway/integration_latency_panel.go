@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -22,53 +21,25 @@ var AwsxApiIntegrationLatencyCmd = &cobra.Command{
 	Use:   "api_integration_latency_panel",
 	Short: "get integration latency metrics data",
 	Long:  `command to get integration latency metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetApiIntegrationLatencyData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting API integration latency data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetApiIntegrationLatencyData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetApiIntegrationLatencyData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data
-	metricValue, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/ApiGateway", "IntegrationLatency", startTime, endTime, "Average", "ApiName", cloudWatchClient)
+	metricValue, err := comman_function.GetMetricData(req, instanceId, "AWS/ApiGateway", "IntegrationLatency", startTime, endTime, "Average", "ApiName", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting latency metric value: ", err)
 		return "", nil, err
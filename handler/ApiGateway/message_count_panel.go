@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -18,60 +17,30 @@ var AwsxApiMessageCountCmd = &cobra.Command{
 	Short: "get message count metrics data",
 
 	Long: `command to get message count data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-
-		fmt.Println("running from child command")
-
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-
-			log.Printf("Error during authentication: %v\n", err)
-
-			err := cmd.Help()
-
-			if err != nil {
-
-				return
-			}
-
-			return
-		}
-		if authFlag {
-
-			panel, err := GetMessageCountPanel(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-
-		}
-
-	},
+// MessageCountRow is one row of the message_count_panel query.
+type MessageCountRow struct {
+	MessageCount int64 `json:"messageCount" logs:"MessageCount"`
 }
 
-func GetMessageCountPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetMessageCountPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	events, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource="apigateway.amazonaws.com" | parse @message /"name":\s*"(?<ApiName>[^"]+)"/| stats count(@message) as MessageCount`, cloudWatchLogs)
+	events, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource="apigateway.amazonaws.com" | parse @message /"name":\s*"(?<ApiName>[^"]+)"/| stats count(@message) as MessageCount`, cloudWatchLogs)
 	if err != nil {
 		log.Println("Error in getting sample count: ", err)
 		// handle error
 	}
-	for _, event := range events {
-		fmt.Println(event)
-	}
-	
-	return nil, err
+	return events, err
 }
 
 func init() {
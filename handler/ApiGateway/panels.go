@@ -0,0 +1,187 @@
+package ApiGateway
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var apiGatewayElementTypes = []string{"ApiGateway", "AWS/ApiGateway"}
+
+func init() {
+	comman_function.RegisterIdentifier(apiGatewayElementTypes, comman_function.IdentifierApiName)
+	comman_function.RegisterPanels(apiGatewayElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "rest_api_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetApiGatewayRestAPIData(req.ClientAuth, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "successful_and_failed_events_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, uptimeMetricResp, err := GetApiSuccessFailedData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: uptimeMetricResp}, err
+			}),
+		},
+		{
+			Name:          "top_events_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel:         comman_function.TypedLogsPanel(GetTopEventsData, TopEventRow{}),
+		},
+		{
+			Name:          "message_count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.TypedLogsPanel(GetMessageCountPanel, MessageCountRow{}),
+		},
+		{
+			Name:          "successful_event_details_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetSuccessEventData, SuccessEventRow{}),
+		},
+		{
+			Name:          "http_api_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetApiGatewayHttpApiData(req.ClientAuth, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "websocket_api_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetApiGatewayWebSocketAPIData(req.ClientAuth, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "total_api_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetTotalApiData(req.ClientAuth, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "concurrent_execution_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.TypedLogsPanel(GetConcurrentExecutionData, ConcurrentExecutionRow{}),
+		},
+		{
+			Name:          "failed_event_details",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetFailedEventData, FailedEventRow{}),
+		},
+		{
+			Name:          "integration_count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetIntegrationCountData, IntegrationCountRow{}),
+		},
+		{
+			Name:          "request_count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetRequestCountData, RequestCountRow{}),
+		},
+		{
+			Name:          "error_logs_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetErrorLogsData, ErrorLogRow{}),
+		},
+		{
+			Name:          "4xx_errors_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetApi4xxErrorData),
+		},
+		{
+			Name:          "5xx_errors_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetApi5xxErrorData),
+		},
+		{
+			Name:          "latency_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetApiLatencyData),
+		},
+		{
+			Name:          "integration_latency_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetApiIntegrationLatencyData),
+		},
+		{
+			Name:          "response_time_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       ApiResponseTimeCmd,
+			Panel:         comman_function.MetricPanel(GetApiResponseTimePanel),
+		},
+		{
+			Name:          "uptime_percentage_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxApiUptimeCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, uptimeMetricResp, err := GetApiUptimeData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: uptimeMetricResp}, err
+			}),
+		},
+		{
+			Name:          "cache_hit_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxApiCacheHitsCmd,
+			Panel:         comman_function.MetricPanel(GetApiCacheHitsData),
+		},
+		{
+			Name:          "cache_miss_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxApiCacheMissCmd,
+			Panel:         comman_function.MetricPanel(GetApiCacheMissData),
+		},
+		{
+			Name:          "downtime_incident_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxApiDowntimeIncidentsCmd,
+			Panel:         comman_function.TypedLogsPanel(GetDowntimeIncidentsData, DowntimeIncidentRow{}),
+		},
+		{
+			Name:          "uptime_of_deployment_stages",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxApiDeploymentCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, err := GetApiUptimedata(req)
+				return &comman_function.PanelResult{Json: jsonResp}, err
+			}),
+		},
+		{
+			Name:          "total_api_calls_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxApiCallsCmd,
+			Panel:         comman_function.MetricPanel(GetApiCallsData),
+		},
+	})
+}
@@ -4,13 +4,11 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
-
 	// "github.com/Appkube-awsx/awsx-common/config"
-	"github.com/Appkube-awsx/awsx-common/model"
 )
 
 // type APIGatewayLatency struct {
@@ -24,54 +22,25 @@ var ApiResponseTimeCmd = &cobra.Command{
 	Use:   "api_response_time_panel",
 	Short: "Get API response time metrics data",
 	Long:  `Command to get API response time metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command...")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetApiResponseTimePanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting API response time metrics: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// Default case. It prints JSON
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetApiResponseTimePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetApiResponseTimePanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data
-	rawData, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/ApiGateway", "Latency", startTime, endTime, "Average", "ApiName", cloudWatchClient)
+	rawData, err := comman_function.GetMetricData(req, instanceId, "AWS/ApiGateway", "Latency", startTime, endTime, "Average", "ApiName", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting API response time data: ", err)
 		return "", nil, err
@@ -2,13 +2,12 @@ package ApiGateway
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/spf13/cobra"
 )
 
@@ -20,36 +19,9 @@ var AwsxApiGatewayRestAPICmd = &cobra.Command{
 	Use:   "rest_api_panel",
 	Short: "get rest API metrics data",
 	Long:  `Command to get rest API metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetApiGatewayRestAPIData(clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting rest API data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetApiGatewayRestAPIData(clientAuth *model.Auth, apiGatewayClient *apigateway.APIGateway) (string, map[string]float64, error) {
+func GetApiGatewayRestAPIData(clientAuth *model.Auth, apiGatewayClient apigatewayiface.APIGatewayAPI) (string, map[string]float64, error) {
 	cloudwatchMetricData := map[string]float64{}
 
 	restAPIs, err := GetRestAPIs(clientAuth, apiGatewayClient)
@@ -70,9 +42,9 @@ func GetApiGatewayRestAPIData(clientAuth *model.Auth, apiGatewayClient *apigatew
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetRestAPIs(clientAuth *model.Auth, apiGatewayClient *apigateway.APIGateway) (int, error) {
+func GetRestAPIs(clientAuth *model.Auth, apiGatewayClient apigatewayiface.APIGatewayAPI) (int, error) {
 	if apiGatewayClient == nil {
-		apiGatewayClient = awsclient.GetClient(*clientAuth, awsclient.APIGATEWAY_CLIENT).(*apigateway.APIGateway)
+		apiGatewayClient = comman_function.APIGatewayClient(*clientAuth)
 	}
 
 	restAPIs := 0
@@ -6,11 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -23,87 +23,37 @@ var AwsxApiSuccessfulFailedCmd = &cobra.Command{
 	Use:   "successful_and_failed_events_panel",
 	Short: "get successful failed metrics data",
 	Long:  `command to get successful failed metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetApiSuccessFailedData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting API error data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetApiSuccessFailedData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]float64, error) {
-	ApiName := "dev-hrms"
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
+func GetApiSuccessFailedData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]float64, error) {
+	ApiName, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error getting api name: %w", err)
 	}
 
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// Debug prints
-	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
-
 	cloudwatchMetricData := map[string]float64{}
 
 	// Fetch raw data
-	totalevents, err := GetApiTotalEventsMetricValue(clientAuth, startTime, endTime, ApiName, cloudWatchClient)
+	totalevents, err := GetApiTotalEventsMetricValue(req.ClientAuth, startTime, endTime, ApiName, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting  error metric value: ", err)
 		return "", nil, err
 	}
 	cloudwatchMetricData["Count"] = totalevents
 
-	error_4xx, err := GetApiClientErrorMetricValue(clientAuth, startTime, endTime, ApiName,  cloudWatchClient)
+	error_4xx, err := GetApiClientErrorMetricValue(req.ClientAuth, startTime, endTime, ApiName,  cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting  error metric value: ", err)
 		return "", nil, err
 	}
 	cloudwatchMetricData["4xxError"] = error_4xx
 
-	error_5xx, err := GetApiServerErrorsMetricValue(clientAuth, startTime, endTime, ApiName , cloudWatchClient)
+	error_5xx, err := GetApiServerErrorsMetricValue(req.ClientAuth, startTime, endTime, ApiName , cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting  error metric value: ", err)
 		return "", nil, err
@@ -123,7 +73,7 @@ func GetApiSuccessFailedData(cmd *cobra.Command, clientAuth *model.Auth, cloudWa
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetApiTotalEventsMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time,ApiName string, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetApiTotalEventsMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time,ApiName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
     input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String("AWS/ApiGateway"),
 		MetricName: aws.String("Count"),
@@ -141,7 +91,7 @@ func GetApiTotalEventsMetricValue(clientAuth *model.Auth, startTime, endTime *ti
     }
 
     if cloudWatchClient == nil {
-        cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+        cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
     }
 
     result, err := cloudWatchClient.GetMetricStatistics(input)
@@ -150,14 +100,14 @@ func GetApiTotalEventsMetricValue(clientAuth *model.Auth, startTime, endTime *ti
     }
 
     if len(result.Datapoints) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
+		return 0, &comman_function.NoDataError{Panel: "successful_and_failed_events_panel"}
 	}
 
 	// Assuming there's only one datapoint, return its Sum
 	return aws.Float64Value(result.Datapoints[0].Sum), nil
 }
 
-func GetApiClientErrorMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time, ApiName string, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetApiClientErrorMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time, ApiName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String("AWS/ApiGateway"),
 		MetricName: aws.String("4XXError"),
@@ -175,7 +125,7 @@ func GetApiClientErrorMetricValue(clientAuth *model.Auth, startTime, endTime *ti
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricStatistics(input)
@@ -184,14 +134,14 @@ func GetApiClientErrorMetricValue(clientAuth *model.Auth, startTime, endTime *ti
 	}
 
 	if len(result.Datapoints) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
+		return 0, &comman_function.NoDataError{Panel: "successful_and_failed_events_panel"}
 	}
 
 	// Assuming there's only one datapoint, return its Sum
 	return aws.Float64Value(result.Datapoints[0].Sum), nil
 }
 
-func GetApiServerErrorsMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time, ApiName string, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetApiServerErrorsMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time, ApiName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String("AWS/ApiGateway"),
 		MetricName: aws.String("5XXError"),
@@ -209,7 +159,7 @@ func GetApiServerErrorsMetricValue(clientAuth *model.Auth, startTime, endTime *t
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricStatistics(input)
@@ -218,7 +168,7 @@ func GetApiServerErrorsMetricValue(clientAuth *model.Auth, startTime, endTime *t
 	}
 
 	if len(result.Datapoints) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
+		return 0, &comman_function.NoDataError{Panel: "successful_and_failed_events_panel"}
 	}
 
 	// Assuming there's only one datapoint, return its Sum
@@ -2,13 +2,11 @@ package ApiGateway
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
-	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/spf13/cobra"
 )
 
@@ -20,36 +18,9 @@ var AwsxTotalApiCmd = &cobra.Command{
 	Use:   "total_api_panel",
 	Short: "get total api metrics data",
 	Long:  `command to get total api metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetTotalApiData(clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting total api data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetTotalApiData(clientAuth *model.Auth, apiClient *apigateway.APIGateway) (string, map[string]float64, error) {
+func GetTotalApiData(clientAuth *model.Auth, apiClient apigatewayiface.APIGatewayAPI) (string, map[string]float64, error) {
 	cloudwatchMetricData := map[string]float64{}
 
 	totalApis, err := GetTotalApi(clientAuth, apiClient)
@@ -70,9 +41,9 @@ func GetTotalApiData(clientAuth *model.Auth, apiClient *apigateway.APIGateway) (
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetTotalApi(clientAuth *model.Auth, apiClient *apigateway.APIGateway) (int, error) {
+func GetTotalApi(clientAuth *model.Auth, apiClient apigatewayiface.APIGatewayAPI) (int, error) {
 	if apiClient == nil {
-		apiClient = awsclient.GetClient(*clientAuth, awsclient.APIGATEWAY_CLIENT).(*apigateway.APIGateway)
+		apiClient = comman_function.APIGatewayClient(*clientAuth)
 	}
 
 	totalApis := 0
@@ -1,15 +1,14 @@
 package ApiGateway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"strconv"
-	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/apigateway"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
@@ -25,99 +24,87 @@ var AwsxApiDeploymentCmd = &cobra.Command{
 	Use:   "api_downtime_deployment_panel",
 	Short: "Get uptime and downtime deployment metrics data for API stages",
 	Long:  `Command to get uptime and downtime deployment metrics data for API stages`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, err := GetApiUptimedata(cmd, clientAuth)
-			if err != nil {
-				log.Println("Error getting API uptime data: ", err)
-				return
-			}
-
-			if responseType == "frame" {
-				fmt.Println(jsonResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetApiUptimedata(cmd *cobra.Command, clientAuth *model.Auth) (string, error) {
-	apiID := "i3mdnxvgrf"
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	startTime, endTime, err := parseTime(startTimeStr, endTimeStr)
+func GetApiUptimedata(req *comman_function.PanelRequest) (string, error) {
+	apiName, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", fmt.Errorf("error getting api name: %w", err)
+	}
+	apiID, err := GetRestApiId(req.ClientAuth, apiName)
+	if err != nil {
+		return "", err
+	}
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
 		return "", err
 	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
 
-	stages, err := GetStagesForAPI(clientAuth, apiID)
+	stages, err := GetStagesForAPI(req.ClientAuth, apiID)
 	if err != nil {
 		return "", err
 	}
 
-	cloudwatchMetricData := make(map[string]MetricResultss)
-
-	for _, stage := range stages {
-		log.Printf("Fetching metrics for stage: %s", stage)
-		totalRequests, err := GetMetricValue(clientAuth, startTime, endTime, apiID, stage, "Count", "Sum")
-		if err != nil {
-			log.Printf("Error in getting total requests metric value for stage %s: %v", stage, err)
-			return "", err
+	type stageUptime struct {
+		stage  string
+		result MetricResultss
+	}
+	uptimes, err := comman_function.FanOut(req, stages, func(ctx context.Context, stage string) (stageUptime, error) {
+		metricQuery := req.WithContext(ctx).MetricQuery(startTime, endTime)
+		for _, metric := range []struct{ id, name string }{{"total", "Count"}, {"client", "4XXError"}, {"server", "5XXError"}} {
+			metricQuery.Add(comman_function.MetricStatQuery{
+				Id:         metric.id,
+				Namespace:  "AWS/ApiGateway",
+				MetricName: metric.name,
+				Stat:       "Sum",
+				Dimensions: []*cloudwatch.Dimension{comman_function.Dimension("ApiName", apiName), comman_function.Dimension("Stage", stage)},
+				Unit:       "Count",
+				Hidden:     true,
+			})
 		}
-
-		clientErrors, err := GetMetricValue(clientAuth, startTime, endTime, apiID, stage, "4XXError", "Sum")
+		metricQuery.AddExpression("uptime", uptimeExpression, stage)
+		metricData, err := metricQuery.Execute(req.ClientAuth, nil)
 		if err != nil {
-			log.Printf("Error in getting client errors metric value for stage %s: %v", stage, err)
-			return "", err
+			return stageUptime{}, fmt.Errorf("stage %s: %w", stage, err)
 		}
 
-		serverErrors, err := GetMetricValue(clientAuth, startTime, endTime, apiID, stage, "5XXError", "Sum")
-		if err != nil {
-			log.Printf("Error in getting server errors metric value for stage %s: %v", stage, err)
-			return "", err
+		uptime := metricData.Result("uptime")
+		if len(uptime.Values) == 0 {
+			return stageUptime{}, fmt.Errorf("stage %s: %w", stage, &comman_function.NoDataError{Panel: "uptime_of_deployment_stages"})
 		}
-
-		uptimePercentage := calculateUptimePercentage(totalRequests, clientErrors, serverErrors)
+		uptimePercentage := aws.Float64Value(uptime.Values[0])
 		downtimePercentage := 100 - uptimePercentage
 		uptimePercentagestr := strconv.FormatFloat(uptimePercentage, 'f', 2, 64)
 		downtimePercentagestr := strconv.FormatFloat(downtimePercentage, 'f', 2, 64)
 
 		uptimePercentageAvgFloat, err := strconv.ParseFloat(uptimePercentagestr, 64)
 		if err != nil {
-			log.Println("Error converting string to float64: ", err)
-			return "", err
+			return stageUptime{}, err
 		}
 
 		downtimePercentageAvgFloat, err := strconv.ParseFloat(downtimePercentagestr, 64)
 		if err != nil {
-			log.Println("Error converting string to float64: ", err)
-			return "", err
+			return stageUptime{}, err
 		}
 
-		cloudwatchMetricData[stage] = MetricResultss{
+		return stageUptime{stage: stage, result: MetricResultss{
 			UptimePercentage:   uptimePercentageAvgFloat,
 			DowntimePercentage: downtimePercentageAvgFloat,
+		}}, nil
+	})
+	if err != nil {
+		if !comman_function.IsPartial(err) {
+			log.Println("Error in getting uptime metric data: ", err)
+			return "", err
 		}
+		log.Println("Error in getting uptime metric data of some stages: ", err)
+	}
 
-		// log.Printf("Uptime Percentage for stage %s: %f", stage, uptimePercentage)
-		// log.Printf("Downtime Percentage for stage %s: %f", stage, downtimePercentage)
+	cloudwatchMetricData := make(map[string]MetricResultss)
+	for _, uptime := range uptimes {
+		cloudwatchMetricData[uptime.stage] = uptime.result
 	}
 
 	jsonString, err := json.Marshal(cloudwatchMetricData)
@@ -129,36 +116,32 @@ func GetApiUptimedata(cmd *cobra.Command, clientAuth *model.Auth) (string, error
 	return string(jsonString), nil
 }
 
-func parseTime(startTimeStr, endTimeStr string) (*time.Time, *time.Time, error) {
-	var startTime, endTime *time.Time
-
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			return nil, nil, fmt.Errorf("error parsing start time: %v", err)
+// GetRestApiId returns the id of the rest api named apiName, the name being
+// what the ApiName dimension of its metrics holds.
+func GetRestApiId(clientAuth *model.Auth, apiName string) (string, error) {
+	apiGatewayClient := comman_function.APIGatewayClient(*clientAuth)
+
+	var apiID string
+	err := apiGatewayClient.GetRestApisPages(&apigateway.GetRestApisInput{}, func(page *apigateway.GetRestApisOutput, lastPage bool) bool {
+		for _, api := range page.Items {
+			if aws.StringValue(api.Name) == apiName {
+				apiID = aws.StringValue(api.Id)
+				return false
+			}
 		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
+		return true
+	})
+	if err != nil {
+		return "", err
 	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			return nil, nil, fmt.Errorf("error parsing end time: %v", err)
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	if apiID == "" {
+		return "", fmt.Errorf("rest api %s not found", apiName)
 	}
-
-	return startTime, endTime, nil
+	return apiID, nil
 }
 
 func GetStagesForAPI(clientAuth *model.Auth, apiID string) ([]string, error) {
-	apiGatewayClient := awsclient.GetClient(*clientAuth, awsclient.APIGATEWAY_CLIENT).(*apigateway.APIGateway)
+	apiGatewayClient := comman_function.APIGatewayClient(*clientAuth)
 
 	params := &apigateway.GetStagesInput{
 		RestApiId: aws.String(apiID),
@@ -177,56 +160,9 @@ func GetStagesForAPI(clientAuth *model.Auth, apiID string) ([]string, error) {
 	return stages, nil
 }
 
-func GetMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time, apiID, stage, metricName, statistic string) (float64, error) {
-	cloudWatchClient := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
-	apiName := "dev-hrms"
-	input := &cloudwatch.GetMetricStatisticsInput{
-		Namespace:  aws.String("AWS/ApiGateway"),
-		MetricName: aws.String(metricName),
-		Dimensions: []*cloudwatch.Dimension{
-			{
-				Name:  aws.String("ApiName"),
-				Value: aws.String(apiName),
-			},
-			{
-				Name:  aws.String("Stage"),
-				Value: aws.String(stage),
-			},
-		},
-		StartTime:  startTime,
-		EndTime:    endTime,
-		Period:     aws.Int64(300), // 5-minute intervals
-		Statistics: []*string{aws.String(statistic)},
-		Unit:       aws.String("Count"),
-	}
-	// fmt.Println("Input for fetching metrics:", input)
-	result, err := cloudWatchClient.GetMetricStatistics(input)
-	if err != nil {
-		return 0, err
-	}
-
-	if len(result.Datapoints) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
-	}
-
-	// Assuming Sum statistic for simplicity, you can modify this based on your requirements
-	sum := 0.0
-	for _, datapoint := range result.Datapoints {
-		sum += aws.Float64Value(datapoint.Sum)
-	}
-
-	return sum, nil
-}
-
-func calculateUptimePercentage(totalRequests, clientErrors, serverErrors float64) float64 {
-	total := totalRequests + clientErrors + serverErrors
-	if total == 0 {
-		return 100
-	}
-	uptimePercentage := (totalRequests / total) * 100
-	//fmt.Println("Uptime percentage:", uptimePercentage)
-	return uptimePercentage
-}
+// uptimeExpression is the share of successful requests of a stage over the
+// window, 100 when the stage received no requests.
+const uptimeExpression = "IF((SUM(total) + SUM(client) + SUM(server)) == 0, 100, 100 * SUM(total) / (SUM(total) + SUM(client) + SUM(server)))"
 
 func init() {
 	AwsxApiDeploymentCmd.PersistentFlags().String("startTime", "", "Start time")
@@ -2,13 +2,12 @@ package ApiGateway
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -20,35 +19,9 @@ var AwsxApiGatewayWebSocketCmd = &cobra.Command{
 	Use:   "websocket_api_panel",
 	Short: "get WebSocket API metrics data",
 	Long:  `Command to get WebSocket API metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetApiGatewayWebSocketAPIData(clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting WebSocket API data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetApiGatewayWebSocketAPIData(clientAuth *model.Auth, apiGatewayClient *apigatewayv2.ApiGatewayV2) (string, map[string]float64, error) {
+func GetApiGatewayWebSocketAPIData(clientAuth *model.Auth, apiGatewayClient apigatewayv2iface.ApiGatewayV2API) (string, map[string]float64, error) {
 	cloudwatchMetricData := map[string]float64{}
 
 	websocketAPIs, err := GetWebSocketAPIs(clientAuth, apiGatewayClient)
@@ -69,9 +42,9 @@ func GetApiGatewayWebSocketAPIData(clientAuth *model.Auth, apiGatewayClient *api
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetWebSocketAPIs(clientAuth *model.Auth, apiGatewayClient *apigatewayv2.ApiGatewayV2) (int, error) {
+func GetWebSocketAPIs(clientAuth *model.Auth, apiGatewayClient apigatewayv2iface.ApiGatewayV2API) (int, error) {
 	if apiGatewayClient == nil {
-		apiGatewayClient = awsclient.GetClient(*clientAuth, awsclient.APIGATEWAYV2_CLIENT).(*apigatewayv2.ApiGatewayV2)
+		apiGatewayClient = comman_function.APIGatewayV2Client(*clientAuth)
 	}
 
 	websocketAPIs := 0
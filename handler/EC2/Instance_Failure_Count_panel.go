@@ -2,12 +2,12 @@ package EC2
 
 import (
 	"fmt"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 	"log"
+	"time"
 )
 
 var AwsxInstanceFailureCountCmd = &cobra.Command{
@@ -15,46 +15,28 @@ var AwsxInstanceFailureCountCmd = &cobra.Command{
 	Use:   "instance_failure_count_panel",
 	Short: "Get instance failure count metrics data",
 	Long:  `Command to get instance failure count metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running instance failure count panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetInstanceFailureCountPanel(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// InstanceFailureCountRow is one row of the Instance_Failure_Count_panel query.
+type InstanceFailureCountRow struct {
+	Time         time.Time `json:"time" logs:"@timestamp"`
+	EventName    string    `json:"eventName" logs:"eventName"`
+	FailureCount int64     `json:"failureCount" logs:"failureCount"`
 }
 
-func GetInstanceFailureCountPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetInstanceFailureCountPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource=="ec2.amazonaws.com"| filter  eventName=="RunInstances" and failureCount!=""| filter ispresent(responseElements) or ispresent(failureCount)| stats count() as failureCount by eventName,@timestamp`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource=="ec2.amazonaws.com"| filter  eventName=="RunInstances" and failureCount!=""| filter ispresent(responseElements) or ispresent(failureCount)| stats count() as failureCount by eventName,@timestamp`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := comman_function.ProcessQueryResult(results)
 
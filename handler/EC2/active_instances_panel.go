@@ -2,11 +2,10 @@ package EC2
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/spf13/cobra"
 )
@@ -16,40 +15,16 @@ var AwsxEC2ActiveInstanceCountCmd = &cobra.Command{
 	Use:   "active_instances_count",
 	Short: "Retrieve count of active EC2 instances",
 	Long:  `Command to retrieve count of active (running) EC2 instances`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		authFlag, clientAuth, err := HandleAuths(cmd)
-		if err != nil {
-			log.Println("Error during authentication:", err)
-			return
-		}
-
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			activeinstance, err := GetEC2ActiveInstanceCount(clientAuth)
-			if err != nil {
-				log.Println("Error getting EC2 instance summary:", err)
-				return
-			}
-
-			if responseType == "frame" {
-				fmt.Println(activeinstance)
-			} else {
-				printResp := fmt.Sprintf("Active Instance Count: %d", activeinstance)
-				fmt.Println(printResp)
-			}
-		}
-	},
 }
 
 // GetEC2ActiveInstanceCount retrieves the count of active (running) EC2 instances
 func GetEC2ActiveInstanceCount(clientAuth *model.Auth) (int, error) {
-	svc := awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
+	svc := comman_function.EC2Client(*clientAuth)
 
 	input := &ec2.DescribeInstancesInput{}
 	result, err := svc.DescribeInstances(input)
 	if err != nil {
-		log.Fatalf("Error describing EC2 instances: %v", err)
+		return 0, fmt.Errorf("error describing EC2 instances: %w", err)
 	}
 
 	activeInstanceCount := 0
@@ -2,13 +2,11 @@ package EC2
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/spf13/cobra"
 )
 
@@ -21,37 +19,11 @@ var autoScalingConfigPanelCmd = &cobra.Command{
 	Use:   "auto_scaling_config_panel",
 	Short: "gets auto scaling active counts and launch config count",
 	Long:  `Command to get auto scaling active counts and launch config count `,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetAutoScalingInfo(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting  CLI data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetAutoScalingInfo(cmd *cobra.Command, clientAuth *model.Auth, autoScalingClient *autoscaling.AutoScaling) (string, []AutoScalingCounts, error) {
+func GetAutoScalingInfo(req *comman_function.PanelRequest, autoScalingClient autoscalingiface.AutoScalingAPI) (string, []AutoScalingCounts, error) {
 	if autoScalingClient == nil {
-		autoScalingClient = awsclient.GetClient(*clientAuth, awsclient.AUTOSCALING_CLIENT).(*autoscaling.AutoScaling)
+		autoScalingClient = comman_function.AutoScalingClient(*req.ClientAuth)
 	}
 	// sess := session.Must(session.NewSession(&aws.Config{
 	// 	Region: aws.String("us-east-1"), // Specify your AWS region
@@ -5,12 +5,10 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/spf13/cobra"
 )
 
@@ -28,45 +26,17 @@ var AwsxAutoScalingGroupsCmd = &cobra.Command{
 	Use:   "autoscaling_groups",
 	Short: "get autoscaling groups details",
 	Long:  `command to get autoscaling groups details`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, autoScalingGroupsResp, err := GetAutoScalingGroupsDetails(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting autoscaling groups details: ", err)
-				return
-			}
-			if responseType == "frame" {
-				for _, detail := range autoScalingGroupsResp {
-					fmt.Printf("%+v\n", *detail)
-				}
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetAutoScalingGroupsDetails(cmd *cobra.Command, clientAuth *model.Auth, autoScalingClient *autoscaling.AutoScaling) (string, []*AutoScalingGroupDetails, error) {
+func GetAutoScalingGroupsDetails(req *comman_function.PanelRequest, autoScalingClient autoscalingiface.AutoScalingAPI) (string, []*AutoScalingGroupDetails, error) {
 
 	if autoScalingClient == nil {
-		autoScalingClient = awsclient.GetClient(*clientAuth, awsclient.AUTOSCALING_CLIENT).(*autoscaling.AutoScaling)
+		autoScalingClient = comman_function.AutoScalingClient(*req.ClientAuth)
 	}
 	autoScalingGroupsInput := &autoscaling.DescribeAutoScalingGroupsInput{}
 	autoScalingGroupsOutput, err := autoScalingClient.DescribeAutoScalingGroups(autoScalingGroupsInput)
 	if err != nil {
-		return "", nil, fmt.Errorf("error describing Auto Scaling groups: %v", err)
+		return "", nil, fmt.Errorf("error describing Auto Scaling groups: %w", err)
 	}
 
 	var autoScalingGroupDetailsList []*AutoScalingGroupDetails
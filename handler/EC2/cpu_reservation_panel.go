@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -22,64 +21,36 @@ var AwsxCpuReservedPanelCmd = &cobra.Command{
 	Use:   "cpu_reserved_panel",
 	Short: "get cpu reserved metrics data",
 	Long:  `command to get cpu reserved metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetEC2CPUReservationData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting cpu reserved data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetEC2CPUReservationData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetEC2CPUReservationData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data
-	CPU_Reservation, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "CpuReserved", startTime, endTime, "Sum", "InstanceId", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("cpu_reservation", "AWS/"+elementType, "CpuReserved", "Sum", comman_function.Dimension("InstanceId", instanceId))
+	metricQuery.AddMetric("cpu_utilization", "AWS/"+elementType, "CPUUtilization", "Sum", comman_function.Dimension("InstanceId", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting cpu reservation raw data: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
+	CPU_Reservation := metricData.Output("cpu_reservation")
 	cloudwatchMetricData["CPU_Reservation"] = CPU_Reservation
-	CPU_Utilization, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "CPUUtilization", startTime, endTime, "Sum", "InstanceId", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting cpu reservation raw data: ", err)
-		return "", nil, err
-	}
+	CPU_Utilization := metricData.Output("cpu_utilization")
 	cloudwatchMetricData["CPU_Utilization"] = CPU_Utilization
 	return "", cloudwatchMetricData, nil
 }
@@ -1,19 +1,19 @@
 package EC2
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"math"
-	"sync"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -21,99 +21,29 @@ var AwsxEC2CpuUtilizationPerInstanceTypeCommmand = &cobra.Command{
 	Use:   "cpu_utilization_per_type",
 	Short: "get cpu utilization per instance type metrics data",
 	Long:  `command to cpu utilization per instance type metrics data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, resp, err := CpuUtilizationPerInstanceType(cmd, clientAuth, nil, nil)
-			if err != nil {
-				log.Println("Error getting cpu utilization per instance type data : ", err)
-				return
-			}
-			if responseType == "json" {
-				fmt.Println(jsonResp)
-			} else {
-				fmt.Println(resp)
-			}
-		}
-	},
 }
 
-func CpuUtilizationPerInstanceType(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *ec2.EC2, cloudWatchClient *cloudwatch.CloudWatch) (string, []Ec2CpuUtilizationResult, error) {
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
-	}
-	if ec2Client == nil {
-		ec2Client = awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
-	}
-	ec2Input := ec2.DescribeInstancesInput{}
-	instancesResult, err := ec2Client.DescribeInstances(&ec2Input)
+func CpuUtilizationPerInstanceType(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, []Ec2CpuUtilizationResult, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("Error getting cpu utilization per instance type data")
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	var instances []Ec2InstanceOutputData
-	for _, reserv := range instancesResult.Reservations {
-		for _, instance := range reserv.Instances {
-			temp := Ec2InstanceOutputData{
-				InstanceType: *instance.InstanceType,
-				InstanceId:   *instance.InstanceId,
-			}
-			instances = append(instances, temp)
-		}
+	instances, err := describeInstanceTypes(req, ec2Client)
+	if err != nil {
+		return "", nil, err
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*req.ClientAuth)
 	}
-	var wg sync.WaitGroup
-
-	ch := make(chan Ec2CpuUtilizationResult)
-
-	for _, instance := range instances {
-		wg.Add(1)
-		go getCpuUtilization(cloudWatchClient, instance, startTime, endTime, &wg, ch)
-	}
-
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
-
-	var data []Ec2CpuUtilizationResult
-	for result := range ch {
-		data = append(data, result)
+	period := req.MetricPeriod(startTime, endTime)
+	data, err := comman_function.FanOut(req, instances, func(ctx context.Context, instance Ec2InstanceOutputData) (Ec2CpuUtilizationResult, error) {
+		return getCpuUtilization(ctx, cloudWatchClient, instance, startTime, endTime, period)
+	})
+	if err != nil {
+		if !comman_function.IsPartial(err) {
+			return "", nil, fmt.Errorf("error getting cpu utilization of instances: %w", err)
+		}
+		log.Printf("Error getting cpu utilization of some instances: %v", err)
 	}
 
 	jsonData, err := json.Marshal(data)
@@ -128,14 +58,35 @@ type Ec2InstanceOutputData struct {
 	InstanceId   string
 }
 
+// describeInstanceTypes lists the id and type of every instance, page by page.
+func describeInstanceTypes(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API) ([]Ec2InstanceOutputData, error) {
+	if ec2Client == nil {
+		ec2Client = comman_function.EC2Client(*req.ClientAuth)
+	}
+	var instances []Ec2InstanceOutputData
+	err := ec2Client.DescribeInstancesPagesWithContext(req.Context(), &ec2.DescribeInstancesInput{}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reserv := range page.Reservations {
+			for _, instance := range reserv.Instances {
+				instances = append(instances, Ec2InstanceOutputData{
+					InstanceType: aws.StringValue(instance.InstanceType),
+					InstanceId:   aws.StringValue(instance.InstanceId),
+				})
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error describing instances: %w", err)
+	}
+	return instances, nil
+}
+
 type Ec2CpuUtilizationResult struct {
 	InstanceType string
 	Items        map[time.Time]float64
 }
 
-func getCpuUtilization(cloudWatchClient *cloudwatch.CloudWatch, instance Ec2InstanceOutputData, startTime, endTime *time.Time, wg *sync.WaitGroup, ch chan<- Ec2CpuUtilizationResult) {
-	defer wg.Done()
-
+func getCpuUtilization(ctx context.Context, cloudWatchClient cloudwatchiface.CloudWatchAPI, instance Ec2InstanceOutputData, startTime, endTime *time.Time, period int64) (Ec2CpuUtilizationResult, error) {
 	cwInput := cloudwatch.GetMetricDataInput{
 		MetricDataQueries: []*cloudwatch.MetricDataQuery{
 			{
@@ -151,7 +102,7 @@ func getCpuUtilization(cloudWatchClient *cloudwatch.CloudWatch, instance Ec2Inst
 							},
 						},
 					},
-					Period: aws.Int64(300),
+					Period: aws.Int64(period),
 					Stat:   aws.String("Average"),
 				},
 				ReturnData: aws.Bool(true),
@@ -160,18 +111,20 @@ func getCpuUtilization(cloudWatchClient *cloudwatch.CloudWatch, instance Ec2Inst
 		StartTime: aws.Time(*startTime),
 		EndTime:   aws.Time(*endTime),
 	}
-	result, err := cloudWatchClient.GetMetricData(&cwInput)
+	result, err := cloudWatchClient.GetMetricDataWithContext(ctx, &cwInput)
 	if err != nil {
-		log.Printf("internal server error : %w", err)
+		return Ec2CpuUtilizationResult{}, fmt.Errorf("instance %s: %w", instance.InstanceId, err)
 	}
 	dataMap := make(map[time.Time]float64)
-	for i := 0; i < len(result.MetricDataResults[0].Timestamps); i++ {
-		k := result.MetricDataResults[0].Timestamps[i]
-		v := result.MetricDataResults[0].Values[i]
-		dataMap[*k] = math.Round(*v * 100)/100
+	if len(result.MetricDataResults) > 0 {
+		for i := 0; i < len(result.MetricDataResults[0].Timestamps); i++ {
+			k := result.MetricDataResults[0].Timestamps[i]
+			v := result.MetricDataResults[0].Values[i]
+			dataMap[*k] = math.Round(*v * 100)/100
+		}
 	}
-	ch <- Ec2CpuUtilizationResult{
+	return Ec2CpuUtilizationResult{
 		InstanceType: instance.InstanceType,
 		Items:        dataMap,
-	}
+	}, nil
 }
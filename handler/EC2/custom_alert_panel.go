@@ -2,53 +2,40 @@ package EC2
 
 import (
 	"fmt"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 	"log"
+	"time"
 )
 
 var AwsxEc2CustomAlertPanelCmd = &cobra.Command{
 	Use:   "custom_alert_panel",
 	Short: "get custom alerts for EC2 security group changes",
 	Long:  `command to get custom alerts for EC2 security group changes`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from custom alert panel")
+}
 
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			cloudwatchMetric, err := GetEc2CustomAlertPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting custom alerts: ", err)
-				return
-			}
-			fmt.Println(cloudwatchMetric)
-		}
-	},
+// CustomAlertRow is one row of the custom_alert_panel query.
+type CustomAlertRow struct {
+	Time            time.Time `json:"time" logs:"@timestamp"`
+	SecurityGroupId string    `json:"securityGroupId" logs:"SecurityGroupID"`
+	Action          string    `json:"action" logs:"Action"`
+	UserName        string    `json:"userName" logs:"UserName"`
 }
 
-func GetEc2CustomAlertPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetEc2CustomAlertPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, requestParameters.groupId AS SecurityGroupID, if (eventName = 'AuthorizeSecurityGroupIngress' OR eventName = 'AuthorizeSecurityGroupEgress', 'Added', 'Removed') AS Action, userIdentity.sessionContext.sessionIssuer.userName AS UserName| filter eventSource = 'ec2.amazonaws.com' AND (eventName = 'AuthorizeSecurityGroupIngress' OR eventName = 'RevokeSecurityGroupIngress' OR eventName = 'AuthorizeSecurityGroupEgress' OR eventName = 'RevokeSecurityGroupEgress')| sort @timestamp desc`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, requestParameters.groupId AS SecurityGroupID, if (eventName = 'AuthorizeSecurityGroupIngress' OR eventName = 'AuthorizeSecurityGroupEgress', 'Added', 'Removed') AS Action, userIdentity.sessionContext.sessionIssuer.userName AS UserName| filter eventSource = 'ec2.amazonaws.com' AND (eventName = 'AuthorizeSecurityGroupIngress' OR eventName = 'RevokeSecurityGroupIngress' OR eventName = 'AuthorizeSecurityGroupEgress' OR eventName = 'RevokeSecurityGroupEgress')| sort @timestamp desc`, cloudWatchLogs)
 	if err != nil {
 		log.Println("Error in getting custom alert data: ", err)
 		return nil, err
@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -21,60 +20,30 @@ var AwsxEc2DiskAvailableCmd = &cobra.Command{
 	Use:   "disk_available_panel",
 	Short: "get disk available metrics data",
 	Long:  `command to get disk available metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricData, err := GetDiskAvailablePanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting disk available utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				// Assuming "frame" type is for a specific format
-				fmt.Println(cloudwatchMetricData)
-			} else {
-				// Default case, print JSON
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetDiskAvailablePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetDiskAvailablePanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 	// Create a map to store the metric data outputs
 	cloudwatchMetricData := make(map[string]*cloudwatch.GetMetricDataOutput)
-	//totalResult,  err :=  comman_function.GetMetricData(clientAuth, instanceId, "CWAgent","disk_total", startTime, endTime, "Average","InstanceId", cloudWatchClient)
+	//totalResult,  err :=  comman_function.GetMetricData(req, instanceId, "CWAgent","disk_total", startTime, endTime, "Average","InstanceId", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting total and used disk space data: ", err)
 		return "", nil, err
 	}
 
 	// Process the CloudWatch metric data to calculate disk available data
-	availableData, err := comman_function.GetMetricData(clientAuth, instanceId, "CWAgent","disk_total", startTime, endTime, "Average","InstanceId", cloudWatchClient)
+	availableData, err := comman_function.GetMetricData(req, instanceId, "CWAgent","disk_total", startTime, endTime, "Average","InstanceId", cloudWatchClient)
 	if err != nil {
 		log.Println("Error processing disk available data: ", err)
 		return "", nil, err
@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -23,71 +22,41 @@ var AwsxEC2DiskIOPerformanceCmd = &cobra.Command{
 	Use:   "disk_io_performance_panel",
 	Short: "get disk I/O performance metrics data",
 	Long:  `command to get disk I/O performance metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetDiskReadPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting disk I/O performance metrics: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetEC2DiskIOPerformancePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
+func GetEC2DiskIOPerformancePanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 	
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	
 		if err != nil {
-			return "", nil, fmt.Errorf("error parsing time: %v", err)
+			return "", nil, fmt.Errorf("error parsing time: %w", err)
 		}
-		instanceId, err = comman_function.GetCmdbData(cmd)
+		instanceId, err = comman_function.GetCmdbData(req)
 
 		
 		if err != nil {
-			return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+			return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 		}	
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data for DiskReadBytes
-	rawDataDiskReadBytes, err := comman_function.GetMetricData(clientAuth, instanceId, "CWAgent","diskio_read_bytes", startTime, endTime, "Sum","InstanceId",  cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("rawDataDiskReadBytes", "CWAgent", "diskio_read_bytes", "Sum", comman_function.Dimension("InstanceId", instanceId))
+	metricQuery.AddMetric("rawDataDiskWriteBytes", "CWAgent", "diskio_write_bytes", "Sum", comman_function.Dimension("InstanceId", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting raw data for DiskReadBytes: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
+	rawDataDiskReadBytes := metricData.Output("rawDataDiskReadBytes")
 	cloudwatchMetricData["DiskReadBytes"] = rawDataDiskReadBytes
 
 	// Fetch raw data for DiskWriteBytes
-	rawDataDiskWriteBytes, err := comman_function.GetMetricData(clientAuth, instanceId,  "CWAgent","diskio_write_bytes", startTime, endTime, "Sum","InstanceId",   cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting raw data for DiskWriteBytes: ", err)
-		return "", nil, err
-	}
+	rawDataDiskWriteBytes := metricData.Output("rawDataDiskWriteBytes")
 	cloudwatchMetricData["DiskWriteBytes"] = rawDataDiskWriteBytes
 
 	//resultDiskReadBytes := processRawPanelRawData(rawDataDiskReadBytes)
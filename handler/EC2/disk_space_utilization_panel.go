@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -22,53 +21,26 @@ var AwsxEc2DiskUtilizationCmd = &cobra.Command{
 	Use:   "disk_used_panel",
 	Short: "get disk used metrics data",
 	Long:  `command to get disk used metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetDiskUtilizationData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting disk read utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetDiskUtilizationData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetDiskUtilizationData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 	//instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
 	instanceId := "i-0f095714b7c326e6f"
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// instanceId, err = comman_function.GetCmdbData(cmd)
+	// instanceId, err = comman_function.GetCmdbData(req)
 	// if err != nil {
-	// 	return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+	// 	return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	// }
 
 	totalDiskSpace := 100.0 // Assuming total disk space is 100 (representing 100%)
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data
-	rawData, err := comman_function.GetMetricData(clientAuth, instanceId, "CWAgent", "disk_used_percent", startTime, endTime, "Average", "InstanceId", cloudWatchClient)
+	rawData, err := comman_function.GetMetricData(req, instanceId, "CWAgent", "disk_used_percent", startTime, endTime, "Average", "InstanceId", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting disk used data: ", err)
 		return "", nil, err
@@ -1,18 +1,17 @@
 package EC2
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
-	"sync"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
-	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -20,101 +19,28 @@ var AwsxEC2DiskWriteBytesCommmand = &cobra.Command{
 	Use:   "disk_write_bytes_per_type",
 	Short: "get disk write bytes metrics data",
 	Long:  `command to get disk write bytes metrics data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, resp, err := DiskWriteBytesData(cmd, clientAuth, nil, nil)
-			if err != nil {
-				log.Println("Error getting disk write bytes data : ", err)
-				return
-			}
-			if responseType == "json" {
-				fmt.Println(jsonResp)
-			} else {
-				fmt.Println(resp)
-			}
-		}
-	},
 }
 
-func DiskWriteBytesData(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *ec2.EC2, cloudWatchClient *cloudwatch.CloudWatch) (string, []DiscWriteBytesRes, error) {
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
-	}
-	if ec2Client == nil {
-		ec2Client = awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
-	}
-	ec2Input := ec2.DescribeInstancesInput{}
-	instancesResult, err := ec2Client.DescribeInstances(&ec2Input)
+func DiskWriteBytesData(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, []DiscWriteBytesRes, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		log.Printf("Error getting disk write bytes data")
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	var instances []Ec2InstanceOutputData
-	for _, reserv := range instancesResult.Reservations {
-		for _, instance := range reserv.Instances {
-			temp := Ec2InstanceOutputData{
-				InstanceType: *instance.InstanceType,
-				InstanceId:   *instance.InstanceId,
-			}
-			instances = append(instances, temp)
-		}
+	instances, err := describeInstanceTypes(req, ec2Client)
+	if err != nil {
+		return "", nil, err
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*req.ClientAuth)
 	}
-	var wg sync.WaitGroup
-
-	ch := make(chan DiscWriteBytesRes)
-
-	for _, instance := range instances {
-		wg.Add(1)
-		go getDiskWriteBytes(cloudWatchClient, instance, startTime, endTime, &wg, ch)
-	}
-
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
-
-	var data []DiscWriteBytesRes
-	for result := range ch {
-		data = append(data, result)
+	data, err := comman_function.FanOut(req, instances, func(ctx context.Context, instance Ec2InstanceOutputData) (DiscWriteBytesRes, error) {
+		return getDiskWriteBytes(ctx, cloudWatchClient, instance, startTime, endTime)
+	})
+	if err != nil {
+		if !comman_function.IsPartial(err) {
+			return "", nil, fmt.Errorf("error getting disk write bytes of instances: %w", err)
+		}
+		log.Printf("Error getting disk write bytes of some instances: %v", err)
 	}
 
 	jsonData, err := json.Marshal(data)
@@ -130,9 +56,7 @@ type DiscWriteBytesRes struct {
 	Bytes        int64
 }
 
-func getDiskWriteBytes(cloudWatchClient *cloudwatch.CloudWatch, instance Ec2InstanceOutputData, startTime, endTime *time.Time, wg *sync.WaitGroup, ch chan<- DiscWriteBytesRes) {
-	defer wg.Done()
-
+func getDiskWriteBytes(ctx context.Context, cloudWatchClient cloudwatchiface.CloudWatchAPI, instance Ec2InstanceOutputData, startTime, endTime *time.Time) (DiscWriteBytesRes, error) {
 	cwInput := cloudwatch.GetMetricDataInput{
 		MetricDataQueries: []*cloudwatch.MetricDataQuery{
 			{
@@ -157,17 +81,20 @@ func getDiskWriteBytes(cloudWatchClient *cloudwatch.CloudWatch, instance Ec2Inst
 		StartTime: aws.Time(*startTime),
 		EndTime:   aws.Time(*endTime),
 	}
-	result, err := cloudWatchClient.GetMetricData(&cwInput)
+	result, err := cloudWatchClient.GetMetricDataWithContext(ctx, &cwInput)
 	if err != nil {
-		log.Printf("internal server error : %w", err)
+		return DiscWriteBytesRes{}, fmt.Errorf("instance %s: %w", instance.InstanceId, err)
 	}
-	values := result.MetricDataResults[0].Values
 	var sum float64 = 0
-	for _, item := range values {
-		sum += *item
+	for _, res := range result.MetricDataResults {
+		for _, item := range res.Values {
+			if item != nil {
+				sum += *item
+			}
+		}
 	}
-	ch <- DiscWriteBytesRes{
+	return DiscWriteBytesRes{
 		InstanceType: instance.InstanceType,
 		Bytes:        int64(sum),
-	}
+	}, nil
 }
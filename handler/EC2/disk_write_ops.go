@@ -1,18 +1,17 @@
 package EC2
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
-	"sync"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
-	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -20,99 +19,29 @@ var AwsxEC2DiskWriteOpsPerInstanceTypeCommmand = &cobra.Command{
 	Use:   "disk_write_ops_per_type",
 	Short: "get disk write ops per instance type metrics data",
 	Long:  `command to disk write ops per instance type metrics data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, resp, err := DiskWriteOpsPerInstanceType(cmd, clientAuth, nil, nil)
-			if err != nil {
-				log.Println("Error getting disk write ops per instance type data : ", err)
-				return
-			}
-			if responseType == "json" {
-				fmt.Println(jsonResp)
-			} else {
-				fmt.Println(resp)
-			}
-		}
-	},
 }
 
-func DiskWriteOpsPerInstanceType(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *ec2.EC2, cloudWatchClient *cloudwatch.CloudWatch) (string, []Ec2DiskWriteOpsResult, error) {
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-300 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
-	}
-	if ec2Client == nil {
-		ec2Client = awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
-	}
-	ec2Input := ec2.DescribeInstancesInput{}
-	instancesResult, err := ec2Client.DescribeInstances(&ec2Input)
+func DiskWriteOpsPerInstanceType(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, []Ec2DiskWriteOpsResult, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("Error getting disk write ops per instance type data")
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	var instances []Ec2InstanceOutputData
-	for _, reserv := range instancesResult.Reservations {
-		for _, instance := range reserv.Instances {
-			temp := Ec2InstanceOutputData{
-				InstanceType: *instance.InstanceType,
-				InstanceId:   *instance.InstanceId,
-			}
-			instances = append(instances, temp)
-		}
+	instances, err := describeInstanceTypes(req, ec2Client)
+	if err != nil {
+		return "", nil, err
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
-	}
-	var wg sync.WaitGroup
-
-	ch := make(chan Ec2DiskWriteOpsResult)
-
-	for _, instance := range instances {
-		wg.Add(1)
-		go getWriteWriteOps(cloudWatchClient, instance, startTime, endTime, &wg, ch)
+		cloudWatchClient = comman_function.CloudWatchClient(*req.ClientAuth)
 	}
-
-	go func() {
-		wg.Wait()
-		close(ch)
-	}()
-
-	var data []Ec2DiskWriteOpsResult
-	for result := range ch {
-		data = append(data, result)
+	period := req.MetricPeriod(startTime, endTime)
+	data, err := comman_function.FanOut(req, instances, func(ctx context.Context, instance Ec2InstanceOutputData) (Ec2DiskWriteOpsResult, error) {
+		return getWriteWriteOps(ctx, cloudWatchClient, instance, startTime, endTime, period)
+	})
+	if err != nil {
+		if !comman_function.IsPartial(err) {
+			return "", nil, fmt.Errorf("error getting disk write ops of instances: %w", err)
+		}
+		log.Printf("Error getting disk write ops of some instances: %v", err)
 	}
 
 	jsonData, err := json.Marshal(data)
@@ -127,9 +56,7 @@ type Ec2DiskWriteOpsResult struct {
 	Items        map[time.Time]float64
 }
 
-func getWriteWriteOps(cloudWatchClient *cloudwatch.CloudWatch, instance Ec2InstanceOutputData, startTime, endTime *time.Time, wg *sync.WaitGroup, ch chan<- Ec2DiskWriteOpsResult) {
-	defer wg.Done()
-
+func getWriteWriteOps(ctx context.Context, cloudWatchClient cloudwatchiface.CloudWatchAPI, instance Ec2InstanceOutputData, startTime, endTime *time.Time, period int64) (Ec2DiskWriteOpsResult, error) {
 	cwInput := cloudwatch.GetMetricDataInput{
 		MetricDataQueries: []*cloudwatch.MetricDataQuery{
 			{
@@ -145,7 +72,7 @@ func getWriteWriteOps(cloudWatchClient *cloudwatch.CloudWatch, instance Ec2Insta
 							},
 						},
 					},
-					Period: aws.Int64(300),
+					Period: aws.Int64(period),
 					Stat:   aws.String("Average"),
 				},
 				ReturnData: aws.Bool(true),
@@ -154,18 +81,20 @@ func getWriteWriteOps(cloudWatchClient *cloudwatch.CloudWatch, instance Ec2Insta
 		StartTime: aws.Time(*startTime),
 		EndTime:   aws.Time(*endTime),
 	}
-	result, err := cloudWatchClient.GetMetricData(&cwInput)
+	result, err := cloudWatchClient.GetMetricDataWithContext(ctx, &cwInput)
 	if err != nil {
-		log.Printf("internal server error : %w", err)
+		return Ec2DiskWriteOpsResult{}, fmt.Errorf("instance %s: %w", instance.InstanceId, err)
 	}
 	dataMap := make(map[time.Time]float64)
-	for i := 0; i < len(result.MetricDataResults[0].Timestamps); i++ {
-		k := result.MetricDataResults[0].Timestamps[i]
-		v := result.MetricDataResults[0].Values[i]
-		dataMap[*k] = *v
+	if len(result.MetricDataResults) > 0 {
+		for i := 0; i < len(result.MetricDataResults[0].Timestamps); i++ {
+			k := result.MetricDataResults[0].Timestamps[i]
+			v := result.MetricDataResults[0].Values[i]
+			dataMap[*k] = *v
+		}
 	}
-	ch <- Ec2DiskWriteOpsResult{
+	return Ec2DiskWriteOpsResult{
 		InstanceType: instance.InstanceType,
 		Items:        dataMap,
-	}
+	}, nil
 }
@@ -3,11 +3,9 @@ package EC2
 import (
 	"bytes"
 	"fmt"
-	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -25,29 +23,6 @@ var AwsxEC2InstanceSummaryCmd = &cobra.Command{
 	Use:   "ec2_instance_summary_panel",
 	Short: "Retrieve EC2 instance summary data",
 	Long:  `Command to retrieve EC2 instance summary data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		authFlag, clientAuth, err := handleAuths(cmd)
-		if err != nil {
-			log.Println("Error during authentication:", err)
-			return
-		}
-
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			instanceSummaries, printResp, err := GetEC2InstanceSummaryPanel(clientAuth)
-			if err != nil {
-				log.Println("Error getting EC2 instance summary:", err)
-				return
-			}
-
-			if responseType == "frame" {
-				fmt.Println(instanceSummaries)
-			} else {
-				fmt.Println(printResp)
-			}
-		}
-	},
 }
 
 func GetEC2InstanceSummaryPanel(clientAuth *model.Auth) ([]InstanceSummary, string, error) {
@@ -61,13 +36,13 @@ func GetEC2InstanceSummaryPanel(clientAuth *model.Auth) ([]InstanceSummary, stri
 
 func GetEC2InstanceSummary(clientAuth *model.Auth) ([]InstanceSummary, error) {
 	// Use existing AWS client
-	svc := awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
+	svc := comman_function.EC2Client(*clientAuth)
 
 	// Describe EC2 instances
 	input := &ec2.DescribeInstancesInput{}
 	result, err := svc.DescribeInstances(input)
 	if err != nil {
-		log.Fatalf("Error describing EC2 instances: %v", err)
+		return nil, fmt.Errorf("error describing EC2 instances: %w", err)
 	}
 
 	var instanceSummaries []InstanceSummary
@@ -97,14 +72,6 @@ func GetEC2InstanceSummary(clientAuth *model.Auth) ([]InstanceSummary, error) {
 	return instanceSummaries, nil
 }
 
-func handleAuths(cmd *cobra.Command) (bool, *model.Auth, error) {
-	authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-	if err != nil {
-		return false, nil, err
-	}
-	return authFlag, clientAuth, nil
-}
-
 func printTables(instanceSummaries []InstanceSummary) string {
 	var buffer bytes.Buffer
 	table := tablewriter.NewWriter(&buffer)
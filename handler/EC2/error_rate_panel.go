@@ -3,11 +3,11 @@ package EC2
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,47 +16,26 @@ var AwsxEc2ErrorRatePanelCmd = &cobra.Command{
 	Use:   "error_rate_panel",
 	Short: "Get error rate panel metrics data",
 	Long:  `Command to get error rate panel metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running error rate panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			results, err := GetInstanceErrorRatePanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error in getting instance error rate panel: ", err)
-				return
-			}
-			// processedResults := ProcessQueryResults(results)
-			fmt.Println(results)
-		}
-	},
+// InstanceErrorRateRow is one row of the error_rate_panel query.
+type InstanceErrorRateRow struct {
+	Time       time.Time `json:"time" logs:"bin(1d)"`
+	ErrorCount int64     `json:"errorCount" logs:"ErrorCount"`
 }
 
-func GetInstanceErrorRatePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetInstanceErrorRatePanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	events, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource==\"ec2.amazonaws.com | filter eventName==\"RunInstances\" and errorCode!=\"\" | stats count(*) as ErrorCount by bin(1d)| sort @timestamp desc`, cloudWatchLogs)
+	events, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource==\"ec2.amazonaws.com | filter eventName==\"RunInstances\" and errorCode!=\"\" | stats count(*) as ErrorCount by bin(1d)| sort @timestamp desc`, cloudWatchLogs)
 	if err != nil {
 		log.Println("Error in getting sample count: ", err)
 		return nil, err
@@ -222,7 +201,7 @@ func init() {
 
 //     queryResult, err := cloudWatchLogs.StartQuery(params)
 //     if err != nil {
-//         return nil, fmt.Errorf("failed to start query: %v", err)
+//         return nil, fmt.Errorf("failed to start query: %w", err)
 //     }
 
 //     queryId := queryResult.QueryId
@@ -236,7 +215,7 @@ func init() {
 
 //         queryResults, err = cloudWatchLogs.GetQueryResults(queryStatusInput) // Assign value to queryResults
 //         if err != nil {
-//             return nil, fmt.Errorf("failed to get query results: %v", err)
+//             return nil, fmt.Errorf("failed to get query results: %w", err)
 //         }
 
 //         queryStatus = aws.StringValue(queryResults.Status)
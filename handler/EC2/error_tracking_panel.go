@@ -3,11 +3,11 @@ package EC2
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,47 +16,29 @@ var AwsxEc2ErrorTrackingPanelCmd = &cobra.Command{
 	Use:   "error_tracking_panel",
 	Short: "Get error tracking panel metrics data",
 	Long:  `Command to get error tracking panel metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running error tracking panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			results, err := GetErrorTrackingPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error in getting instance error rate panel: ", err)
-				return
-			}
-			// processedResults := ProcessQueryResults(results)
-			fmt.Println(results)
-		}
-	},
+// ErrorTrackingRow is one row of the error_tracking_panel query.
+type ErrorTrackingRow struct {
+	EventTime    time.Time `json:"eventTime" logs:"eventTime"`
+	EventName    string    `json:"eventName" logs:"eventName"`
+	ErrorCode    string    `json:"errorCode" logs:"errorCode"`
+	ErrorMessage string    `json:"errorMessage" logs:"errorMessage"`
+	ErrorCount   int64     `json:"errorCount" logs:"errorCount"`
 }
 
-func GetErrorTrackingPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetErrorTrackingPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	events, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource=="ec2.amazonaws.com"| filter  eventName=="RunInstances"  and errorCode!=""| filter ispresent(responseElements) or ispresent(errorCode)| stats count(*) as errorCount by eventTime,eventName,errorCode,errorMessage`, cloudWatchLogs)
+	events, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource=="ec2.amazonaws.com"| filter  eventName=="RunInstances"  and errorCode!=""| filter ispresent(responseElements) or ispresent(errorCode)| stats count(*) as errorCount by eventTime,eventName,errorCode,errorMessage`, cloudWatchLogs)
 	if err != nil {
 		log.Println("Error in getting sample count: ", err)
 		return nil, err
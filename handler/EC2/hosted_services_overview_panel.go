@@ -1,6 +1,7 @@
 package EC2
 
 import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/spf13/cobra"
 )
 
@@ -19,20 +20,9 @@ var AwsxEc2hostedServicesCmd = &cobra.Command{
 	Use:   "EC2",
 	Short: "A brief description of your application",
 	Long:  `A longer description that spans multiple lines and likely contains examples and usage of using your application.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		queryName, _ := cmd.Flags().GetString("query")
-		elementType, _ := cmd.Flags().GetString("elementType")
-
-		if queryName == "hosted_services_overview_panel" && (elementType == "EC2" || elementType == "AWS/EC2") {
-			_, err := GetHostedServicesData(cmd)
-			if err != nil {
-				return
-			}
-		}
-	},
 }
 
-func GetHostedServicesData(cmd *cobra.Command) ([]HostedSerivcesOverView, error) {
+func GetHostedServicesData(req *comman_function.PanelRequest) ([]HostedSerivcesOverView, error) {
 	serviceStatus := []HostedSerivcesOverView{
 		{
 			ServiceName:  "WebServer",
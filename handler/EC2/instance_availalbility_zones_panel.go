@@ -4,12 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -28,38 +27,11 @@ var AwsxInstanceAvailabilityZoneCmd = &cobra.Command{
 	Use:   "instance_availalbility_zones_panel",
 	Short: "gets total insatances and availability zones percentage",
 	Long:  `Command to get total insatances and availability zones percentage`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetInstanceAvailabilityZonesData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting instance availalbility zones data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetInstanceAvailabilityZonesData(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *ec2.EC2) (string, Summary, error) {
+func GetInstanceAvailabilityZonesData(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API) (string, Summary, error) {
 
-	instances_details, err := GetEc2InstanceDetails(cmd, clientAuth, ec2Client)
+	instances_details, err := GetEc2InstanceDetails(req, ec2Client)
 	if err != nil {
 		log.Println("Error in getting instance availalbility zones: ", err)
 		return "", Summary{}, err
@@ -69,22 +41,22 @@ func GetInstanceAvailabilityZonesData(cmd *cobra.Command, clientAuth *model.Auth
 
 	jsonString, err := json.MarshalIndent(instances_details, "", "  ")
 	if err != nil {
-		log.Fatalf("Failed to marshal summary to JSON: %v", err)
+		return "", Summary{}, fmt.Errorf("failed to marshal summary to JSON: %w", err)
 	}
 
 	return string(jsonString), instances_details, nil
 }
 
-func GetEc2InstanceDetails(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *ec2.EC2) (Summary, error) {
+func GetEc2InstanceDetails(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API) (Summary, error) {
 	if ec2Client == nil {
-		ec2Client = awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
+		ec2Client = comman_function.EC2Client(*req.ClientAuth)
 	}
 
 	input := &ec2.DescribeInstancesInput{}
 
 	result, err := ec2Client.DescribeInstances(input)
 	if err != nil {
-		log.Fatalf("Failed to describe instances: %v", err)
+		return Summary{}, fmt.Errorf("failed to describe instances: %w", err)
 	}
 
 	azCount := make(map[string]int)
@@ -107,6 +79,7 @@ func GetEc2InstanceDetails(cmd *cobra.Command, clientAuth *model.Auth, ec2Client
 			Percentage:       percentage,
 		})
 	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].AvailabilityZone < zones[j].AvailabilityZone })
 
 	summary := Summary{
 		TotalInstances: instanceCount,
@@ -5,11 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -25,38 +23,11 @@ var AwsxNetworkConnectivityCmd = &cobra.Command{
 	Use:   "instance_connectivity_panel",
 	Short: "gets connectivity of instances",
 	Long:  `Command to get connectivity of instances`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetConnectivityData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting connectivity of instances : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetConnectivityData(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *ec2.EC2) (string, []Instance, error) {
+func GetConnectivityData(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API) (string, []Instance, error) {
 
-	instances_details, err := GetConnectivityDetails(cmd, clientAuth, ec2Client)
+	instances_details, err := GetConnectivityDetails(req, ec2Client)
 	if err != nil {
 		log.Println("Error in connectivity of instances: ", err)
 		return "", nil, err
@@ -66,22 +37,22 @@ func GetConnectivityData(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *
 
 	jsonString, err := json.MarshalIndent(instances_details, "", "  ")
 	if err != nil {
-		log.Fatalf("Failed to marshal summary to JSON: %v", err)
+		return "", nil, fmt.Errorf("failed to marshal summary to JSON: %w", err)
 	}
 
 	return string(jsonString), instances_details, nil
 }
 
-func GetConnectivityDetails(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *ec2.EC2) ([]Instance, error) {
+func GetConnectivityDetails(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API) ([]Instance, error) {
 	if ec2Client == nil {
-		ec2Client = awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
+		ec2Client = comman_function.EC2Client(*req.ClientAuth)
 	}
 
 	input := &ec2.DescribeInstancesInput{}
 
 	result, err := ec2Client.DescribeInstances(input)
 	if err != nil {
-		log.Fatalf("Failed to describe instances: %v", err)
+		return nil, fmt.Errorf("failed to describe instances: %w", err)
 	}
 
 	var instances []Instance
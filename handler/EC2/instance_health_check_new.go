@@ -3,13 +3,11 @@ package EC2
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -23,42 +21,11 @@ var AwsxEc2InstanceHealthCheckNewCmd = &cobra.Command{
 	Use:   "instance_health_check_new",
 	Short: "Get EC2 instance health check data",
 	Long:  `Command to get EC2 instance health check data including counts of healthy and unhealthy instances`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running instance health check command...")
-		authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			healthCheck, err := GetInstanceHealthCheckNew(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting network traffic data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println("This cli is for only json output")
-			} else {
-				jsonResp, err := json.Marshal(&healthCheck)
-				if err != nil {
-					log.Println("Error marshalling network traffic data: ", err)
-					return
-				}
-				fmt.Println(string(jsonResp))
-			}
-		}
-	},
 }
 
-func GetInstanceHealthCheckNew(cmd *cobra.Command, clientAuth *model.Auth, ec2Client *ec2.EC2) (string, error) {
+func GetInstanceHealthCheckNew(req *comman_function.PanelRequest, ec2Client ec2iface.EC2API) (string, error) {
 	if ec2Client == nil {
-		ec2Client = awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
+		ec2Client = comman_function.EC2Client(*req.ClientAuth)
 	}
 
 	allInstanceStatuses := []*ec2.InstanceStatus{}
@@ -72,7 +39,7 @@ func GetInstanceHealthCheckNew(cmd *cobra.Command, clientAuth *model.Auth, ec2Cl
 
 		resp, err := ec2Client.DescribeInstanceStatus(params)
 		if err != nil {
-			return "", fmt.Errorf("failed to describe instance status: %v", err)
+			return "", fmt.Errorf("failed to describe instance status: %w", err)
 		}
 		//fmt.Println(resp)
 		allInstanceStatuses = append(allInstanceStatuses, resp.InstanceStatuses...)
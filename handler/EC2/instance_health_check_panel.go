@@ -3,11 +3,11 @@ package EC2
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,47 +16,32 @@ var AwsxEc2InstanceHealthCheckCmd = &cobra.Command{
 	Use:   "ec2_instance_health_check_panel",
 	Short: "Get ec2 instance health check data",
 	Long:  `Command to get ec2 instance health check data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running ec2 instance health check panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetEc2InstanceHealthCheckData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// InstanceHealthCheckRow is one row of the instance_health_check_panel query.
+type InstanceHealthCheckRow struct {
+	Time             time.Time `json:"time" logs:"@timestamp"`
+	InstanceId       string    `json:"instanceId" logs:"instanceId"`
+	InstanceType     string    `json:"instanceType" logs:"instanceType"`
+	LaunchTime       time.Time `json:"launchTime" logs:"launchTime"`
+	AvailabilityZone string    `json:"availabilityZone" logs:"availabilityZone"`
+	InstanceStatus   string    `json:"instanceStatus" logs:"instanceStatus"`
 }
 
-func GetEc2InstanceHealthCheckData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetEc2InstanceHealthCheckData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp| filter eventSource=="ec2.amazonaws.com"| filter eventName=="RunInstances"| fields responseElements.instancesSet.items.0.instanceId as instanceId, requestParameters.instanceType as instanceType, responseElements.instancesSet.items.0.launchTime as launchTime, responseElements.instancesSet.items.0.placement.availabilityZone as availabilityZone, responseElements.instancesSet.items.0.instanceState.name as instanceStatus| sort @timestamp desc`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp| filter eventSource=="ec2.amazonaws.com"| filter eventName=="RunInstances"| fields responseElements.instancesSet.items.0.instanceId as instanceId, requestParameters.instanceType as instanceType, responseElements.instancesSet.items.0.launchTime as launchTime, responseElements.instancesSet.items.0.placement.availabilityZone as availabilityZone, responseElements.instancesSet.items.0.instanceState.name as instanceStatus| sort @timestamp desc`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQuerysResultzss(results)
 
@@ -4,14 +4,12 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/cmdb"
-	"github.com/Appkube-awsx/awsx-common/config"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -25,31 +23,18 @@ type InstanceInfo struct {
 	HealthPercentage   float64
 }
 
-func GetInstanceStatus(cmd *cobra.Command, clientauth *model.Auth) (InstanceInfo, error) {
-    cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
+func GetInstanceStatus(req *comman_function.PanelRequest) (InstanceInfo, error) {
 	// elementType, _ := cmd.PersistentFlags().GetString("elementType")
 
-	if elementId != "" {
-		log.Println("getting cloud-element data from cmdb")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("using default cmdb url")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("cmdb url: " + apiUrl)
-		cmdbData, _ := cmdb.GetCloudElementData(apiUrl, elementId)
-		// if err != nil {
-		// 	return ,err
-		// }
-		instanceId = cmdbData.InstanceId
+	instanceId, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return InstanceInfo{}, err
 	}
-    instanceID := "i-078bafb47ad7de492"// Initialize EC2 client
-	ec2Client := awsclient.GetClient(*clientauth, awsclient.EC2_CLIENT).(*ec2.EC2)
+	// Initialize EC2 client
+	ec2Client := comman_function.EC2Client(*req.ClientAuth)
 
 	// Initialize CloudWatch client
-	cloudWatchClient := awsclient.GetClient(*clientauth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+	cloudWatchClient := comman_function.CloudWatchClient(*req.ClientAuth)
 
 	log.Printf("Getting AWS EC2 instance status for instance ID: %s\n", instanceId)
 
@@ -92,7 +77,7 @@ func GetInstanceStatus(cmd *cobra.Command, clientauth *model.Auth) (InstanceInfo
 	}
 
 	instanceInfo := InstanceInfo{
-		InstanceID:         instanceID,
+		InstanceID:         instanceId,
 		InstanceType:       aws.StringValue(instance.InstanceType),
 		AvailabilityZone:   aws.StringValue(instance.Placement.AvailabilityZone),
 		State:              aws.StringValue(instance.State.Name),
@@ -105,7 +90,7 @@ func GetInstanceStatus(cmd *cobra.Command, clientauth *model.Auth) (InstanceInfo
 }
 
 // getSystemChecksStatus retrieves the status of system checks for the instance (passed or failed).
-func getSystemChecksStatus(ec2Client *ec2.EC2, instanceID string) string {
+func getSystemChecksStatus(ec2Client ec2iface.EC2API, instanceID string) string {
 	params := &ec2.DescribeInstanceStatusInput{
 		InstanceIds: []*string{aws.String(instanceID)},
 	}
@@ -127,7 +112,7 @@ func getSystemChecksStatus(ec2Client *ec2.EC2, instanceID string) string {
 }
 
 // checkForCustomAlert checks if the instance has custom alerts.
-func checkForCustomAlert(cloudWatchClient *cloudwatch.CloudWatch, instanceID string) (bool, error) {
+func checkForCustomAlert(cloudWatchClient cloudwatchiface.CloudWatchAPI, instanceID string) (bool, error) {
 	// Retrieve CloudWatch alarms using DescribeAlarms API
 	resp, err := cloudWatchClient.DescribeAlarms(&cloudwatch.DescribeAlarmsInput{
 		StateValue:      aws.String("ALARM"), // Optionally filter by alarm state
@@ -145,34 +130,6 @@ var AwsxEc2InstanceStatusCmd = &cobra.Command{
 	Use:   "instance_status_panel",
 	Short: "get instance status metrics data",
 	Long:  `command to get instance status metrics data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-
-		if authFlag {
-			// Example instance ID
-
-
-			// Call GetInstanceStatus
-			instanceStatus, err := GetInstanceStatus(cmd, clientAuth)
-			if err != nil {
-				log.Fatalf("Error getting instance status: %v", err)
-			}
-
-			// Print instance information
-			fmt.Printf("Instance ID: %s, Instance Type: %s, Availability Zone: %s, State: %s, System Checks Status: %s, Custom Alert: %t, Health Percentage: %.2f%%\n",
-				instanceStatus.InstanceID, instanceStatus.InstanceType, instanceStatus.AvailabilityZone, instanceStatus.State, instanceStatus.SystemChecksStatus, instanceStatus.CustomAlert, instanceStatus.HealthPercentage)
-		}
-	},
 }
 
 
@@ -251,7 +208,7 @@ var AwsxEc2InstanceStatusCmd = &cobra.Command{
 
 // 			// Append instance information to the slice
 // 			instanceStatusData = append(instanceStatusData, InstanceInfo{
-// 				InstanceID:         instanceID,
+// 				InstanceID:         instanceId,
 // 				InstanceType:       instanceType,
 // 				AvailabilityZone:   availabilityZone,
 // 				State:              state,
@@ -2,12 +2,11 @@ package EC2
 
 import (
 	"fmt"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
-	"log"
+	"time"
 )
 
 var AwsxEc2InstanceTerminatedCountCmd = &cobra.Command{
@@ -15,48 +14,31 @@ var AwsxEc2InstanceTerminatedCountCmd = &cobra.Command{
 	Use:   "instance_terminated_count_panel",
 	Short: "Get instance terminated count metrics data",
 	Long:  `Command to get instance terminated count metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running instance terminated count panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetInstanceTerminatedCountPanel(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// InstanceTerminatedRow is one row of the instance_terminated_count_panel query.
+type InstanceTerminatedRow struct {
+	EventTime time.Time `json:"eventTime" logs:"eventTime"`
+	EventName string    `json:"eventName" logs:"eventName"`
+	InvokedBy string    `json:"invokedBy" logs:"userIdentity.invokedBy"`
+	State     string    `json:"state" logs:"responseElements.instancesSet.items.0.currentState.name"`
 }
 
-func GetInstanceTerminatedCountPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetInstanceTerminatedCountPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message,eventTime, eventName, userIdentity.invokedBy, responseElements.instancesSet.items.0.currentState.name
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message,eventTime, eventName, userIdentity.invokedBy, responseElements.instancesSet.items.0.currentState.name
 	| filter eventName = "TerminateInstances"
 	|display eventTime, eventName, userIdentity.invokedBy,responseElements.instancesSet.items.0.currentState.name`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := comman_function.ProcessQueryResult(results)
 
@@ -4,15 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/cmdb"
-	"github.com/Appkube-awsx/awsx-common/config"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -27,123 +23,43 @@ var AwsxEc2LatencyCmd = &cobra.Command{
 	Use:   "latency_panel",
 	Short: "get latency metrics data",
 	Long:  `command to get latency metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLatencyPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting network utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetLatencyPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-
-	if elementId != "" {
-		log.Println("getting cloud-element data from cmdb")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("using default cmdb url")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("cmdb url: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", nil, err
-		}
-		instanceId = cmdbData.InstanceId
-
-	}
+func GetLatencyPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			err := cmd.Help()
-			if err != nil {
-				return "", nil, err
-			}
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
+	instanceId, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", nil, err
 	}
 
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			err := cmd.Help()
-			if err != nil {
-				return "", nil, err
-			}
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
-	// Get Inbound Traffic
-	inboundTraffic, err := GetLatencyMetricData(clientAuth, instanceId, elementType, startTime, endTime, "Average", "NetworkIn", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting inbound traffic: ", err)
-		return "", nil, err
-	}
-	cloudwatchMetricData["InboundTraffic"] = inboundTraffic
-	// Get Outbound Traffic
-	outboundTraffic, err := GetLatencyMetricData(clientAuth, instanceId, elementType, startTime, endTime, "Average", "NetworkOut", cloudWatchClient)
+	// Data transferred and latency are derived by cloudwatch for every timestamp.
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("inboundTraffic", "AWS/EC2", "NetworkIn", "Average", comman_function.Dimension("InstanceId", instanceId))
+	metricQuery.AddMetric("outboundTraffic", "AWS/EC2", "NetworkOut", "Average", comman_function.Dimension("InstanceId", instanceId))
+	metricQuery.AddExpression("dataTransferred", "inboundTraffic + outboundTraffic", "DataTransferred")
+	metricQuery.AddExpression("latency", "(inboundTraffic + outboundTraffic) / 2", "Latency")
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting outbound traffic: ", err)
+		log.Println("Error in getting network traffic: ", err)
 		return "", nil, err
 	}
-	cloudwatchMetricData["OutboundTraffic"] = outboundTraffic
-
-	// Calculate Data Transferred (sum of inbound and outbound)
-	dataTransferred := *inboundTraffic.MetricDataResults[0].Values[0] + *outboundTraffic.MetricDataResults[0].Values[0]
-	latency := dataTransferred / 2
-	cloudwatchMetricData["DataTransferred"] = createMetricData(dataTransferred)
+	cloudwatchMetricData["InboundTraffic"] = metricData.Output("inboundTraffic")
+	cloudwatchMetricData["OutboundTraffic"] = metricData.Output("outboundTraffic")
+	cloudwatchMetricData["DataTransferred"] = metricData.Output("dataTransferred")
+	cloudwatchMetricData["Latency"] = metricData.Output("latency")
 
 	jsonOutput := Ec2Latency{
-		InboundTraffic:  *inboundTraffic.MetricDataResults[0].Values[0],
-		OutboundTraffic: *outboundTraffic.MetricDataResults[0].Values[0],
-		DataTransferred: dataTransferred,
-		Latency:         latency,
+		InboundTraffic:  latestValue(metricData.Result("inboundTraffic")),
+		OutboundTraffic: latestValue(metricData.Result("outboundTraffic")),
+		DataTransferred: latestValue(metricData.Result("dataTransferred")),
+		Latency:         latestValue(metricData.Result("latency")),
 	}
 
 	jsonString, err := json.Marshal(struct{ Latency float64 }{Latency: jsonOutput.Latency})
@@ -155,56 +71,14 @@ func GetLatencyPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClien
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetLatencyMetricData(clientAuth *model.Auth, instanceID, elementType string, startTime, endTime *time.Time, statistic string, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
-	log.Printf("Getting metric data for instance %s in namespace %s from %v to %v", instanceID, elementType, startTime, endTime)
-	elmType := "AWS/EC2"
-	if elementType == "EC2" {
-		elmType = "AWS/" + elementType
-	}
-	input := &cloudwatch.GetMetricDataInput{
-		EndTime:   endTime,
-		StartTime: startTime,
-		MetricDataQueries: []*cloudwatch.MetricDataQuery{
-			{
-				Id: aws.String("m1"),
-				MetricStat: &cloudwatch.MetricStat{
-					Metric: &cloudwatch.Metric{
-						Dimensions: []*cloudwatch.Dimension{
-							{
-								Name:  aws.String("InstanceId"),
-								Value: aws.String(instanceID),
-							},
-						},
-						MetricName: aws.String(metricName),
-						Namespace:  aws.String(elmType),
-					},
-					Period: aws.Int64(300),
-					Stat:   aws.String(statistic),
-				},
-			},
-		},
-	}
-	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+// latestValue returns the most recent datapoint of result, or 0 without data.
+func latestValue(result *cloudwatch.MetricDataResult) float64 {
+	if len(result.Values) == 0 {
+		return 0
 	}
-
-	result, err := cloudWatchClient.GetMetricData(input)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
+	return aws.Float64Value(result.Values[0])
 }
 
-func createMetricData(value float64) *cloudwatch.GetMetricDataOutput {
-	return &cloudwatch.GetMetricDataOutput{
-		MetricDataResults: []*cloudwatch.MetricDataResult{
-			{
-				Values: []*float64{&value},
-			},
-		},
-	}
-}
 func init() {
 	AwsxEc2LatencyCmd.PersistentFlags().String("elementId", "", "element id")
 	AwsxEc2LatencyCmd.PersistentFlags().String("elementType", "", "element type")
@@ -288,12 +162,12 @@ func init() {
 
 // 	startTime, endTime, err := comman_function.ParseTimes(cmd)
 // 	if err != nil {
-// 		return "", nil, fmt.Errorf("error parsing time: %v", err)
+// 		return "", nil, fmt.Errorf("error parsing time: %w", err)
 // 	}
 
 // 	instanceId, err = comman_function.GetCmdbData(cmd)
 // 	if err != nil {
-// 		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+// 		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 // 	}
 // 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	 "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,47 +15,32 @@ var AwsxEC2ListOfInstancesFailureCmd = &cobra.Command{
 	Use:   "list_of_instances_failure_panel",
 	Short: "Get list of instances failure logs data",
 	Long:  `Command to get list of instances failure logs data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running list of instances failure panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetListOfInstancesFailureData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// InstanceFailureRow is one row of the list_of_ec2_instances_failure_panel query.
+type InstanceFailureRow struct {
+	EventName        string `json:"eventName" logs:"eventName"`
+	InstanceId       string `json:"instanceId" logs:"responseElements.instancesSet.items.0.instanceId"`
+	InstanceType     string `json:"instanceType" logs:"responseElements.instancesSet.items.0.instanceType"`
+	AvailabilityZone string `json:"availabilityZone" logs:"responseElements.instancesSet.items.0.placement.availabilityZone"`
+	ErrorMessage     string `json:"errorMessage" logs:"errorMessage"`
+	FailureCount     int64  `json:"failureCount" logs:"failureCode"`
 }
 
-func GetListOfInstancesFailureData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetListOfInstancesFailureData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource=="ec2.amazonaws.com"| filter  eventName=="RunInstances" and failureCode!=""| filter ispresent(responseElements) or ispresent(failureCode)| stats count() as failureCode by eventName,responseElements.instancesSet.items.0.instanceId,responseElements.instancesSet.items.0.instanceType,responseElements.instancesSet.items.0.placement.availabilityZone,errorMessage`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource=="ec2.amazonaws.com"| filter  eventName=="RunInstances" and failureCode!=""| filter ispresent(responseElements) or ispresent(failureCode)| stats count() as failureCode by eventName,responseElements.instancesSet.items.0.instanceId,responseElements.instancesSet.items.0.instanceType,responseElements.instancesSet.items.0.placement.availabilityZone,errorMessage`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQueryResults(results)
 
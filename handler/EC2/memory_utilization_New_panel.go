@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,59 +15,35 @@ var AwsxEc2MemoryUtilizationNewCmd = &cobra.Command{
 	Use:   "memory_utilization_panel",
 	Short: "get memory utilization metrics data",
 	Long:  `command to get memory utilization metrics data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetMemoryUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting memory utilization: ", err)
-				fmt.Println("null")
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetMemoryUtilizationNewPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetMemoryUtilizationNewPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
+	elementType := req.ElementType
 
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
-	currentUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "mem_used_percent", startTime, endTime, "SampleCount", "InstanceId", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("currentUsage", "AWS/"+elementType, "mem_used_percent", "SampleCount", comman_function.Dimension("InstanceId", instanceId))
+	metricQuery.AddMetric("maxUsage", "AWS/"+elementType, "mem_used_percent", "Maximum", comman_function.Dimension("InstanceId", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting sample count: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
+	currentUsage := metricData.Output("currentUsage")
 	if len(currentUsage.MetricDataResults) > 0 && len(currentUsage.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["CurrentUsage"] = currentUsage
 	} else {
@@ -83,11 +58,7 @@ func GetMemoryUtilizationNewPanel(cmd *cobra.Command, clientAuth *model.Auth, cl
 	// 	MetricDataResults: []*cloudwatch.MetricDataResult{{Values: []*float64{aws.Float64(0)}}},
 	// }
 
-	maxUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "mem_used_percent", startTime, endTime, "Maximum", "InstanceId", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting maximum: ", err)
-		return "", nil, err
-	}
+	maxUsage := metricData.Output("maxUsage")
 	if len(maxUsage.MetricDataResults) > 0 && len(maxUsage.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["MaxUsage"] = maxUsage
 	} else {
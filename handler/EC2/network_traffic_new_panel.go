@@ -6,13 +6,12 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -25,43 +24,12 @@ var AwsxEC2NetworkTrafficCmdAllinstances = &cobra.Command{
 	Use:   "network_traffic_new_panel",
 	Short: "Get network traffic metrics data",
 	Long:  `Command to get network traffic metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			networkTraffic, err := GetNetworkTrafficNewPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting network traffic data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println("This cli is for only json output")
-			} else {
-				jsonResp, err := json.Marshal(networkTraffic)
-				if err != nil {
-					log.Println("Error marshalling network traffic data: ", err)
-					return
-				}
-				fmt.Println(string(jsonResp))
-			}
-		}
-	},
 }
 
-func GetNetworkTrafficNewPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
+func GetNetworkTrafficNewPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, error) {
+	elementId := req.ElementId
+	elementType := req.ElementType
+	cmdbApiUrl := req.CmdbApiUrl
 
 	if elementId != "" {
 		log.Println("getting cloud-element data from cmdb")
@@ -73,44 +41,21 @@ func GetNetworkTrafficNewPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 		log.Println("cmdb url: " + apiUrl)
 	}
 
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-	var startTime, endTime *time.Time
-
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", fmt.Errorf("error parsing time: %w", err)
 	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
 
 	// Fetch raw data for inbound and outbound metrics separately
-	rawInboundData, err := GetAllNetworkMetricData(clientAuth, elementType, startTime, endTime, "NetworkIn", cloudWatchClient)
+	rawInboundData, err := GetAllNetworkMetricData(req.ClientAuth, elementType, startTime, endTime, "NetworkIn", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting network inbound data: ", err)
 		return "", err
 	}
 
-	rawOutboundData, err := GetAllNetworkMetricData(clientAuth, elementType, startTime, endTime, "NetworkOut", cloudWatchClient)
+	rawOutboundData, err := GetAllNetworkMetricData(req.ClientAuth, elementType, startTime, endTime, "NetworkOut", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting network outbound data: ", err)
 		return "", err
@@ -132,7 +77,7 @@ func GetNetworkTrafficNewPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	return string(jsondata), nil
 }
 
-func GetAllNetworkMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetAllNetworkMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for instance %s in namespace AWS/EC2 from %v to %v", elementType, startTime, endTime)
 
 	input := &cloudwatch.GetMetricDataInput{
@@ -154,7 +99,7 @@ func GetAllNetworkMetricData(clientAuth *model.Auth, elementType string, startTi
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
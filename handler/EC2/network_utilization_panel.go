@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -26,74 +25,46 @@ var AwsxEc2NetworkUtilizationCmd = &cobra.Command{
 	Use:   "network_utilization_panel",
 	Short: "get network utilization metrics data",
 	Long:  `command to get network utilization metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNetworkUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting network utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetNetworkUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetNetworkUtilizationPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Get Inbound Traffic
-	inboundTraffic, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/EC2", "NetworkIn", startTime, endTime, "Average", "InstanceId", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("inboundTraffic", "AWS/EC2", "NetworkIn", "Average", comman_function.Dimension("InstanceId", instanceId))
+	metricQuery.AddMetric("outboundTraffic", "AWS/EC2", "NetworkOut", "Average", comman_function.Dimension("InstanceId", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting inbound traffic: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
-	inboundTrafficMegabytes := *inboundTraffic.MetricDataResults[0].Values[0] / bytesToMegabytes
-	cloudwatchMetricData["InboundTraffic"] = createMetricDataOutput(inboundTrafficMegabytes)
+	inboundTraffic := metricData.Output("inboundTraffic")
+	inboundTrafficBytes, inboundOk := comman_function.FirstValue(inboundTraffic)
+	inboundTrafficMegabytes := inboundTrafficBytes / bytesToMegabytes
+	cloudwatchMetricData["InboundTraffic"] = createMetricDataOutput(inboundTrafficMegabytes, inboundOk)
 
 	// Get Outbound Traffic
-	outboundTraffic, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/EC2", "NetworkOut", startTime, endTime, "Average", "InstanceId", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting outbound traffic: ", err)
-		return "", nil, err
-	}
-	outboundTrafficMegabytes := *outboundTraffic.MetricDataResults[0].Values[0] / bytesToMegabytes
-	cloudwatchMetricData["OutboundTraffic"] = createMetricDataOutput(outboundTrafficMegabytes)
+	outboundTraffic := metricData.Output("outboundTraffic")
+	outboundTrafficBytes, outboundOk := comman_function.FirstValue(outboundTraffic)
+	outboundTrafficMegabytes := outboundTrafficBytes / bytesToMegabytes
+	cloudwatchMetricData["OutboundTraffic"] = createMetricDataOutput(outboundTrafficMegabytes, outboundOk)
 
 	// Calculate Data Transferred (sum of inbound and outbound)
 	dataTransferred := inboundTrafficMegabytes + outboundTrafficMegabytes
-	cloudwatchMetricData["DataTransferred"] = createMetricDataOutput(dataTransferred)
+	cloudwatchMetricData["DataTransferred"] = createMetricDataOutput(dataTransferred, inboundOk || outboundOk)
 
 	jsonOutput := NetworkResult{
 		InboundTraffic:  inboundTrafficMegabytes,
@@ -110,7 +81,12 @@ func GetNetworkUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, clou
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func createMetricDataOutput(value float64) *cloudwatch.GetMetricDataOutput {
+// createMetricDataOutput returns a series of value, without datapoints when
+// ok is false.
+func createMetricDataOutput(value float64, ok bool) *cloudwatch.GetMetricDataOutput {
+	if !ok {
+		return &cloudwatch.GetMetricDataOutput{MetricDataResults: []*cloudwatch.MetricDataResult{{}}}
+	}
 	return &cloudwatch.GetMetricDataOutput{
 		MetricDataResults: []*cloudwatch.MetricDataResult{
 			{
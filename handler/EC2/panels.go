@@ -0,0 +1,554 @@
+package EC2
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var ec2ElementTypes = []string{"EC2", "AWS/EC2"}
+
+func init() {
+	comman_function.RegisterIdentifier(ec2ElementTypes, comman_function.IdentifierInstanceId)
+	comman_function.RegisterPanels(ec2ElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "cpu_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxEc2CpuUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetCpuUtilizationPanel),
+		},
+		{
+			Name:          "instance_start_count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetInstanceStartCountPanel, InstanceStartCountRow{}),
+		},
+		{
+			// the name the start count panel was queried by before it had its
+			// own, kept for the dashboards that still ask for it
+			Name:          "instance_stop_count_panel_test",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetInstanceStartCountPanel, InstanceStartCountRow{}),
+		},
+		{
+			Name:          "instance_stop_count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2InstanceStopCmd,
+			Panel:         comman_function.TypedLogsPanel(GetInstanceStopCountPanel, InstanceStopCountRow{}),
+		},
+		{
+			Name:          "instance_hours_stopped_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetInstanceStoppedCountPanel, InstanceStoppedCountRow{}),
+		},
+		{
+			Name:          "instance_running_hour_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetInstanceRunningHour, InstanceRunningHourRow{}),
+		},
+		{
+			Name:          "error_rate_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2ErrorRatePanelCmd,
+			Panel:         comman_function.TypedLogsPanel(GetInstanceErrorRatePanel, InstanceErrorRateRow{}),
+		},
+		{
+			Name:          "custom_alert_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxEc2CustomAlertPanelCmd,
+			Panel:         comman_function.TypedLogsPanel(GetEc2CustomAlertPanel, CustomAlertRow{}),
+		},
+		{
+			Name:          "hosted_services_overview_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Command:       AwsxEc2hostedServicesCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				hostedServicesOverview, err := GetHostedServicesData(req)
+				return &comman_function.PanelResult{Json: hostedServicesOverview}, err
+			}),
+		},
+		{
+			Name:          "instance_status_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Command:       AwsxEc2InstanceStatusCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				instanceStatus, err := GetInstanceStatus(req)
+				return &comman_function.PanelResult{Json: instanceStatus}, err
+			}),
+		},
+		{
+			Name:          "error_tracking_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxEc2ErrorTrackingPanelCmd,
+			Panel:         comman_function.TypedLogsPanel(GetErrorTrackingPanel, ErrorTrackingRow{}),
+		},
+		{
+			Name:          "memory_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxEc2MemoryUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryUtilizationPanel),
+		},
+		{
+			Name:          "total_cpu_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Panel:         comman_function.MetricPanel(GetCpuUtilizationAcrossAllInstancesPanel),
+		},
+		{
+			Name:          "total_network_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetNetworkUtilizationAcrossAllInstancesPanel),
+		},
+		{
+			Name:          "total_memory_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Panel:         comman_function.MetricPanel(GetMemoryUtilizationForAllInstancesPanel),
+		},
+		{
+			Name:          "instance_availalbility_zones_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetInstanceAvailabilityZonesData(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "instance_availability_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := InstanceAvailability(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "instance_connectivity_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetConnectivityData(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "auto_scaling_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetAutoScalingGroupsDetails(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "disk_io_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxEC2DiskIOPerformanceCmd,
+			Panel:         comman_function.MetricPanel(GetEC2DiskIOPerformancePanel),
+		},
+		{
+			Name:          "network_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "MBs",
+			Command:       AwsxEc2NetworkUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkUtilizationPanel),
+		},
+		{
+			Name:          "latency_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "bytes",
+			Command:       AwsxEc2LatencyCmd,
+			Panel:         comman_function.MetricPanel(GetLatencyPanel),
+		},
+		{
+			Name:          "cpu_utilization_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2CpuUtilizationGraphsCmd,
+			Panel:         comman_function.MetricPanel(GetCpuUtilizationGraphPanel),
+		},
+		{
+			Name:          "memory_utilization_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2MemoryUtilizationGraphCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryUtilizationGraphPanel),
+		},
+		{
+			Name:          "active_instances_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				instanceCount, err := GetEC2ActiveInstanceCount(req.ClientAuth)
+				return &comman_function.PanelResult{Json: instanceCount}, err
+			}),
+		},
+		{
+			Name:          "inactive_instances_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.TypedLogsPanel(GetInactiveInstancesCountPanel, InactiveInstancesCountRow{}),
+		},
+		{
+			Name:          "ec2_instance_summary_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetEC2InstanceSummaryPanel(req.ClientAuth)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "latest_successful_events_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetEC2InstanceSummaryPanel(req.ClientAuth)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "instance_terminated_count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetInstanceTerminatedCountPanel, InstanceTerminatedRow{}),
+		},
+		{
+			Name:          "cpu_usage_user_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2CpuUsageUserCmd,
+			Panel:         comman_function.MetricPanel(GetCPUUsageUserPanel),
+		},
+		{
+			Name:          "cpu_usage_sys_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2CpuSysTimeCmd,
+			Panel:         comman_function.MetricPanel(GetCPUUsageSysPanel),
+		},
+		{
+			Name:          "cpu_usage_nice_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2CpuUsageNiceCmd,
+			Panel:         comman_function.MetricPanel(GetCPUUsageNicePanel),
+		},
+		{
+			Name:          "cpu_usage_idle_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2CpuUsageIdleCmd,
+			Panel:         comman_function.MetricPanel(GetCPUUsageIdlePanel),
+		},
+		{
+			Name:          "mem_usage_free_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2MemoryUsageFreeCmd,
+			Panel:         comman_function.MetricPanel(GetMemUsageFreePanel),
+		},
+		{
+			Name:          "mem_cached_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2MemCachedCmd,
+			Panel:         comman_function.MetricPanel(GetMemCachePanel),
+		},
+		{
+			Name:          "mem_usage_total_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2MemoryUsageTotalCmd,
+			Panel:         comman_function.MetricPanel(GetMemUsageTotal),
+		},
+		{
+			Name:          "mem_usage_used_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2MemoryUsageUsedCmd,
+			Panel:         comman_function.MetricPanel(GetMemUsageUsed),
+		},
+		{
+			Name:          "disk_writes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxEc2DiskWriteCmd,
+			Panel:         comman_function.MetricPanel(GetDiskWritePanel),
+		},
+		{
+			Name:          "disk_reads_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxEc2DiskReadCmd,
+			Panel:         comman_function.MetricPanel(GetDiskReadPanel),
+		},
+		{
+			Name:          "disk_available_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxEc2DiskAvailableCmd,
+			Panel:         comman_function.MetricPanel(GetDiskAvailablePanel),
+		},
+		{
+			Name:          "disk_used_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxEc2DiskUsedCmd,
+			Panel:         comman_function.MetricPanel(GetDiskUsedPanel),
+		},
+		{
+			Name:          "net_inpackets_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2NetworkInPacketsCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkInPacketsPanel),
+		},
+		{
+			Name:          "net_inbytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2NetworkInBytesCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkInBytesPanel),
+		},
+		{
+			Name:          "net_outbytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2NetworkOutBytesCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkOutBytesPanel),
+		},
+		{
+			Name:          "net_outpackets_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2NetworkOutPacketsCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkOutPacketsPanel),
+		},
+		{
+			Name:          "net_throughput_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetNetworkThroughputPanel),
+		},
+		{
+			Name:          "instance_health_check_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Command:       AwsxEc2InstanceHealthCheckCmd,
+			Panel:         comman_function.TypedLogsPanel(GetEc2InstanceHealthCheckData, InstanceHealthCheckRow{}),
+		},
+		{
+			Name:          "network_inbound_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2NetworkInboundCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkInBoundPanel),
+		},
+		{
+			Name:          "network_traffic_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEC2NetworkTrafficCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				inboundResp, _, cloudwatchMetricResp, err := GetNetworkTrafficPanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: inboundResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "network_outbound_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEc2NetworkOutboundCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkOutBoundPanel),
+		},
+		{
+			Name:          "alert_and_notification_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxEc2AlarmandNotificationcmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, err := GetAlertsAndNotificationsPanel(req)
+				return &comman_function.PanelResult{Json: jsonResp}, err
+			}),
+		},
+		{
+			Name:          "list_of_ec2_instances_failure_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetListOfInstancesFailureData, InstanceFailureRow{}),
+		},
+		{
+			Name:          "ec2_instance_events_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetEc2InstanceEventsData, InstanceEventRow{}),
+		},
+		{
+			Name:          "Instance_Failure_Count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetInstanceFailureCountPanel, InstanceFailureCountRow{}),
+		},
+		{
+			Name:          "disk_space_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Panel:         comman_function.MetricPanel(GetDiskUtilizationData),
+		},
+		{
+			Name:          "instance_count_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				instanceCounts, err := GetInstanceCountPanel(req, nil)
+				return &comman_function.PanelResult{Json: instanceCounts}, err
+			}),
+		},
+		{
+			Name:          "cpu_reservation_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetEC2CPUReservationData),
+		},
+		{
+			Name:          "instance_health_check_new",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, err := GetInstanceHealthCheckNew(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp}, err
+			}),
+		},
+		{
+			Name:          "network_latency",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetNetworkLatencyAcrossAllInstancesPanel),
+		},
+		{
+			Name:          "auto_scaling_config_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, _, err := GetAutoScalingInfo(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp}, err
+			}),
+		},
+		{
+			Name:          "instance_backup_status_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, err := GetBackupStatus(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp}, err
+			}),
+		},
+		{
+			Name:          "network_traffic_new_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "decmbytes",
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, err := GetNetworkTrafficNewPanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp}, err
+			}),
+		},
+		{
+			Name:          "memory_utilization_New_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Panel:         comman_function.MetricPanel(GetMemoryUtilizationNewPanel),
+		},
+		{
+			Name:          "storage_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Panel:         comman_function.MetricPanel(GetStorageUtilizationPanel),
+		},
+		{
+			Name:          "cpu_utilization_per_type",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := CpuUtilizationPerInstanceType(req, nil, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "disk_read_bytes_per_type",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := DiskReadBytesData(req, nil, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "disk_write_bytes_per_type",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := DiskWriteBytesData(req, nil, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "disk_read_ops_per_type",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := DiskReadOpsPerInstanceType(req, nil, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "disk_write_ops_per_type",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := DiskWriteOpsPerInstanceType(req, nil, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "network_in_per_type",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := NetworkInPerInstanceType(req, nil, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "network_out_per_type",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := NetworkOutPerInstanceType(req, nil, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+	})
+}
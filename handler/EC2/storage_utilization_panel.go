@@ -6,10 +6,9 @@ import (
 	"log"
 	"strconv"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -23,49 +22,19 @@ var AwsxEc2StorageUtilizationCmd = &cobra.Command{
 	Use:   "Storage_utilization_panel",
 	Short: "get storage utilization metrics data",
 	Long:  `command to get storage utilization metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetStorageUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting cpu utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetStorageUtilizationPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 	
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	instanceId := req.InstanceId
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	
 		if err != nil {
-			return "", nil, fmt.Errorf("error parsing time: %v", err)
+			return "", nil, fmt.Errorf("error parsing time: %w", err)
 		}
-		instanceId, err = comman_function.GetCmdbData(cmd)
+		instanceId, err = comman_function.GetCmdbData(req)
 		if err != nil {
-			return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+			return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 		}
 	
 
@@ -76,27 +45,24 @@ func GetStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, clou
 	
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 	// Get Root Volume Utilization
-	rootVolumeUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/EC2","disk_used_percent", startTime, endTime, "Average", "InstanceId",cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("rootVolumeUsage", "AWS/EC2", "disk_used_percent", "Average", comman_function.Dimension("InstanceId", instanceId))
+	metricQuery.AddMetric("ebs1VolumeUsage", "AWS/EC2", "disk_used_percent", "Average", comman_function.Dimension("InstanceId", instanceId))
+	metricQuery.AddMetric("ebs2VolumeUsage", "AWS/EC2", "disk_used_percent", "Average", comman_function.Dimension("InstanceId", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting Root Volume Utilization: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
+	rootVolumeUsage := metricData.Output("rootVolumeUsage")
 	cloudwatchMetricData["RootVolumeUtilization"] = rootVolumeUsage
 
 	// Get EBS1 Volume Utilization
-	ebs1VolumeUsage, err := comman_function.GetMetricData(clientAuth, instanceId,"AWS/EC2" ,"disk_used_percent", startTime, endTime, "Average",  "InstanceId", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting EBS1 Volume Utilization: ", err)
-		return "", nil, err
-	}
+	ebs1VolumeUsage := metricData.Output("ebs1VolumeUsage")
 	cloudwatchMetricData["EBS1VolumeUtilization"] = ebs1VolumeUsage
 
 	// Get EBS2 Volume Utilization
-	ebs2VolumeUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/EC2" , "disk_used_percent", startTime, endTime, "Average","InstanceId" , cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting EBS2 Volume Utilization: ", err)
-		return "", nil, err
-	}
+	ebs2VolumeUsage := metricData.Output("ebs2VolumeUsage")
 	cloudwatchMetricData["EBS2VolumeUtilization"] = ebs2VolumeUsage
 
 	// Calculate average of all three volumes
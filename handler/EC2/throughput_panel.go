@@ -6,13 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/cmdb"
-	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -27,83 +25,19 @@ var AwsxEc2NetworkThroughputCmd = &cobra.Command{
 	Use:   "network_throughput_panel",
 	Short: "get network throughput metrics data",
 	Long:  `command to get network throughput metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNetworkThroughputPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting network throughput data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetNetworkThroughputPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-
-	if elementId != "" {
-		log.Println("getting cloud-element data from cmdb")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("using default cmdb url")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("cmdb url: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", nil, err
-		}
-		instanceId = cmdbData.InstanceId
-	}
-
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
+func GetNetworkThroughputPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
 
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
+	instanceId, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", nil, err
 	}
 
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
@@ -111,7 +45,7 @@ func GetNetworkThroughputPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data for NetworkIn
-	rawDataIn, err := GetNetworkThroughputMetricData(clientAuth, instanceId, elementType, startTime, endTime, "Sum", "NetworkIn", cloudWatchClient)
+	rawDataIn, err := GetNetworkThroughputMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, "Sum", "NetworkIn", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting raw data for NetworkIn: ", err)
 		return "", nil, err
@@ -119,7 +53,7 @@ func GetNetworkThroughputPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	cloudwatchMetricData["NetworkThroughputData"] = rawDataIn
 
 	// Fetch raw data for NetworkOut
-	rawDataOut, err := GetNetworkThroughputMetricData(clientAuth, instanceId, elementType, startTime, endTime, "Sum", "NetworkOut", cloudWatchClient)
+	rawDataOut, err := GetNetworkThroughputMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, "Sum", "NetworkOut", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting raw data for NetworkOut: ", err)
 		return "", nil, err
@@ -140,7 +74,7 @@ func GetNetworkThroughputPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetNetworkThroughputMetricData(clientAuth *model.Auth, instanceID, elementType string, startTime, endTime *time.Time, statistic, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetNetworkThroughputMetricData(clientAuth *model.Auth, instanceID, elementType string, startTime, endTime *time.Time, statistic, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for instance %s in namespace %s from %v to %v", instanceID, elementType, startTime, endTime)
 
 	elmType := "AWS/EC2"
@@ -169,7 +103,7 @@ func GetNetworkThroughputMetricData(clientAuth *model.Auth, instanceID, elementT
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -189,14 +123,22 @@ func combineNetworkThroughputRawData(rawDataIn, rawDataOut *cloudwatch.GetMetric
 		Value     float64
 	})
 
+	if len(rawDataIn.MetricDataResults) == 0 || len(rawDataOut.MetricDataResults) == 0 {
+		return combinedRawData
+	}
+	in, out := rawDataIn.MetricDataResults[0], rawDataOut.MetricDataResults[0]
+
 	// Combine the timestamps and values for NetworkIn and NetworkOut
-	for i, timestamp := range rawDataIn.MetricDataResults[0].Timestamps {
+	for i, timestamp := range in.Timestamps {
+		if i >= len(in.Values) || i >= len(out.Values) {
+			break
+		}
 		combinedRawData["RawData"] = append(combinedRawData["RawData"], struct {
 			Timestamp time.Time
 			Value     float64
 		}{
 			Timestamp: *timestamp,
-			Value:     *rawDataIn.MetricDataResults[0].Values[i] + *rawDataOut.MetricDataResults[0].Values[i],
+			Value:     *in.Values[i] + *out.Values[i],
 		})
 	}
 
@@ -5,13 +5,11 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/spf13/cobra"
 )
 
@@ -19,58 +17,23 @@ var AwsxEc2MemoryUtilizationForAllInstancesCmd = &cobra.Command{
 	Use:   "memory_utilization_panel",
 	Short: "get memory utilization metrics data",
 	Long:  `command to get memory utilization metrics data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetMemoryUtilizationForAllInstancesPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting memory utilization: ", err)
-				fmt.Println("null")
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetMemoryUtilizationForAllInstancesPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetMemoryUtilizationForAllInstancesPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 	// elementType, _ := cmd.PersistentFlags().GetString("elementType")
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
-	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
-	}
-
-	sess, err := session.NewSession(&aws.Config{
-		Region: aws.String(clientAuth.Region),
-	})
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error creating AWS session: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	ec2Svc := ec2.New(sess)
+	ec2Svc := comman_function.EC2Client(*req.ClientAuth)
 
 	// Get the list of instances
 	instances, err := getAllInstances(ec2Svc)
-	fmt.Println("instances", instances)
+	log.Println("instances", instances)
 	if err != nil {
-		return "", nil, fmt.Errorf("error listing instances: %v", err)
+		return "", nil, fmt.Errorf("error listing instances: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
@@ -78,7 +41,7 @@ func GetMemoryUtilizationForAllInstancesPanel(cmd *cobra.Command, clientAuth *mo
 
 	for _, instanceId := range instances {
 		// Get average utilization
-		averageUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "CWAgent", "disk_used_percent", startTime, endTime, "Average", "InstanceId", cloudWatchClient)
+		averageUsage, err := comman_function.GetMetricData(req, instanceId, "CWAgent", "disk_used_percent", startTime, endTime, "Average", "InstanceId", cloudWatchClient)
 		if err != nil {
 			log.Printf("Error in getting average for instance %s: %v\n", instanceId, err)
 			continue
@@ -100,7 +63,7 @@ func GetMemoryUtilizationForAllInstancesPanel(cmd *cobra.Command, clientAuth *mo
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func getAllInstances(svc *ec2.EC2) ([]string, error) {
+func getAllInstances(svc ec2iface.EC2API) ([]string, error) {
 	input := &ec2.DescribeInstancesInput{}
 	instanceIds := []string{}
 
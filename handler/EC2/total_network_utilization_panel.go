@@ -6,12 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -19,47 +18,20 @@ var AwsxEc2NetworkUtilizationAcrossAllInstanceCmd = &cobra.Command{
 	Use:   "total_network_utilization_panel",
 	Short: "get network utilization metrics data for all instances",
 	Long:  `command to get network utilization metrics data for all instances`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNetworkUtilizationAcrossAllInstancesPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting network utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetNetworkUtilizationAcrossAllInstancesPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
+func GetNetworkUtilizationAcrossAllInstancesPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Get Inbound Traffic
-	inboundTraffic, err := GetTotalNetworkUtilizationData(clientAuth, elementType, startTime, endTime, "Average", "NetworkIn", cloudWatchClient)
+	inboundTraffic, err := GetTotalNetworkUtilizationData(req.ClientAuth, elementType, startTime, endTime, "Average", "NetworkIn", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting inbound traffic: ", err)
 		return "", nil, err
@@ -69,7 +41,7 @@ func GetNetworkUtilizationAcrossAllInstancesPanel(cmd *cobra.Command, clientAuth
 	}
 
 	// Get Outbound Traffic
-	outboundTraffic, err := GetTotalNetworkUtilizationData(clientAuth, elementType, startTime, endTime, "Average", "NetworkOut", cloudWatchClient)
+	outboundTraffic, err := GetTotalNetworkUtilizationData(req.ClientAuth, elementType, startTime, endTime, "Average", "NetworkOut", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting outbound traffic: ", err)
 		return "", nil, err
@@ -99,7 +71,7 @@ func GetNetworkUtilizationAcrossAllInstancesPanel(cmd *cobra.Command, clientAuth
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetTotalNetworkUtilizationData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, statistic string, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetTotalNetworkUtilizationData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, statistic string, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data in namespace %s from %v to %v", elementType, startTime, endTime)
 	elmType := "AWS/EC2"
 	if elementType == "EC2" {
@@ -123,7 +95,7 @@ func GetTotalNetworkUtilizationData(clientAuth *model.Auth, elementType string,
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,55 +15,33 @@ var AwsxEcsCpuUtilizationCmd = &cobra.Command{
 	Use:   "cpu_utilization_panel",
 	Short: "get cpu utilization metrics data",
 	Long:  `command to get cpu utilization metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetECScpuUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting cpu utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetECScpuUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetECScpuUtilizationPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
-	currentUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "CPUUtilization", startTime, endTime, "SampleCount", "ClusterName", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("currentUsage", "AWS/"+elementType, "CPUUtilization", "SampleCount", comman_function.Dimension("ClusterName", instanceId))
+	metricQuery.AddMetric("averageUsage", "AWS/"+elementType, "CPUUtilization", "Average", comman_function.Dimension("ClusterName", instanceId))
+	metricQuery.AddMetric("maxUsage", "AWS/"+elementType, "CPUUtilization", "Maximum", comman_function.Dimension("ClusterName", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting sample count: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
+	currentUsage := metricData.Output("currentUsage")
 
 	if len(currentUsage.MetricDataResults) > 0 && len(currentUsage.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["CurrentUsage"] = currentUsage
@@ -73,11 +50,7 @@ func GetECScpuUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	}
 
 	// Get average usage
-	averageUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "CPUUtilization", startTime, endTime, "Average", "ClusterName", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting average: ", err)
-		return "", nil, err
-	}
+	averageUsage := metricData.Output("averageUsage")
 
 	if len(averageUsage.MetricDataResults) > 0 && len(averageUsage.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["AverageUsage"] = averageUsage
@@ -86,11 +59,7 @@ func GetECScpuUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	}
 
 	// Get max usage
-	maxUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "CPUUtilization", startTime, endTime, "Maximum", "ClusterName", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting maximum: ", err)
-		return "", nil, err
-	}
+	maxUsage := metricData.Output("maxUsage")
 	if len(maxUsage.MetricDataResults) > 0 && len(maxUsage.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["MaxUsage"] = maxUsage
 	} else {
@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,59 +15,36 @@ var AwsxEcsMemoryUtilizationCmd = &cobra.Command{
 	Use:   "memory_utilization_panel",
 	Short: "get memory utilization metrics data",
 	Long:  `command to get memory utilization metrics data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetMemoryUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting memory utilization: ", err)
-				fmt.Println("null")
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetMemoryUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetMemoryUtilizationPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
+	elementType := req.ElementType
 
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
-	currentUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "MemoryUtilization", startTime, endTime, "SampleCount", "ClusterName", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("currentUsage", "AWS/"+elementType, "MemoryUtilization", "SampleCount", comman_function.Dimension("ClusterName", instanceId))
+	metricQuery.AddMetric("averageUsage", "AWS/"+elementType, "MemoryUtilization", "Average", comman_function.Dimension("ClusterName", instanceId))
+	metricQuery.AddMetric("maxUsage", "AWS/"+elementType, "MemoryUtilization", "Maximum", comman_function.Dimension("ClusterName", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting sample count: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
+	currentUsage := metricData.Output("currentUsage")
 	if len(currentUsage.MetricDataResults) > 0 && len(currentUsage.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["CurrentUsage"] = currentUsage
 	} else {
@@ -80,11 +56,7 @@ func GetMemoryUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	// }
 
 	// Get average utilization
-	averageUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "MemoryUtilization", startTime, endTime, "Average", "ClusterName", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting average: ", err)
-		return "", nil, err
-	}
+	averageUsage := metricData.Output("averageUsage")
 	if len(averageUsage.MetricDataResults) > 0 && len(averageUsage.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["AverageUsage"] = averageUsage
 	} else {
@@ -94,11 +66,7 @@ func GetMemoryUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	// 	MetricDataResults: []*cloudwatch.MetricDataResult{{Values: []*float64{aws.Float64(0)}}},
 	// }
 
-	maxUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "MemoryUtilization", startTime, endTime, "Maximum", "ClusterName", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting maximum: ", err)
-		return "", nil, err
-	}
+	maxUsage := metricData.Output("maxUsage")
 	if len(maxUsage.MetricDataResults) > 0 && len(maxUsage.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["MaxUsage"] = maxUsage
 	} else {
@@ -0,0 +1,219 @@
+package ECS
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var ecsElementTypes = []string{"ECS", "AWS/ECS"}
+
+func init() {
+	comman_function.RegisterIdentifier(ecsElementTypes, comman_function.IdentifierClusterName)
+	comman_function.RegisterPanels(ecsElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "cpu_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxEcsCpuUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetECScpuUtilizationPanel),
+		},
+		{
+			Name:          "memory_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxEcsMemoryUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryUtilizationPanel),
+		},
+		{
+			Name:          "cpu_graph_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEcsCpuUtilizationGraphsCmd,
+			Panel:         comman_function.MetricPanel(GetCpuUtilizationGraphPanel),
+		},
+		{
+			Name:          "memory_utilization_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEcsMemoryUtilizationGraphCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryUtilizationGraphPanel),
+		},
+		{
+			Name:          "Network_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "Bps",
+			Panel:         comman_function.MetricPanel(GetNetworkUtilizationPanel),
+		},
+		{
+			Name:          "storage_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxECSStorageUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetStorageUtilizationPanel),
+		},
+		{
+			Name:          "cpu_reservation_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxCpuReservedCmd,
+			Panel:         comman_function.MetricPanel(GetCPUReservationData),
+		},
+		{
+			Name:          "memory_reservation_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxMemoryReservedCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryReservationData),
+		},
+		{
+			Name:          "net_rxinbytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxECSNetworkRxInBytesCmd,
+			Panel:         comman_function.MetricPanel(GetECSNetworkRxInBytesPanel),
+		},
+		{
+			Name:          "net_txinbytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxECSNetworkTxInBytesCmd,
+			Panel:         comman_function.MetricPanel(GetECSNetworkTxInBytesPanel),
+		},
+		{
+			Name:          "volume_read_bytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxECSReadBytesCmd,
+			Panel:         comman_function.MetricPanel(GetECSReadBytesPanel),
+		},
+		{
+			Name:          "volume_write_bytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxECSWriteBytesCmd,
+			Panel:         comman_function.MetricPanel(GetECSWriteBytesPanel),
+		},
+		{
+			Name:          "available_memory_over_time_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetAvailableMemoryOverTimeData),
+		},
+		{
+			Name:          "top_events_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel:         comman_function.TypedLogsPanel(GetECSTopEventsData, TopEventRow{}),
+		},
+		{
+			Name:          "registration_events_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetRegistrationEventsData, RegistrationEventRow{}),
+		},
+		{
+			Name:          "deregistration_events_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetDeRegistrationEventsData, DeregistrationEventRow{}),
+		},
+		{
+			Name:          "resource_deleted_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Command:       AwsxResourceDeletedPanelCmd,
+			Panel:         comman_function.TypedLogsPanel(GetECSResourceDeletedEvents, ResourceEventCountRow{}),
+		},
+		{
+			Name:          "resources_created_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Command:       AwsxResourceCreatedPanelCmd,
+			Panel:         comman_function.TypedLogsPanel(GetECSResourceCreatedEvents, ResourceEventCountRow{}),
+		},
+		{
+			Name:          "failed_tasks_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetECSFailedTasksEvents, FailedTaskRow{}),
+		},
+		{
+			Name:          "failed_services_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetECSFailedServiceEvents, FailedServiceRow{}),
+		},
+		{
+			Name:          "active_services_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetECSActiveServiceEvents, ActiveServiceRow{}),
+		},
+		{
+			Name:          "active_connection_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetECSActiveConnectionEvents, ActiveConnectionRow{}),
+		},
+		{
+			Name:          "new_connection_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetECSNewConnectionEvents, NewConnectionRow{}),
+		},
+		{
+			Name:          "active_tasks_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetECSActiveTaskEvents, ActiveTaskRow{}),
+		},
+		{
+			Name:          "resource_updated_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Command:       AwsxResourceUpdatedPanelCmd,
+			Panel:         comman_function.TypedLogsPanel(GetECSResourceUpdatedEvents, ResourceEventCountRow{}),
+		},
+		{
+			Name:          "container_net_received_inbytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetECSContainerNetRxInBytesPanel),
+		},
+		{
+			Name:          "container_net_transmit_inbytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetECSContainerNetTxInBytesPanel),
+		},
+		{
+			Name:          "container_memory_usage_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetContainerMemoryUsageData),
+		},
+		{
+			Name:          "uptime_percentage_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxECSUptimeCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetECSUptimeData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "service_error_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxEcsServiceErrorCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				serviceErrors, err := ListServiceErrors()
+				return &comman_function.PanelResult{Json: serviceErrors}, err
+			}),
+		},
+	})
+}
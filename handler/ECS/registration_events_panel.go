@@ -3,11 +3,11 @@ package ECS
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,48 +16,32 @@ var AwsxECSRegistrationEventsCmd = &cobra.Command{
 	Use:   "registration_events_panel",
 	Short: "Get registration events logs data",
 	Long:  `Command to get registration events logs data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running registration events panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetRegistrationEventsData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// RegistrationEventRow is one row of the registration_events_panel query.
+type RegistrationEventRow struct {
+	EventTime     time.Time `json:"eventTime" logs:"eventTime"`
+	Region        string    `json:"region" logs:"awsRegion"`
+	Cluster       string    `json:"cluster" logs:"requestParameters.cluster"`
+	ResourceName  string    `json:"resourceName" logs:"requestParameters.totalResources.0.name"`
+	Ec2InstanceId string    `json:"ec2InstanceId" logs:"responseElements.containerInstance.ec2InstanceId"`
 }
 
-func GetRegistrationEventsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
+func GetRegistrationEventsData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message, @logStream, @log| filter eventSource = "ecs.amazonaws.com"| filter eventName = "RegisterContainerInstance"| display eventTime,awsRegion,requestParameters.cluster,requestParameters.totalResources.0.name,responseElements.containerInstance.ec2InstanceId| sort @timestamp desc| limit 10`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message, @logStream, @log| filter eventSource = "ecs.amazonaws.com"| filter eventName = "RegisterContainerInstance"| display eventTime,awsRegion,requestParameters.cluster,requestParameters.totalResources.0.name,responseElements.containerInstance.ec2InstanceId| sort @timestamp desc| limit 10`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQueryResult(results)
 
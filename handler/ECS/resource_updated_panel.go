@@ -2,12 +2,10 @@ package ECS
 
 import (
 	"fmt"
-	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,48 +13,20 @@ var AwsxResourceUpdatedPanelCmd = &cobra.Command{
 	Use:   "resource_updated_panel",
 	Short: "Get ECS resource update events",
 	Long:  `Command to retrieve ECS resource update events`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running ECS resource updated panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			updatedEvents, err := GetECSResourceUpdatedEvents(cmd, clientAuth, nil)
-			if err != nil {
-				log.Fatalf("Error retrieving ECS resource update events: %v", err)
-				return
-			}
-			for _, event := range updatedEvents {
-				fmt.Println(event)
-			}
-		}
-	},
 }
 
-func GetECSResourceUpdatedEvents(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetECSResourceUpdatedEvents(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	updatedEvents, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventName| filter eventSource = "ecs.amazonaws.com" and (eventName = "UpdateCluster" or eventName = "UpdateContainerInstance" or eventName = "UpdateService" or eventName = "UpdateTaskSet" or eventName = "RegisterTaskDefinition")| stats count(*) as EventCount by eventName`, cloudWatchLogs)
+	updatedEvents, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventName| filter eventSource = "ecs.amazonaws.com" and (eventName = "UpdateCluster" or eventName = "UpdateContainerInstance" or eventName = "UpdateService" or eventName = "UpdateTaskSet" or eventName = "RegisterTaskDefinition")| stats count(*) as EventCount by eventName`, cloudWatchLogs)
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +41,7 @@ func GetECSResourceUpdatedEvents(cmd *cobra.Command, clientAuth *model.Auth, clo
 // 	if startTimeStr != "" {
 // 		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
 // 		if err != nil {
-// 			return nil, nil, fmt.Errorf("error parsing start time: %v", err)
+// 			return nil, nil, fmt.Errorf("error parsing start time: %w", err)
 // 		}
 // 		startTime = &parsedStartTime
 // 	}
@@ -80,7 +50,7 @@ func GetECSResourceUpdatedEvents(cmd *cobra.Command, clientAuth *model.Auth, clo
 // 	if endTimeStr != "" {
 // 		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
 // 		if err != nil {
-// 			return nil, nil, fmt.Errorf("error parsing end time: %v", err)
+// 			return nil, nil, fmt.Errorf("error parsing end time: %w", err)
 // 		}
 // 		endTime = &parsedEndTime
 // 	}
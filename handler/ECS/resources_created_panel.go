@@ -2,12 +2,10 @@ package ECS
 
 import (
 	"fmt"
-	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,48 +13,20 @@ var AwsxResourceCreatedPanelCmd = &cobra.Command{
 	Use:   "resource_created_panel",
 	Short: "Get ECS resource creation events",
 	Long:  `Command to retrieve ECS resource creation events`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running ECS resource created panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			createdEvents, err := GetECSResourceCreatedEvents(cmd, clientAuth, nil)
-			if err != nil {
-				log.Fatalf("Error retrieving ECS resource creation events: %v", err)
-				return
-			}
-			for _, event := range createdEvents {
-				fmt.Println(event)
-			}
-		}
-	},
 }
 
-func GetECSResourceCreatedEvents(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetECSResourceCreatedEvents(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	createdEvents, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventName| filter eventSource = "ecs.amazonaws.com" and (eventName = "CreateCluster" or eventName = "RegisterContainerInstance" or eventName = "CreateService" or eventName = "RegisterTaskDefinition" or eventName = "CreateTask" or eventName = "RunTask")| stats count(*) as EventCount by eventName`, cloudWatchLogs)
+	createdEvents, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventName| filter eventSource = "ecs.amazonaws.com" and (eventName = "CreateCluster" or eventName = "RegisterContainerInstance" or eventName = "CreateService" or eventName = "RegisterTaskDefinition" or eventName = "CreateTask" or eventName = "RunTask")| stats count(*) as EventCount by eventName`, cloudWatchLogs)
 	if err != nil {
 		return nil, err
 	}
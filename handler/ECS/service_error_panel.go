@@ -19,12 +19,6 @@ type ServiceError struct {
 var AwsxEcsServiceErrorCmd = &cobra.Command{
 	Use:   "AwsxEcsServiceError",
 	Short: "List AWS ECS service errors",
-	Run: func(cmd *cobra.Command, args []string) {
-		_, err := ListServiceErrors()
-		if err != nil {
-			return
-		}
-	},
 }
 
 func ListServiceErrors() ([]ServiceError, error) {
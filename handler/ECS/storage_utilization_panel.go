@@ -6,13 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/cmdb"
-	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -26,98 +24,25 @@ var AwsxECSStorageUtilizationCmd = &cobra.Command{
 	Use:   "storage_utilization_panel",
 	Short: "get storage utilization metrics data",
 	Long:  `command to get storage utilization metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetStorageUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting storage utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	if elementId != "" {
-		log.Println("getting cloud-element data from cmdb")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("using default cmdb url")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("cmdb url: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", nil, err
-		}
-		instanceId = cmdbData.InstanceId
-
-	}
+func GetStorageUtilizationPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
 
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			err := cmd.Help()
-			if err != nil {
-				return "", nil, err
-			}
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
+	instanceId, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", nil, err
 	}
 
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			err := cmd.Help()
-			if err != nil {
-				return "", nil, err
-			}
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Get Root Volume Usage
-	rootVolumeUsage, err := GetStorageMetricData(clientAuth, instanceId, elementType, startTime, endTime, "EphemeralStorageUtilized", cloudWatchClient)
+	rootVolumeUsage, err := GetStorageMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, "EphemeralStorageUtilized", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting root volume usage: ", err)
 		return "", nil, err
@@ -125,7 +50,7 @@ func GetStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, clou
 	cloudwatchMetricData["RootVolumeUsage"] = rootVolumeUsage
 
 	// Get EBS Volume 1 Usage
-	ebsVolume1Usage, err := GetStorageMetricData(clientAuth, instanceId, elementType, startTime, endTime, "EphemeralStorageUtilized", cloudWatchClient)
+	ebsVolume1Usage, err := GetStorageMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, "EphemeralStorageUtilized", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting EBS volume 1 usage: ", err)
 		return "", nil, err
@@ -133,18 +58,21 @@ func GetStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, clou
 	cloudwatchMetricData["EBSVolume1Usage"] = ebsVolume1Usage
 
 	// Get EBS Volume 2 Usage
-	ebsVolume2Usage, err := GetStorageMetricData(clientAuth, instanceId, elementType, startTime, endTime, "EphemeralStorageUtilized", cloudWatchClient)
+	ebsVolume2Usage, err := GetStorageMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, "EphemeralStorageUtilized", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting EBS volume 2 usage: ", err)
 		return "", nil, err
 	}
 	cloudwatchMetricData["EBSVolume2Usage"] = ebsVolume2Usage
 
-	// Create JSON output
+	// Create JSON output, with 0 for volumes without data
+	rootVolumeValue, _ := comman_function.FirstValue(rootVolumeUsage)
+	ebsVolume1Value, _ := comman_function.FirstValue(ebsVolume1Usage)
+	ebsVolume2Value, _ := comman_function.FirstValue(ebsVolume2Usage)
 	jsonOutput := StorageUtilizationResult{
-		RootVolumeUsage: *rootVolumeUsage.MetricDataResults[0].Values[0],
-		EBSVolume1Usage: *ebsVolume1Usage.MetricDataResults[0].Values[0],
-		EBSVolume2Usage: *ebsVolume2Usage.MetricDataResults[0].Values[0],
+		RootVolumeUsage: rootVolumeValue,
+		EBSVolume1Usage: ebsVolume1Value,
+		EBSVolume2Usage: ebsVolume2Value,
 	}
 
 	jsonString, err := json.Marshal(jsonOutput)
@@ -156,7 +84,7 @@ func GetStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, clou
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetStorageMetricData(clientAuth *model.Auth, instanceId, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetStorageMetricData(clientAuth *model.Auth, instanceId, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	elmType := "ECS/ContainerInsights"
 	input := &cloudwatch.GetMetricDataInput{
 		EndTime:   endTime,
@@ -183,7 +111,7 @@ func GetStorageMetricData(clientAuth *model.Auth, instanceId, elementType string
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 	result, err := cloudWatchClient.GetMetricData(input)
 	if err != nil {
@@ -6,11 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -22,66 +22,18 @@ var AwsxECSUptimeCmd = &cobra.Command{
 	Use:   "ecs_uptime_panel",
 	Short: "get uptime metrics data for ECS",
 	Long:  `command to get uptime metrics data for ECS`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			jsonResp, _, err := GetECSUptimeData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting ECS uptime data: ", err)
-				return
-			}
-			fmt.Println(jsonResp)
-		}
-	},
 }
 
-func GetECSUptimeData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]string, error) {
+func GetECSUptimeData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]string, error) {
 	ClusterName := "cluster-01-02-2024"
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
 
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// Debug prints
-	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
-
 	// Fetch raw data
-	totalTaskCount, totalServiceCount, err := GetECSTaskAndServiceCount(clientAuth, startTime, endTime, ClusterName, cloudWatchClient)
+	totalTaskCount, totalServiceCount, err := GetECSTaskAndServiceCount(req.ClientAuth, startTime, endTime, ClusterName, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting ECS task and service count metrics: ", err)
 		return "", nil, err
@@ -108,7 +60,7 @@ func GetECSUptimeData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClie
 	return string(jsonString), timeSeriesData, nil
 }
 
-func GetECSTaskAndServiceCount(clientAuth *model.Auth, startTime, endTime *time.Time, ClusterName string, cloudWatchClient *cloudwatch.CloudWatch) (float64, float64, error) {
+func GetECSTaskAndServiceCount(clientAuth *model.Auth, startTime, endTime *time.Time, ClusterName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, float64, error) {
 	taskCountInput := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String("ECS/ContainerInsights"),
 		MetricName: aws.String("TaskCount"),
@@ -142,7 +94,7 @@ func GetECSTaskAndServiceCount(clientAuth *model.Auth, startTime, endTime *time.
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	taskCountResult, err := cloudWatchClient.GetMetricStatistics(taskCountInput)
@@ -6,13 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/cmdb"
-	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -30,90 +28,22 @@ var AwsxEKSAllocatableMemCmd = &cobra.Command{
 	Use:   "allocatable_mem_panel",
 	Short: "get allocatable memory metrics data",
 	Long:  `command to get allocatable memory metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetAllocatableMemData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting allocatable memory: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetAllocatableMemData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	if elementId != "" {
-		log.Println("getting cloud-element data from cmdb")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("using default cmdb url")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("cmdb url: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", nil, err
-		}
-		instanceId = cmdbData.InstanceId
-
-	}
-
-	var startTime, endTime *time.Time
+func GetAllocatableMemData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
+	instanceId, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", nil, err
 	}
 
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// Debug prints
-	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
-
 	// Fetch raw data
-	rawData, err := GetAllocatableMemMetricData(clientAuth, instanceId, startTime, endTime, cloudWatchClient)
+	rawData, err := GetAllocatableMemMetricData(req.ClientAuth, instanceId, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting raw data: ", err)
 		return "", nil, err
@@ -141,8 +71,8 @@ func GetAllocatableMemData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatc
 	timestamps := make([]time.Time, len(result.AllocatableMemory))
 	values := make([]float64, len(result.AllocatableMemory))
 
-	fmt.Println("timeeeeeeeee", timestamps)
-	fmt.Println("timeeeeeeeee", values)
+	log.Println("timeeeeeeeee", timestamps)
+	log.Println("timeeeeeeeee", values)
 
 	// Populate the slices with actual data
 	for i, data := range result.AllocatableMemory {
@@ -182,7 +112,7 @@ func GetAllocatableMemData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatc
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetAllocatableMemMetricData(clientAuth *model.Auth, instanceId string, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetAllocatableMemMetricData(clientAuth *model.Auth, instanceId string, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	elmType := "ContainerInsights"
 	input := &cloudwatch.GetMetricDataInput{
 		EndTime:   endTime,
@@ -225,7 +155,7 @@ func GetAllocatableMemMetricData(clientAuth *model.Auth, instanceId string, star
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 	result, err := cloudWatchClient.GetMetricData(input)
 	if err != nil {
@@ -247,8 +177,8 @@ func processMemRawData(result *cloudwatch.GetMetricDataOutput) AllocateMemResult
 		rawData.AllocatableMemory[i].Timestamp = *timestamp
 		memLimit := *result.MetricDataResults[0].Values[i]
 		reservedCapacity := *result.MetricDataResults[1].Values[i]
-		fmt.Println("memlimit", memLimit)
-		fmt.Println("reserved capacity", reservedCapacity)
+		log.Println("memlimit", memLimit)
+		log.Println("reserved capacity", reservedCapacity)
 		allocatableMem := memLimit - reservedCapacity
 
 		// Only include the calculated allocatable memory in the result
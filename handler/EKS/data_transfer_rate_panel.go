@@ -2,15 +2,14 @@ package EKS
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -24,70 +23,27 @@ var AwsxEksDataTransferRateCmd = &cobra.Command{
 	Use:   "data_transfer_rate_panel",
 	Short: "get EKS data transfer rate metrics data",
 	Long:  `command to get EKS data transfer rate metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetEksDataTransferRatePanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting EKS data transfer rate data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetEksDataTransferRatePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, []DataTransferRateDataPoint, error) {
+func GetEksDataTransferRatePanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, []DataTransferRateDataPoint, error) {
 	clusterName := "myClustTT"
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
 
-	var startTime, endTime *time.Time
+	startTime, endTime := req.StartTime, req.EndTime
 
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
+	// Default start time if not provided
+	if startTime == nil {
 		defaultStartTime := time.Now().Add(-1 * time.Hour) // Default start time: 1 hour ago
 		startTime = &defaultStartTime
 	}
 
-	// Parse end time if provided
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
+	// Default end time if not provided
+	if endTime == nil {
 		defaultEndTime := time.Now() // Default end time: Now
 		endTime = &defaultEndTime
 	}
 
 	// Get EKS cluster metrics
-	eksMetrics, err := GetEksMetrics(clientAuth, clusterName, startTime, endTime, cloudWatchClient)
+	eksMetrics, err := GetEksMetrics(req.ClientAuth, clusterName, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting EKS metrics: ", err)
 		return "", nil, err
@@ -113,7 +69,7 @@ func GetEksDataTransferRatePanel(cmd *cobra.Command, clientAuth *model.Auth, clo
 	return string(jsonString), dataTransferRateData, nil
 }
 
-func GetEksMetrics(clientAuth *model.Auth, clusterName string, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetEksMetrics(clientAuth *model.Auth, clusterName string, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	input := &cloudwatch.GetMetricDataInput{
 		EndTime:   endTime,
 		StartTime: startTime,
@@ -155,14 +111,14 @@ func GetEksMetrics(clientAuth *model.Auth, clusterName string, startTime, endTim
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 	result, err := cloudWatchClient.GetMetricData(input)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println("nulllll", result)
-	fmt.Println("input", input)
+	log.Println("nulllll", result)
+	log.Println("input", input)
 
 	return result, nil
 }
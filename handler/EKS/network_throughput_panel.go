@@ -6,13 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/cmdb"
-	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -36,73 +34,33 @@ var AwsxEKSNetworkThroughputCmd = &cobra.Command{
 	Use:   "network_throughput_panel",
 	Short: "get Network throughput graph metrics data",
 	Long:  `command to get Network throughput graph metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNetworkThroughputPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting Network throughput data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetNetworkThroughputPanel(cmd *cobra.Command, clientAuth *model.Auth,cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	if elementId != "" {
-		log.Println("getting cloud-element data from cmdb")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("using default cmdb url")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("cmdb url: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", nil, err
-		}
-		instanceId = cmdbData.InstanceId
+func GetNetworkThroughputPanel(req *comman_function.PanelRequest,cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
 
+	instanceId, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", nil, err
 	}
 	
-	startTime, endTime := parseTime(startTimeStr, endTimeStr)
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
+	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
-	networkInRawData, err := GetMetricData(clientAuth, instanceId, elementType, startTime, endTime, PodNetworkRXBytes,cloudWatchClient)
+	networkInRawData, err := GetMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, PodNetworkRXBytes,cloudWatchClient)
 	if err != nil {
 		log.Println("Error fetching network in raw data: ", err)
 		return "", nil, err
 	}
 	cloudwatchMetricData["NetworkIn"] = networkInRawData
 
-	networkOutRawData, err := GetMetricData(clientAuth, instanceId, elementType, startTime, endTime, PodNetworkTXBytes,cloudWatchClient)
+	networkOutRawData, err := GetMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, PodNetworkTXBytes,cloudWatchClient)
 	if err != nil {
 		log.Println("Error fetching network out raw data: ", err)
 		return "", nil, err
@@ -120,39 +78,7 @@ func GetNetworkThroughputPanel(cmd *cobra.Command, clientAuth *model.Auth,cloudW
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func parseTime(startTimeStr, endTimeStr string) (*time.Time, *time.Time) {
-	var startTime, endTime *time.Time
-
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-		} else {
-			startTime = &parsedStartTime
-		}
-	} else {
-		now := time.Now()
-		startTime = &now
-		minusFiveMinutes := now.Add(-5 * time.Minute)
-		startTime = &minusFiveMinutes
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-		} else {
-			endTime = &parsedEndTime
-		}
-	} else {
-		now := time.Now()
-		endTime = &now
-	}
-
-	return startTime, endTime
-}
-
-func GetMetricData(clientAuth *model.Auth, instanceId, elementType string, startTime, endTime *time.Time, metricName string,cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetMetricData(clientAuth *model.Auth, instanceId, elementType string, startTime, endTime *time.Time, metricName string,cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	elmType := "ContainerInsights"
 	input := &cloudwatch.GetMetricDataInput{
 		EndTime:   endTime,
@@ -178,7 +104,7 @@ func GetMetricData(clientAuth *model.Auth, instanceId, elementType string, start
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 	result, err := cloudWatchClient.GetMetricData(input)
 	if err != nil {
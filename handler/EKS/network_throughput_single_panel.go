@@ -8,11 +8,10 @@ import (
 
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -32,59 +31,31 @@ var AwsxEKSNetworkThroughputSingleCmd = &cobra.Command{
 	Use:   "network_throughput_single_panel",
 	Short: "get Network throughput single graph metrics data",
 	Long:  `command to get Network throughput single graph metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNetworkThroughputSinglePanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting Network throughput data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetNetworkThroughputSinglePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, string, error) {
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
+func GetNetworkThroughputSinglePanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, string, error) {
+	instanceId := req.InstanceId
+	elementType := req.ElementType
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, "", fmt.Errorf("error parsing time: %v", err)
+		return nil, "", fmt.Errorf("error parsing time: %w", err)
 	}
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return nil, "", fmt.Errorf("error getting instance ID: %v", err)
+		return nil, "", fmt.Errorf("error getting instance ID: %w", err)
 	}
 	// Fetch network in raw data
-	networkInRawData, err := GetMetricData(clientAuth, instanceId, elementType, startTime, endTime, PodNetworkRXByte, cloudWatchClient)
+	networkInRawData, err := GetMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, PodNetworkRXByte, cloudWatchClient)
 	if err != nil {
 		log.Println("Error fetching network in raw data: ", err)
 		return nil, "", err
 	}
 
 	// Fetch network out raw data
-	networkOutRawData, err := GetmetricData(clientAuth, instanceId, elementType, startTime, endTime, PodNetworkTXByte, cloudWatchClient)
+	networkOutRawData, err := GetmetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, PodNetworkTXByte, cloudWatchClient)
 	if err != nil {
 		log.Println("Error fetching network out raw data: ", err)
 		return nil, "", err
@@ -104,7 +75,7 @@ func GetNetworkThroughputSinglePanel(cmd *cobra.Command, clientAuth *model.Auth,
 }
 
 // Function to fetch CloudWatch metric data
-func GetmetricData(clientAuth *model.Auth, instanceId, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetmetricData(clientAuth *model.Auth, instanceId, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	elmType := "ContainerInsights"
 	input := &cloudwatch.GetMetricDataInput{
 		EndTime:   endTime,
@@ -130,7 +101,7 @@ func GetmetricData(clientAuth *model.Auth, instanceId, elementType string, start
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 	result, err := cloudWatchClient.GetMetricData(input)
 	if err != nil {
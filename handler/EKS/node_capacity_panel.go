@@ -3,10 +3,9 @@ package EKS
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -26,69 +25,35 @@ var AwsxEKSNodeCapacityCmd = &cobra.Command{
 	Use:   "node_capacity_panel",
 	Short: "get node capacity metrics data",
 	Long:  `command to get node capacity metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			nodeCapacityPanel, err := GetNodeCapacityPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting node capacity data: ", err)
-				return
-			}
-
-			jsonResp := nodeCapacityPanel.JsonData
-			cloudwatchMetricResp := nodeCapacityPanel.RawData
-
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetNodeCapacityPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (*NodeCapacityPanel, error) {
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
+func GetNodeCapacityPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*NodeCapacityPanel, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	cpuUsageRawData, err := comman_function.GetMetricData(clientAuth, instanceId, "ContainerInsights", "node_cpu_utilization", startTime, endTime, "Sum", "ClusterName", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("cpuUsageRawData", "ContainerInsights", "node_cpu_utilization", "Sum", comman_function.Dimension("ClusterName", instanceId))
+	metricQuery.AddMetric("memoryUsageRawData", "ContainerInsights", "node_memory_utilization", "Sum", comman_function.Dimension("ClusterName", instanceId))
+	metricQuery.AddMetric("storageAvailRawData", "ContainerInsights", "node_filesystem_utilization", "Sum", comman_function.Dimension("ClusterName", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
 		return nil, err
 	}
+	cpuUsageRawData := metricData.Output("cpuUsageRawData")
 
-	memoryUsageRawData, err := comman_function.GetMetricData(clientAuth, instanceId, "ContainerInsights", "node_memory_utilization", startTime, endTime, "Sum", "ClusterName", cloudWatchClient)
-	if err != nil {
-		return nil, err
-	}
+	memoryUsageRawData := metricData.Output("memoryUsageRawData")
 
-	storageAvailRawData, err := comman_function.GetMetricData(clientAuth, instanceId, "ContainerInsights", "node_filesystem_utilization", startTime, endTime, "Sum", "ClusterName", cloudWatchClient)
-	if err != nil {
-		return nil, err
-	}
+	storageAvailRawData := metricData.Output("storageAvailRawData")
 
 	totalCPU := 100.0 // Assuming 100% CPU
 	totalMemory := 100.0
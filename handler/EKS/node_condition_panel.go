@@ -3,11 +3,8 @@ package EKS
 import (
 	"fmt"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
@@ -24,51 +21,22 @@ var AwsxEKSNodeConditionCmd = &cobra.Command{
 	Use:   "node_condition_panel",
 	Short: "get node condition metrics data",
 	Long:  `command to get node condition metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNodeConditionPanel(cmd, clientAuth)
-			if err != nil {
-				log.Println("Error getting Node condition data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetNodeConditionPanel(cmd *cobra.Command, clientAuth *model.Auth) (map[string]float64, *NodeConditionPanel, error) {
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
+func GetNodeConditionPanel(req *comman_function.PanelRequest) (map[string]float64, *NodeConditionPanel, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 	// Get node condition data
-	nodeConditionData, err := GetNodeConditionData(clientAuth, instanceId, startTime, endTime)
+	nodeConditionData, err := GetNodeConditionData(req.ClientAuth, instanceId, startTime, endTime)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -194,7 +162,7 @@ func GetNodeConditionData(clientAuth *model.Auth, instanceId string, startTime,
 	}
 
 	// Get the CloudWatch client
-	cloudWatchClient := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+	cloudWatchClient := comman_function.CloudWatchClient(*clientAuth)
 
 	// Call the GetMetricData API
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -7,11 +7,9 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/spf13/cobra"
 )
@@ -28,52 +26,23 @@ var AwsxEKSNodeEventLogsCmd = &cobra.Command{
 	Use:   "node_event_logs_panel",
 	Short: "get node event logs data",
 	Long:  `command to get node event logs data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNodeEventLogsSinglePanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting Node event logs data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetNodeEventLogsSinglePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, string, error) {
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
+func GetNodeEventLogsSinglePanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, string, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", "", fmt.Errorf("error parsing time: %v", err)
+		return "", "", fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", "", fmt.Errorf("error getting instance ID: %v", err)
+		return "", "", fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	// Fetch node event logs
-	nodeEventLogs, err := GetNodeEventLogs(clientAuth, instanceId, startTime, endTime, cloudWatchClient)
+	nodeEventLogs, err := GetNodeEventLogs(req.ClientAuth, instanceId, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error fetching node event logs: ", err)
 		return "", "", err
@@ -95,7 +64,7 @@ func GetNodeEventLogsSinglePanel(cmd *cobra.Command, clientAuth *model.Auth, clo
 	return string(jsonString), rawLogs, nil
 }
 
-func GetNodeEventLogs(clientAuth *model.Auth, instanceId string, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) ([]NodeEventLog, error) {
+func GetNodeEventLogs(clientAuth *model.Auth, instanceId string, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) ([]NodeEventLog, error) {
 	logGroupName := "/aws/containerinsights/" + instanceId + "/host"
 	startTimeMillis := startTime.UnixNano() / int64(time.Millisecond) // Convert to milliseconds
 	endTimeMillis := endTime.UnixNano() / int64(time.Millisecond)     // Convert to milliseconds
@@ -107,7 +76,7 @@ func GetNodeEventLogs(clientAuth *model.Auth, instanceId string, startTime, endT
 		FilterPattern: &filterPattern,             // Pass the address of the filter pattern string
 	}
 	// Get CloudWatchLogs client from awsclient
-	cloudWatchLogsClient := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH_LOG).(*cloudwatchlogs.CloudWatchLogs)
+	cloudWatchLogsClient := comman_function.CloudWatchLogsClient(*clientAuth)
 	result, err := cloudWatchLogsClient.FilterLogEvents(input)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,249 @@
+package EKS
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var eksElementTypes = []string{"EKS", "AWS/EKS"}
+
+func init() {
+	comman_function.RegisterIdentifier(eksElementTypes, comman_function.IdentifierClusterName)
+	comman_function.RegisterPanels(eksElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "cpu_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxEKSCpuUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetEKScpuUtilizationPanel),
+		},
+		{
+			Name:          "cpu_requests_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSCpuRequestsCmd,
+			Panel:         comman_function.MetricPanel(GetCPURequestData),
+		},
+		{
+			Name:          "node_stability_index_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSNodeStabilityCmd,
+			Panel:         comman_function.MetricPanel(GetNodeStabilityData),
+		},
+		{
+			Name:          "memory_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxEKSMemoryUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GeteksMemoryUtilizationPanel),
+		},
+		{
+			Name:          "network_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "MBs",
+			Command:       AwsxEKSNetworkUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkUtilizationPanel),
+		},
+		{
+			Name:          "storage_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxEKSStorageUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetStorageUtilizationPanel),
+		},
+		{
+			Name:          "incident_response_time_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSIncidentResponseTimeCmd,
+			Panel:         comman_function.MetricPanel(GetIncidentResponseTimeData),
+		},
+		{
+			Name:          "disk_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSDiskUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetDiskUtilizationData),
+		},
+		{
+			Name:          "allocatable_cpu_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSAllocatableCpuCmd,
+			Panel:         comman_function.MetricPanel(GetAllocatableCPUData),
+		},
+		{
+			Name:          "allocatable_memory_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetAllocatableMemData),
+		},
+		{
+			Name:          "cpu_limits_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSCpuLimitsCmd,
+			Panel:         comman_function.MetricPanel(GetCPULimitsData),
+		},
+		{
+			Name:          "node_recovery_time_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetNodeRecoveryTime),
+		},
+		{
+			Name:          "node_failure_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetNodeFailureData),
+		},
+		{
+			Name:          "cpu_graph_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSCpuUtilizationGraphCmd,
+			Panel:         comman_function.MetricPanel(GetCPUUtilizationData),
+		},
+		{
+			Name:          "memory_requests_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSMemoryRequestsCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryRequestData),
+		},
+		{
+			Name:          "memory_limits_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSMemoryLimitsCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryLimitsData),
+		},
+		{
+			Name:          "memory_graph_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSMemoryUtilizationGraphCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryUtilizationGraphData),
+		},
+		{
+			Name:          "network_in_out_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSNetworkInOutCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkInOutData),
+		},
+		{
+			Name:          "disk_io_performance_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetNetworkInOutData),
+		},
+		{
+			Name:          "cpu_utilization_node_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSCpuUtilizationNodeGraphCmd,
+			Panel:         comman_function.MetricPanel(GetCPUUtilizationNodeData),
+		},
+		{
+			Name:          "memory_usage_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSMemoryUsageCmd,
+			Panel:         comman_function.MetricPanel(GetMemoryUsageData),
+		},
+		{
+			Name:          "network_throughput_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSNetworkThroughputCmd,
+			Panel:         comman_function.MetricPanel(GetNetworkThroughputPanel),
+		},
+		{
+			Name:          "node_capacity_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxEKSNodeCapacityCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				nodeCapacityPanel, err := GetNodeCapacityPanel(req, req.CloudWatchClient())
+				if err != nil {
+					return nil, err
+				}
+				return &comman_function.PanelResult{Json: nodeCapacityPanel.JsonData, Frame: nodeCapacityPanel.RawData}, nil
+			}),
+		},
+		{
+			Name:          "node_uptime_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSNodeUptimeCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetNodeUptimePanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "network_throughput_single_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSNetworkThroughputSingleCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				cloudwatchMetricResp, jsonResp, err := GetNetworkThroughputSinglePanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "node_downtime_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSNodeDowntimeCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetNodeDowntimePanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "network_availability_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSNetworkAvailabilityCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetNetworkAvailabilityData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "service_availability_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxEKSServiceAvailabilityCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetServiceAvailabilityData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "node_event_logs_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxEKSNodeEventLogsCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetNodeEventLogsSinglePanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "node_condition_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetNodeConditionPanel(req)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+	})
+}
@@ -3,10 +3,9 @@ package EKS
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 	"log"
 	"strconv"
@@ -22,72 +21,40 @@ var AwsxEKSStorageUtilizationCmd = &cobra.Command{
 	Use:   "storage_utilization_panel",
 	Short: "get storage utilization metrics data",
 	Long:  `command to get storage utilization metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetStorageUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting storage utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetStorageUtilizationPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Get Root Volume Usage
-	rootVolumeUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "ContainerInsights", "node_filesystem_utilization", startTime, endTime, "Average", "ClusterName", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("rootVolumeUsage", "ContainerInsights", "node_filesystem_utilization", "Average", comman_function.Dimension("ClusterName", instanceId))
+	metricQuery.AddMetric("ebs1VolumeUsage", "ContainerInsights", "node_filesystem_inodes", "Average", comman_function.Dimension("ClusterName", instanceId))
+	metricQuery.AddMetric("ebs2VolumeUsage", "ContainerInsights", "node_filesystem_inodes", "Average", comman_function.Dimension("ClusterName", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting root volume usage: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
+	rootVolumeUsage := metricData.Output("rootVolumeUsage")
 	cloudwatchMetricData["RootVolumeUtilization"] = rootVolumeUsage
 
 	// Get EBS Volume 1 Usage
-	ebs1VolumeUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "ContainerInsights", "node_filesystem_inodes", startTime, endTime, "Average", "ClusterName", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting EBS1 volume  usage: ", err)
-		return "", nil, err
-	}
+	ebs1VolumeUsage := metricData.Output("ebs1VolumeUsage")
 	cloudwatchMetricData["EBS1VolumeUtilization"] = ebs1VolumeUsage
 
 	// Get EBS Volume 2 Usage
-	ebs2VolumeUsage, err := comman_function.GetMetricData(clientAuth, instanceId, "ContainerInsights", "node_filesystem_inodes", startTime, endTime, "Average", "ClusterName", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting EBS2 volume  usage: ", err)
-		return "", nil, err
-	}
+	ebs2VolumeUsage := metricData.Output("ebs2VolumeUsage")
 	cloudwatchMetricData["EBS2VolumeUtilization"] = ebs2VolumeUsage
 	// Calculate average of all three volumes
 	rootVolumeAvg := calculateAverage(rootVolumeUsage)
@@ -3,11 +3,11 @@ package Lambda
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,48 +15,28 @@ var AwsxDeadLetterErrorsTrendsCmd = &cobra.Command{
 	Use:   "dead_letter_errors_trends_panel",
 	Short: "Get error trend events",
 	Long:  `Command to retrieve error trend events`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running  panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetLambdaDeadLetterErrorsTrendsEvents(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-
-		}
-	},
+// DeadLetterErrorRow is one row of the dead_letter_errors_trends_panel query.
+type DeadLetterErrorRow struct {
+	Time                 time.Time `json:"time" logs:"bin(1h)"`
+	DeadLetterErrorCount int64     `json:"deadLetterErrorCount" logs:"DeadLetterErrorCount"`
 }
 
-func GetLambdaDeadLetterErrorsTrendsEvents(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetLambdaDeadLetterErrorsTrendsEvents(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 	
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource = "lambda.amazonaws.com"| filter @message like /Dead|Deadletter queue/| stats count(*) as DeadLetterErrorCount by bin(1h)`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource = "lambda.amazonaws.com"| filter @message like /Dead|Deadletter queue/| stats count(*) as DeadLetterErrorCount by bin(1h)`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQuerysResult(results)
 
@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,79 +15,45 @@ var AwsxLambdaDurationCmd = &cobra.Command{
 	Use:   "duration_panel",
 	Short: "Get duration metrics data for a Lambda function",
 	Long:  `Command to get duration metrics data for a Lambda function`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running duration panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLambdaDurationData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting cpu utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetLambdaDurationData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetLambdaDurationData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 	instanceID := "appkube-ecommerce-api-dev-getAllOrders"
 	metricName := "Duration"
 	dimensionsName := "FunctionName"
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	fmt.Println(elementId)
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	elementId := req.ElementId
+	log.Println(elementId)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// elementId, err = comman_function.GetCmdbData(cmd)
+	// elementId, err = comman_function.GetCmdbData(req)
 	// if err != nil {
-	// 	return "", nil, fmt.Errorf("error getting element ID: %v", err)
+	// 	return "", nil, fmt.Errorf("error getting element ID: %w", err)
 	// }
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
-	average, err := comman_function.GetMetricData(clientAuth, instanceID, "AWS/Lambda", metricName, startTime, endTime, "Average", dimensionsName, cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("average", "AWS/Lambda", metricName, "Average", comman_function.Dimension(dimensionsName, instanceID))
+	metricQuery.AddMetric("minimum", "AWS/Lambda", metricName, "Minimum", comman_function.Dimension(dimensionsName, instanceID))
+	metricQuery.AddMetric("maximum", "AWS/Lambda", metricName, "Maximum", comman_function.Dimension(dimensionsName, instanceID))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
 		return "", nil, err
 	}
+	average := metricData.Output("average")
 	if len(average.MetricDataResults) > 0 && len(average.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["AverageUsage"] = average
 	} else {
 		log.Println("No data available for average Usage")
 	}
-	minimum, err := comman_function.GetMetricData(clientAuth, instanceID, "AWS/Lambda", metricName, startTime, endTime, "Minimum", dimensionsName, cloudWatchClient)
-	if err != nil {
-		return "", nil, err
-	}
+	minimum := metricData.Output("minimum")
 	if len(minimum.MetricDataResults) > 0 && len(minimum.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["MinUsage"] = minimum
 	} else {
 		log.Println("No data available for minimum Usage")
 	}
-	maximum, err := comman_function.GetMetricData(clientAuth, instanceID, "AWS/Lambda", metricName, startTime, endTime, "Maximum", dimensionsName, cloudWatchClient)
-	if err != nil {
-		return "", nil, err
-	}
+	maximum := metricData.Output("maximum")
 	if len(maximum.MetricDataResults) > 0 && len(maximum.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["MaxUsage"] = maximum
 	} else {
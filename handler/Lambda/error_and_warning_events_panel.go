@@ -3,11 +3,11 @@ package Lambda
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,46 +15,29 @@ var AwsxLambdaErrorAndWarningCmd = &cobra.Command{
 	Use:   "error_and_warning_events_panel",
 	Short: "Get error and warning events metrics data",
 	Long:  `Command to get error and warning events metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("Running from child command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetLambdaErrorAndWarningData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// ErrorAndWarningRow is one row of the error_and_warning_events_panel query.
+type ErrorAndWarningRow struct {
+	Time          time.Time `json:"time" logs:"bin(1month)"`
+	TotalWarnings int64     `json:"totalWarnings" logs:"TotalWarnings"`
+	TotalErrors   int64     `json:"totalErrors" logs:"TotalErrors"`
 }
 
-func GetLambdaErrorAndWarningData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetLambdaErrorAndWarningData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp| filter eventSource = 'lambda.amazonaws.com' and (errorCode != '')| stats count(*) as TotalWarnings, count(errorCode) as TotalErrors by bin(1month)| sort @timestamp asc`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp| filter eventSource = 'lambda.amazonaws.com' and (errorCode != '')| stats count(*) as TotalWarnings, count(errorCode) as TotalErrors by bin(1month)| sort @timestamp asc`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := processQuerysResults(results)
 
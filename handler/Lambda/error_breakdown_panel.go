@@ -7,12 +7,11 @@ import (
 	"math"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -27,58 +26,21 @@ var AwsxLambdaErrorBreakdownCmd = &cobra.Command{
 	Use:   "error_breakdown_panel",
 	Short: "get error metrics data",
 	Long:  `command to get error metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		// if authFlag {
-		// 	panel, err := GetLambdaTopErrorsMessagesEvents(cmd, clientAuth, nil)
-		// 	if err != nil {
-		// 		return
-		// 	}
-		// 	fmt.Println(panel)
-
-		// }
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetErrorBreakdownData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting lambda error breakdown data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetErrorBreakdownData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]interface{}, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	fmt.Println(instanceId)
+func GetErrorBreakdownData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]interface{}, error) {
+	instanceId := req.InstanceId
+	log.Println(instanceId)
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// instanceId, err = comman_function.GetCmdbData(cmd)
+	// instanceId, err = comman_function.GetCmdbData(req)
 
 	// if err != nil {
-	// 	return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+	// 	return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	// }
 	cloudwatchMetricData := map[string]interface{}{}
 	InvocationInput := &cloudwatch.GetMetricStatisticsInput{
@@ -120,32 +82,32 @@ func GetErrorBreakdownData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatc
 		Period:     aws.Int64(300),
 		Statistics: []*string{aws.String("Sum")},
 	}
-	fmt.Println("date", startTime, endTime, lastMonthStartTime, lastMonthEndTime)
-	lastMonthInvocations, err := GetLambdaBreakdownData(InvocationInputLastMonth, clientAuth, &lastMonthStartTime, &lastMonthEndTime, cloudWatchClient)
+	log.Println("date", startTime, endTime, lastMonthStartTime, lastMonthEndTime)
+	lastMonthInvocations, err := GetLambdaBreakdownData(InvocationInputLastMonth, req.ClientAuth, &lastMonthStartTime, &lastMonthEndTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting error metric value for last month: ", err)
 		return "", nil, err
 	}
 
-	currentMonthInvocations, err := GetLambdaBreakdownData(InvocationInput, clientAuth, startTime, endTime, cloudWatchClient)
+	currentMonthInvocations, err := GetLambdaBreakdownData(InvocationInput, req.ClientAuth, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting error metric value for current month: ", err)
 		return "", nil, err
 	}
 
-	ErrorCount, err := GetLambdaBreakdownData(ErrorInput, clientAuth, startTime, endTime, cloudWatchClient)
+	ErrorCount, err := GetLambdaBreakdownData(ErrorInput, req.ClientAuth, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting error metric value for error: ", err)
 		return "", nil, err
 	}
 
-	lastMonthErrorCount, err := GetLambdaBreakdownData(ErrorInputLastMonth, clientAuth, &lastMonthStartTime, &lastMonthEndTime, cloudWatchClient)
+	lastMonthErrorCount, err := GetLambdaBreakdownData(ErrorInputLastMonth, req.ClientAuth, &lastMonthStartTime, &lastMonthEndTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting error metric value for last month: ", err)
 		return "", nil, err
 	}
 
-	fmt.Println(lastMonthInvocations, currentMonthInvocations, ErrorCount, lastMonthErrorCount)
+	log.Println(lastMonthInvocations, currentMonthInvocations, ErrorCount, lastMonthErrorCount)
 	// Calculate percentage change
 	errorPercentage := (ErrorCount / currentMonthInvocations) * 100
 	errorPercentageRounded := math.Round(errorPercentage*100) / 100
@@ -173,10 +135,10 @@ func GetErrorBreakdownData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatc
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetLambdaBreakdownData(input *cloudwatch.GetMetricStatisticsInput, clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetLambdaBreakdownData(input *cloudwatch.GetMetricStatisticsInput, clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricStatistics(input)
@@ -186,7 +148,7 @@ func GetLambdaBreakdownData(input *cloudwatch.GetMetricStatisticsInput, clientAu
 	// fmt.Println(result)
 
 	if len(result.Datapoints) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
+		return 0, &comman_function.NoDataError{Panel: "error_breakdown_panel"}
 	}
 
 	// Sum up the values from all the datapoints
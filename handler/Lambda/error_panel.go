@@ -4,10 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,47 +13,19 @@ var AwsxLambdaErrorCmd = &cobra.Command{
 	Use:   "error_panel",
 	Short: "get error metrics data",
 	Long:  `command to get error metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLambdaErrorData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting lambda errors data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetLambdaErrorData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]interface{}, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetLambdaErrorData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]interface{}, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]interface{}{}
@@ -63,7 +33,7 @@ func GetLambdaErrorData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchCl
 	// Fetch raw data for last month and current month
 	lastMonthStartTime := startTime.AddDate(0, -1, 0)
 	lastMonthEndTime := endTime.AddDate(0, -1, 0)
-	lastMonthMemory, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/Lambda", "Errors", &lastMonthStartTime, &lastMonthEndTime, "Sum", "FunctionName", cloudWatchClient)
+	lastMonthMemory, err := comman_function.GetMetricData(req, instanceId, "AWS/Lambda", "Errors", &lastMonthStartTime, &lastMonthEndTime, "Sum", "FunctionName", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting error metric value for last month: ", err)
 		return "", nil, err
@@ -75,12 +45,12 @@ func GetLambdaErrorData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchCl
 	}
 	cloudwatchMetricData["LastMonthMemory"] = lastMonthValue
 
-	currentMonthMemory, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/Lambda", "Errors", startTime, endTime, "Sum", "FunctionName", cloudWatchClient)
+	currentMonthMemory, err := comman_function.GetMetricData(req, instanceId, "AWS/Lambda", "Errors", startTime, endTime, "Sum", "FunctionName", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting error metric value for current month: ", err)
 		return "", nil, err
 	}
-    fmt.Println(currentMonthMemory)
+    log.Println(currentMonthMemory)
 	currentMonthValue := float64(0)
 	if len(currentMonthMemory.MetricDataResults) > 0 && len(currentMonthMemory.MetricDataResults[0].Values) > 0 {
 		currentMonthValue = *currentMonthMemory.MetricDataResults[0].Values[0]
@@ -6,11 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -24,48 +24,20 @@ var LambdaExecutionTimeCmd = &cobra.Command{
 	Use:   "execution_time_panel",
 	Short: "Get Lambda function execution time metrics",
 	Long:  `Command to get Lambda function execution time metrics`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("Running Lambda execution time metrics command")
-
-		responseType, _ := cmd.PersistentFlags().GetString("responseType")
-
-		authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Fatalf("Error getting client auth: %v", err)
-		}
-		if !authFlag {
-			log.Println("Authentication failed")
-			return
-		}
-
-		jsonResp, cloudwatchMetricResp, err := GetLambdaExecutionTimePanel(cmd, clientAuth, nil)
-		if err != nil {
-			log.Fatalf("Error getting Lambda execution time metric data: %v", err)
-		}
-
-		if responseType == "frame" {
-			fmt.Println(cloudwatchMetricResp)
-		} else {
-			fmt.Println(jsonResp)
-		}
-	},
 }
 
-func GetLambdaExecutionTimePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, *map[string]*cloudwatch.GetMetricDataOutput, error) {
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
+func GetLambdaExecutionTimePanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, *map[string]*cloudwatch.GetMetricDataOutput, error) {
 	functionName := "List-Org-Github"
-	fmt.Println("getting function", functionName)
+	log.Println("getting function", functionName)
 
-	startTime, endTime, err := parseTimeFlag(startTimeStr, endTimeStr)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
 		return "", nil, err
 	}
 
 	cloudwatchMetricData := make(map[string]*cloudwatch.GetMetricDataOutput)
 
-	rawData, err := GetLambdaExecutionTimeMetricData(clientAuth, startTime, endTime, cloudWatchClient, functionName)
+	rawData, err := GetLambdaExecutionTimeMetricData(req.ClientAuth, *startTime, *endTime, cloudWatchClient, functionName)
 	if err != nil {
 		return "", nil, err
 	}
@@ -75,12 +47,12 @@ func GetLambdaExecutionTimePanel(cmd *cobra.Command, clientAuth *model.Auth, clo
 
 	jsonString, err := json.Marshal(processedDataList)
 	if err != nil {
-		log.Fatalf("Error marshalling JSON response: %v", err)
+		return "", nil, fmt.Errorf("error marshalling JSON response: %w", err)
 	}
 	return string(jsonString), &cloudwatchMetricData, nil
 }
 
-func GetLambdaExecutionTimeMetricData(clientAuth *model.Auth, startTime, endTime time.Time, cloudWatchClient *cloudwatch.CloudWatch, functionName string) (*cloudwatch.GetMetricDataOutput, error) {
+func GetLambdaExecutionTimeMetricData(clientAuth *model.Auth, startTime, endTime time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI, functionName string) (*cloudwatch.GetMetricDataOutput, error) {
 	input := &cloudwatch.GetMetricDataInput{
 		StartTime: &startTime,
 		EndTime:   &endTime,
@@ -123,7 +95,7 @@ func GetLambdaExecutionTimeMetricData(clientAuth *model.Auth, startTime, endTime
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 	result, err := cloudWatchClient.GetMetricData(input)
 	if err != nil {
@@ -161,31 +133,6 @@ func processExecutionTimeRawData(result *cloudwatch.GetMetricDataOutput, functio
 	return executionTimeDataList
 }
 
-func parseTimeFlag(startTimeStr, endTimeStr string) (time.Time, time.Time, error) {
-	var startTime, endTime time.Time
-	var err error
-
-	if startTimeStr != "" {
-		startTime, err = time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			return time.Time{}, time.Time{}, err
-		}
-	} else {
-		startTime = time.Now().Add(-5 * time.Minute)
-	}
-
-	if endTimeStr != "" {
-		endTime, err = time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			return time.Time{}, time.Time{}, err
-		}
-	} else {
-		endTime = time.Now()
-	}
-
-	return startTime, endTime, nil
-}
-
 func init() {
 	LambdaExecutionTimeCmd.PersistentFlags().String("startTime", "", "Start time")
 	LambdaExecutionTimeCmd.PersistentFlags().String("endTime", "", "End time")
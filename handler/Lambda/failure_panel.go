@@ -6,11 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -22,83 +22,24 @@ var AwsxLambdaFailureCmd = &cobra.Command{
 	Use:   "failure_panel",
 	Short: "get failure metrics data",
 	Long:  `command to get failure metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLambdaErrorData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting lambda failure data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetLambdaFailureData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]float64, error) {
-
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+func GetLambdaFailureData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]float64, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// Debug prints
-	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
-
 	cloudwatchMetricData := map[string]float64{}
 
 	// Fetch raw data
-	rawData, err := GetLambdaFailureCount(clientAuth, startTime, endTime, cloudWatchClient)
+	rawData, err := GetLambdaFailureCount(req.ClientAuth, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting failure count: ", err)
 		return "", nil, err
 	}
 	cloudwatchMetricData["FailureCount"] = rawData
 
-	// Debug prints
-	log.Printf("Failure Count: %f", rawData)
-
 	jsonString, err := json.Marshal(rawData)
 	if err != nil {
 		log.Println("Error in marshalling json in string: ", err)
@@ -108,7 +49,7 @@ func GetLambdaFailureData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatch
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetLambdaFailureCount(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetLambdaFailureCount(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
 	// First, retrieve the total number of invocations
 	totalInvocations, invocationsErr := GetTotalLambdaErrorInvocations(clientAuth, startTime, endTime, cloudWatchClient)
 	if invocationsErr != nil {
@@ -128,7 +69,7 @@ func GetLambdaFailureCount(clientAuth *model.Auth, startTime, endTime *time.Time
 	return failureCount, nil
 }
 
-func GetTotalLambdaErrorInvocations(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetTotalLambdaErrorInvocations(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String("AWS/Lambda"),
 		MetricName: aws.String("Invocations"),
@@ -139,7 +80,7 @@ func GetTotalLambdaErrorInvocations(clientAuth *model.Auth, startTime, endTime *
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricStatistics(input)
@@ -148,7 +89,7 @@ func GetTotalLambdaErrorInvocations(clientAuth *model.Auth, startTime, endTime *
 	}
 
 	if len(result.Datapoints) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
+		return 0, &comman_function.NoDataError{Panel: "failure_panel"}
 	}
 
 	// Extract the sum value from the first datapoint
@@ -157,7 +98,7 @@ func GetTotalLambdaErrorInvocations(clientAuth *model.Auth, startTime, endTime *
 	return sumValue, nil
 }
 
-func GetTotalLambdaErrors(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetTotalLambdaErrors(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String("AWS/Lambda"),
 		MetricName: aws.String("Errors"),
@@ -168,7 +109,7 @@ func GetTotalLambdaErrors(clientAuth *model.Auth, startTime, endTime *time.Time,
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricStatistics(input)
@@ -177,7 +118,7 @@ func GetTotalLambdaErrors(clientAuth *model.Auth, startTime, endTime *time.Time,
 	}
 
 	if len(result.Datapoints) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
+		return 0, &comman_function.NoDataError{Panel: "failure_panel"}
 	}
 
 	// Extract the sum value from the first datapoint
@@ -2,13 +2,11 @@ package Lambda
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,41 +14,17 @@ var AwsxLambdaFullConcurrencyCommmand = &cobra.Command{
 	Use:   "full_concurrency_panel",
 	Short: "get full concurrency metrics data",
 	Long:  `command to get full concurrency metrics data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, resp, err := GetLambdaFullConcurrencyData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting full concurrency data : ", err)
-				return
-			}
-			if responseType == "json" {
-				fmt.Println(jsonResp)
-			} else {
-				fmt.Println(resp)
-			}
-		}
-	},
 }
 
-func GetLambdaFullConcurrencyData(cmd *cobra.Command, clientAuth *model.Auth, lambdaClient *lambda.Lambda) (string, map[string]int, error) {
+func GetLambdaFullConcurrencyData(req *comman_function.PanelRequest, lambdaClient lambdaiface.LambdaAPI) (string, map[string]int, error) {
 	if lambdaClient == nil {
-		lambdaClient = awsclient.GetClient(*clientAuth, awsclient.LAMBDA_CLIENT).(*lambda.Lambda)
+		lambdaClient = comman_function.LambdaClient(*req.ClientAuth)
 	}
 	input := lambda.GetAccountSettingsInput{}
 	result, err := lambdaClient.GetAccountSettings(&input)
 	if err != nil {
 		log.Printf("Error getting full concurrency of lambda")
+		return "", nil, err
 	}
 	fullConcurrency := int(*result.AccountLimit.ConcurrentExecutions)
 	data := make(map[string]int)
@@ -3,13 +3,12 @@ package Lambda
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/spf13/cobra"
 )
 
@@ -17,32 +16,6 @@ var AwsxLambdaFunctionsByRegionCmd = &cobra.Command{
 	Use:   "functions_by_region_panel",
 	Short: "get Lambda functions by region",
 	Long:  `command to get Lambda functions by region`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, functionCounts, err := GetLambdaFunctionsByRegion(clientAuth)
-			if err != nil {
-				log.Println("Error getting Lambda functions by region: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(functionCounts)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
 func GetLambdaFunctionsByRegion(clientAuth *model.Auth) (string, map[string]interface{}, error) {
@@ -69,7 +42,7 @@ func GetLambdaFunctionsByRegion(clientAuth *model.Auth) (string, map[string]inte
 		}
 
 		// Get Lambda client for the current region
-		lambdaClient := awsclient.GetClient(newAuth, awsclient.LAMBDA_CLIENT).(*lambda.Lambda)
+		lambdaClient := comman_function.LambdaClient(newAuth)
 
 		// Get total Lambda functions count for the current region
 		count, err := getTotalLambdaFunctions(lambdaClient)
@@ -98,7 +71,7 @@ func GetLambdaFunctionsByRegion(clientAuth *model.Auth) (string, map[string]inte
 }
 
 // Function to get total Lambda functions in a region
-func getTotalLambdaFunctions(lambdaClient *lambda.Lambda) (int, error) {
+func getTotalLambdaFunctions(lambdaClient lambdaiface.LambdaAPI) (int, error) {
 	totalFunctions := 0
 
 	input := &lambda.ListFunctionsInput{}
@@ -1,21 +1,18 @@
 package Lambda
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-
 	"github.com/Appkube-awsx/awsx-common/model"
 
-	"github.com/aws/aws-sdk-go/aws"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 
-	"log"
-
 	"time"
 )
 
@@ -26,184 +23,39 @@ var AwsxLambdaFunctionCmd = &cobra.Command{
 	Short: "get function metrics data",
 
 	Long: `command to get function metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-
-		fmt.Println("running from child command")
-
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-
-			log.Printf("Error during authentication: %v\n", err)
-
-			err := cmd.Help()
-
-			if err != nil {
-
-				return
-			}
-
-			return
-		}
-		if authFlag {
-
-			GetFunctionPanel(cmd, clientAuth, nil)
-
-		}
-
-	},
 }
 
-func GetFunctionPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) {
+func GetFunctionPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.ResultField, error) {
 
 	logGroupName := "CloudTrail/DefaultLogGroup"
 
-	filterPattern, _ := cmd.PersistentFlags().GetString("filterPattern")
-
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-
-	if startTimeStr != "" {
-
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-
-		if err != nil {
+	filterPattern := req.FilterPattern
 
-			log.Printf("Error parsing start time: %v", err)
-
-			err := cmd.Help()
-
-			if err != nil {
-
-				// handle error
-
-			}
-
-		}
-
-		startTime = &parsedStartTime
-
-	} else {
-
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-
-		startTime = &defaultStartTime
-
-	}
-
-	if endTimeStr != "" {
-
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-
-		if err != nil {
-
-			log.Printf("Error parsing end time: %v", err)
-
-			err := cmd.Help()
-
-			if err != nil {
-
-				// handle error
-
-			}
-
-		}
-
-		endTime = &parsedEndTime
-
-	} else {
-
-		defaultEndTime := time.Now()
-
-		endTime = &defaultEndTime
-
-	}
-
-	events, err := filterCloudWatchLogs(clientAuth, startTime, endTime, logGroupName, filterPattern, cloudWatchLogs)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	if err != nil {
-
-		log.Println("Error in getting sample count: ", err)
-
-		// handle error
-
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	for _, event := range events {
-
-		fmt.Println(event)
-
-	}
+	return filterCloudWatchLogs(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, filterPattern, cloudWatchLogs)
 
 }
 
-func filterCloudWatchLogs(clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, filterPattern string, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.ResultField, error) {
-
-	// Construct input parameters
-
-	params := &cloudwatchlogs.StartQueryInput{
-
-		LogGroupName: aws.String(logGroupName),
-
-		StartTime: aws.Int64(startTime.Unix() * 1000), // Convert to milliseconds
+func filterCloudWatchLogs(ctx context.Context, clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, filterPattern string, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.ResultField, error) {
 
-		EndTime: aws.Int64(endTime.Unix() * 1000), // Convert to milliseconds
-
-		QueryString: aws.String(`fields @timestamp, @message
+	queryResults, err := comman_function.RunLogsQuery(ctx, clientAuth, comman_function.LogsQuery{
+		LogGroupNames: []string{logGroupName},
+		QueryString:   `fields @timestamp, @message
 	   | filter eventSource=="lambda.amazonaws.com"
 	   | filter eventName=="GetPolicy20150331"
 	   | stats count(*) as functionCount by bin(1mo)
 
-       | sort @timestamp desc`),
-	}
-
-	if cloudWatchLogs == nil {
-
-		cloudWatchLogs = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH_LOG).(*cloudwatchlogs.CloudWatchLogs)
-
-	}
-
-	queryResult, err := cloudWatchLogs.StartQuery(params)
-
+       | sort @timestamp desc`,
+		StartTime:     startTime,
+		EndTime:       endTime,
+	}, cloudWatchLogs)
 	if err != nil {
-
-		return nil, fmt.Errorf("failed to start query: %v", err)
-
-	}
-
-	queryId := queryResult.QueryId
-
-	queryStatus := ""
-
-	var queryResults *cloudwatchlogs.GetQueryResultsOutput // Declare queryResults outside the loop
-
-	for queryStatus != "Complete" {
-
-		// Check query status
-
-		queryStatusInput := &cloudwatchlogs.GetQueryResultsInput{
-
-			QueryId: queryId,
-		}
-
-		queryResults, err = cloudWatchLogs.GetQueryResults(queryStatusInput) // Assign value to queryResults
-
-		if err != nil {
-
-			return nil, fmt.Errorf("failed to get query results: %v", err)
-
-		}
-
-		queryStatus = aws.StringValue(queryResults.Status)
-
-		time.Sleep(1 * time.Second) // Wait for a second before checking status again
-
+		return nil, err
 	}
 
 	// Query is complete, now process results
@@ -3,14 +3,11 @@ package Lambda
 import (
 	"fmt"
 	"log"
-	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/spf13/cobra"
 )
 
@@ -18,36 +15,10 @@ var AwsxLambdaIdleFunctionCmd = &cobra.Command{
 	Use:   "idle_function_panel",
 	Short: "get idle function metrics data",
 	Long:  `Command to get idle function metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			idleFunctionCount, err := GetLambdaIdleFunctionData(clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting idle function data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				// Print cloudwatchMetricResp if necessary
-			} else {
-				fmt.Println("Idle Function Count:", idleFunctionCount)
-			}
-		}
-	},
 }
 
-func GetLambdaIdleFunctionData(clientAuth *model.Auth, lambdaClient *lambda.Lambda) (int, error) {
-	idleFunctionCount, err := GetIdleLambdaFunctionCount(clientAuth, lambdaClient)
+func GetLambdaIdleFunctionData(req *comman_function.PanelRequest, lambdaClient lambdaiface.LambdaAPI) (int, error) {
+	idleFunctionCount, err := GetIdleLambdaFunctionCount(req, lambdaClient)
 	if err != nil {
 		log.Println("Error in getting idle function count: ", err)
 		return 0, err
@@ -55,60 +26,50 @@ func GetLambdaIdleFunctionData(clientAuth *model.Auth, lambdaClient *lambda.Lamb
 	return idleFunctionCount, nil
 }
 
-func GetIdleLambdaFunctionCount(clientAuth *model.Auth, lambdaClient *lambda.Lambda) (int, error) {
+func GetIdleLambdaFunctionCount(req *comman_function.PanelRequest, lambdaClient lambdaiface.LambdaAPI) (int, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing time: %w", err)
+	}
 	if lambdaClient == nil {
-		lambdaClient = awsclient.GetClient(*clientAuth, awsclient.LAMBDA_CLIENT).(*lambda.Lambda)
+		lambdaClient = comman_function.LambdaClient(*req.ClientAuth)
 	}
 
-	cloudWatchClient := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+	var functions []*lambda.FunctionConfiguration
+	err = lambdaClient.ListFunctionsPagesWithContext(req.Context(), &lambda.ListFunctionsInput{}, func(page *lambda.ListFunctionsOutput, lastPage bool) bool {
+		functions = append(functions, page.Functions...)
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(functions) == 0 {
+		return 0, nil
+	}
 
-	input := &lambda.ListFunctionsInput{}
+	metricQuery := req.MetricQuery(startTime, endTime)
+	ids := make([]string, len(functions))
+	for i, function := range functions {
+		ids[i] = metricQuery.AddMetric("", "AWS/Lambda", "Invocations", "Sum", comman_function.Dimension("FunctionName", aws.StringValue(function.FunctionName)))
+	}
+	metricData, err := metricQuery.Execute(req.ClientAuth, nil)
+	if err != nil {
+		return 0, err
+	}
 
+	// A function without invocations in the range is idle.
 	idleFunctionCount := 0
-
-	for {
-		resp, err := lambdaClient.ListFunctions(input)
-		if err != nil {
-			return 0, err
-		}
-
-		for _, function := range resp.Functions {
-			// Get the invocation count for the last 5 minutes
-			input := &cloudwatch.GetMetricStatisticsInput{
-				Namespace:  aws.String("AWS/Lambda"),
-				MetricName: aws.String("Invocations"),
-				Dimensions: []*cloudwatch.Dimension{
-					{
-						Name:  aws.String("FunctionName"),
-						Value: function.FunctionName,
-					},
-				},
-				StartTime:  aws.Time(time.Now().Add(-5 * time.Minute)),
-				EndTime:    aws.Time(time.Now()),
-				Period:     aws.Int64(300), // 5 minutes
-				Statistics: []*string{aws.String("Sum")},
-			}
-			// fmt.Println(function)
-			resp, err := cloudWatchClient.GetMetricStatistics(input)
-			if err != nil {
-				log.Println("Error getting metric statistics: ", err)
-				continue
-			}
-
-			// If there are no invocations in the last 5 minutes, consider the function idle
-			if len(resp.Datapoints) == 0 || *resp.Datapoints[0].Sum == 0 {
-				idleFunctionCount++
+	for _, id := range ids {
+		invocations := 0.0
+		if result := metricData.Result(id); result != nil {
+			for _, value := range result.Values {
+				invocations += aws.Float64Value(value)
 			}
 		}
-
-		// Check if there are more functions to fetch
-		if resp.NextMarker != nil {
-			input.Marker = resp.NextMarker
-		} else {
-			break // No more functions to fetch
+		if invocations == 0 {
+			idleFunctionCount++
 		}
 	}
-
 	return idleFunctionCount, nil
 }
 
@@ -129,4 +90,5 @@ func init() {
 	AwsxLambdaIdleFunctionCmd.PersistentFlags().String("instanceId", "", "instance id")
 	AwsxLambdaIdleFunctionCmd.PersistentFlags().String("startTime", "", "start time")
 	AwsxLambdaIdleFunctionCmd.PersistentFlags().String("endTime", "", "end time")
+	AwsxLambdaIdleFunctionCmd.PersistentFlags().String("concurrency", "", "most aws calls a panel makes at once")
 }
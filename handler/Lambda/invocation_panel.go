@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,79 +15,45 @@ var AwsxLambdaInvocationCmd = &cobra.Command{
 	Use:   "invocation_panel",
 	Short: "Get duration metrics data for a Lambda function",
 	Long:  `Command to get duration metrics data for a Lambda function`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running duration panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLambdaInvocationData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting cpu utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetLambdaInvocationData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetLambdaInvocationData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 	instanceID := "appkube-ecommerce-api-dev-updateProduct"
 	//metricName := "Invocations"
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	fmt.Println(elementId)
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	elementId := req.ElementId
+	log.Println(elementId)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// elementId, err = comman_function.GetCmdbData(cmd)
+	// elementId, err = comman_function.GetCmdbData(req)
 	// if err != nil {
-	// 	return "", nil, fmt.Errorf("error getting element ID: %v", err)
+	// 	return "", nil, fmt.Errorf("error getting element ID: %w", err)
 	// }
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
-	success, err := comman_function.GetMetricData(clientAuth, instanceID, "AWS/Lambda", "Invocations", startTime, endTime, "Sum", "FunctionName", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("success", "AWS/Lambda", "Invocations", "Sum", comman_function.Dimension("FunctionName", instanceID))
+	metricQuery.AddMetric("errordata", "AWS/Lambda", "Errors", "Sum", comman_function.Dimension("FunctionName", instanceID))
+	metricQuery.AddMetric("coldstart", "LambdaInsights", "init_duration", "Sum", comman_function.Dimension("function_name", instanceID))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
 		return "", nil, err
 	}
+	success := metricData.Output("success")
 	if len(success.MetricDataResults) > 0 && len(success.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["Successfull"] = success
 	} else {
 		log.Println("No data available for Successfull ")
 	}
 
-	errordata, err := comman_function.GetMetricData(clientAuth, instanceID, "AWS/Lambda", "Errors", startTime, endTime, "Sum", "FunctionName", cloudWatchClient)
-	if err != nil {
-		return "", nil, err
-	}
+	errordata := metricData.Output("errordata")
 	if len(errordata.MetricDataResults) > 0 && len(errordata.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["Error"] = errordata
 	} else {
 		log.Println("No data available for Error Usage")
 	}
-	coldstart, err := comman_function.GetMetricData(clientAuth, instanceID, "LambdaInsights", "init_duration", startTime, endTime, "Sum", "function_name", cloudWatchClient)
-	if err != nil {
-		return "", nil, err
-	}
+	coldstart := metricData.Output("coldstart")
 	if len(coldstart.MetricDataResults) > 0 && len(coldstart.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["ColdStart"] = coldstart
 	} else {
@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -20,56 +19,25 @@ var AwsxLambdaMaxMemoryGraphCmd = &cobra.Command{
 	Use:   "max_memory_used_graph_panel",
 	Short: "get lambda memory used data",
 	Long:  `Command to get lambda memory used data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-
-			jsonResp, cloudwatchMetricResp, err := GetLambdaMaxMemoryGraphData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting lambda max memory used data: ", err)
-				return
-			}
-
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetLambdaMaxMemoryGraphData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetLambdaMaxMemoryGraphData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
-	rawData, err := comman_function.GetMetricData(clientAuth, instanceId, "LambdaInsights", "used_memory_max", startTime, endTime, "Maximum", "FunctionName", cloudWatchClient)
+	rawData, err := comman_function.GetMetricData(req, instanceId, "LambdaInsights", "used_memory_max", startTime, endTime, "Maximum", "FunctionName", cloudWatchClient)
 	if err != nil {
-		log.Printf("Error in getting lambda memory metric data for function: ", err)
+		log.Printf("Error in getting lambda memory metric data for function: %v", err)
 		return "", nil, err
 	}
 	cloudwatchMetricData["Max Memory Used (MB)"] = rawData
@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,79 +15,45 @@ var AwsxLambdaMemoryUsageCmd = &cobra.Command{
 	Use:   "invocation_panel",
 	Short: "Get duration metrics data for a Lambda function",
 	Long:  `Command to get duration metrics data for a Lambda function`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running duration panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLambdaMemoryUsageData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting cpu utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetLambdaMemoryUsageData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetLambdaMemoryUsageData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 	instanceID := "appkube-ecommerce-api-dev-updateProduct"
 	//metricName := "Invocations"
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	fmt.Println(elementId)
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	elementId := req.ElementId
+	log.Println(elementId)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// elementId, err = comman_function.GetCmdbData(cmd)
+	// elementId, err = comman_function.GetCmdbData(req)
 	// if err != nil {
-	// 	return "", nil, fmt.Errorf("error getting element ID: %v", err)
+	// 	return "", nil, fmt.Errorf("error getting element ID: %w", err)
 	// }
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
-	Average, err := comman_function.GetMetricData(clientAuth, instanceID, "LambdaInsights", "used_memory_max", startTime, endTime, "Average", "function_name", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("average", "LambdaInsights", "used_memory_max", "Average", comman_function.Dimension("function_name", instanceID))
+	metricQuery.AddMetric("maximum", "LambdaInsights", "used_memory_max", "Maximum", comman_function.Dimension("function_name", instanceID))
+	metricQuery.AddMetric("minimum", "LambdaInsights", "used_memory_max", "Minimum", comman_function.Dimension("function_name", instanceID))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
 		return "", nil, err
 	}
+	Average := metricData.Output("average")
 	if len(Average.MetricDataResults) > 0 && len(Average.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["Average"] = Average
 	} else {
 		log.Println("No data available for Average ")
 	}
 
-	Maximum, err := comman_function.GetMetricData(clientAuth, instanceID, "LambdaInsights", "used_memory_max", startTime, endTime, "Maximum", "function_name", cloudWatchClient)
-	if err != nil {
-		return "", nil, err
-	}
+	Maximum := metricData.Output("maximum")
 	if len(Maximum.MetricDataResults) > 0 && len(Maximum.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["Maximum"] = Maximum
 	} else {
 		log.Println("No data available for Maximum Usage")
 	}
-	Minimum, err := comman_function.GetMetricData(clientAuth, instanceID, "LambdaInsights", "used_memory_max", startTime, endTime, "Minimum", "function_name", cloudWatchClient)
-	if err != nil {
-		return "", nil, err
-	}
+	Minimum := metricData.Output("minimum")
 	if len(Minimum.MetricDataResults) > 0 && len(Minimum.MetricDataResults[0].Values) > 0 {
 		cloudwatchMetricData["Minimum"] = Minimum
 	} else {
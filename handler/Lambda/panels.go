@@ -0,0 +1,355 @@
+package Lambda
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var lambdaElementTypes = []string{"Lambda"}
+
+func init() {
+	comman_function.RegisterIdentifier(lambdaElementTypes, comman_function.IdentifierFunctionName)
+	comman_function.RegisterPanels(lambdaElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "error_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetLambdaErrorData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "error_breakdown_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetErrorBreakdownData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "top_errors_in_lambda_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel:         comman_function.TypedLogsPanel(GetLambdaTopErrorsEvents, TopErrorRow{}),
+		},
+		{
+			Name:          "top_lambda_zones_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel:         comman_function.TypedLogsPanel(GetTopLambdaZonesData, TopZoneRow{}),
+		},
+		{
+			Name:          "dead_letter_errors_trends_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetLambdaDeadLetterErrorsTrendsEvents, DeadLetterErrorRow{}),
+		},
+		{
+			Name:          "error_trend_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetLambdaErrorTrendEvents, ErrorTrendRow{}),
+		},
+		{
+			Name:          "top_errors_messages_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, resp, err := GetLambdaTopErrorsMessagesEvents(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: resp}, err
+			}),
+		},
+		{
+			Name:          "error_and_warning_events_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetLambdaErrorAndWarningData, ErrorAndWarningRow{}),
+		},
+		{
+			Name:          "throttles_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaThrottleData),
+		},
+		{
+			Name:          "latency_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxLambdaCpuCmd,
+			Panel:         comman_function.MetricPanel(GetLambdaLatencyData),
+		},
+		{
+			Name:          "memory_used_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaMemoryData),
+		},
+		{
+			Name:          "total_functions_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetLambdaTotalFunctionData(req.ClientAuth, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "functions_by_region_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetLambdaFunctionsByRegion(req.ClientAuth)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "idle_functions_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				idleFunctionCount, err := GetLambdaIdleFunctionData(req, nil)
+				return &comman_function.PanelResult{Json: idleFunctionCount}, err
+			}),
+		},
+		{
+			Name:          "throttles_function_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				throttledFunctionCount, err := GetLambdaThrottlesFunctionData(req.ClientAuth)
+				return &comman_function.PanelResult{Json: throttledFunctionCount}, err
+			}),
+		},
+		{
+			Name:          "trends_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetLambdaTrendsData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "net_received_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaNetReceivedData),
+		},
+		{
+			Name:          "request_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetLambdaRequestData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "concurrency_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaConcurrencyData),
+		},
+		{
+			Name:          "used_and_unused_memory_data_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetLambdaUnusedMemoryPanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "max_memory_used_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaMaxMemoryData),
+		},
+		{
+			Name:          "max_memory_used_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaMaxMemoryGraphData),
+		},
+		{
+			Name:          "number_of_calls_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxLambdaNumberOfCallsCmd,
+			Panel:         comman_function.MetricPanel(GetLambdaNumberOfCallsPanel),
+		},
+		{
+			Name:          "cold_start_duration_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaColdStartData),
+		},
+		{
+			Name:          "execution_time_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetLambdaExecutionTimePanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "invocation_trend_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetInvocationTrendData, InvocationTrendRow{}),
+		},
+		{
+			Name:          "failure_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxLambdaFailureCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetLambdaFailureData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "error_messages_count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetErrorMessageCountData, ErrorMessageCountRow{}),
+		},
+		{
+			Name:          "throttling_trends_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetThrottlingTrendsData, ThrottlingTrendRow{}),
+		},
+		{
+			Name:          "function_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				events, err := GetFunctionPanel(req, nil)
+				return &comman_function.PanelResult{Json: events}, err
+			}),
+		},
+		{
+			Name:          "top_failure_functions_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel:         comman_function.TypedLogsPanel(GetTopFailureFunctionsLogData, TopFailureFunctionRow{}),
+		},
+		{
+			Name:          "top_used_functions_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel:         comman_function.TypedLogsPanel(GetTopUsedFunctionsLogData, TopUsedFunctionRow{}),
+		},
+		{
+			Name:          "success_and_failed_function_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaSuccessFailedCountData),
+		},
+		{
+			Name:          "cpu_used_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaCpuData),
+		},
+		{
+			Name:          "errors_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaErrorGraphData),
+		},
+		{
+			Name:          "throttles_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaThrottlesGraphData),
+		},
+		{
+			Name:          "concurrency_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaConcurrencyGraphData),
+		},
+		{
+			Name:          "memory_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetLambdaMemoryUsageData),
+		},
+		{
+			Name:          "duration_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "ms",
+			Panel:         comman_function.MetricPanel(GetLambdaDurationData),
+		},
+		{
+			Name:          "invocation_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeDoughnut,
+			Panel:         comman_function.MetricPanel(GetLambdaInvocationData),
+		},
+		{
+			Name:          "invocations_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaInvocationsGraphData),
+		},
+		{
+			Name:          "latency_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaLatencyGraphData),
+		},
+		{
+			Name:          "trends_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaTrendsGraphData),
+		},
+		{
+			Name:          "top_failure_graph_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetLambdaTopFailurePanel, FailureTrendRow{}),
+		},
+		{
+			Name:          "response_time_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetLambdaResponseTimeGraphData),
+		},
+		{
+			Name:          "unreserved_concurrency_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxLambdaUnreservedConcurrencyCommmand,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, resp, err := GetLambdaUnreservedConcurrencyCommmand(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: resp}, err
+			}),
+		},
+		{
+			Name:          "full_concurrency_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxLambdaFullConcurrencyCommmand,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, resp, err := GetLambdaFullConcurrencyData(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: resp}, err
+			}),
+		},
+		{
+			Name:          "top_lambda_warnings",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxLambdaTopLambdaWarningsCommmand,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, resp, err := GetLambdaTopLambdaWarningsData(req, nil)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: resp}, err
+			}),
+		},
+	})
+}
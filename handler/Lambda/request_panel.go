@@ -6,11 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -22,83 +22,26 @@ var AwsxLambdaRequestCmd = &cobra.Command{
 	Use:   "request_panel",
 	Short: "get request metrics data",
 	Long:  `command to get request metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLambdaRequestData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting lambda cpu received data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetLambdaRequestData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]float64, error) {
+func GetLambdaRequestData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]float64, error) {
 	//functionName := "CW-agent-installation-automation"
 
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// Debug prints
-	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
-
 	cloudwatchMetricData := map[string]float64{}
 
 	// Fetch raw data
-	rawData, err := GetLambdaRequestMetricValue(clientAuth, startTime, endTime, cloudWatchClient)
+	rawData, err := GetLambdaRequestMetricValue(req.ClientAuth, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting cpu used metric value: ", err)
 		return "", nil, err
 	}
 	cloudwatchMetricData["Memory"] = rawData
 
-	// Debug prints
-	log.Printf("Memory Metric Value: %f", rawData)
-
 	jsonString, err := json.Marshal(rawData)
 	if err != nil {
 		log.Println("Error in marshalling json in string: ", err)
@@ -108,7 +51,7 @@ func GetLambdaRequestData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatch
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetLambdaRequestMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetLambdaRequestMetricValue(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
 	input := &cloudwatch.GetMetricDataInput{
 		MetricDataQueries: []*cloudwatch.MetricDataQuery{
 			{
@@ -135,7 +78,7 @@ func GetLambdaRequestMetricValue(clientAuth *model.Auth, startTime, endTime *tim
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -144,7 +87,7 @@ func GetLambdaRequestMetricValue(clientAuth *model.Auth, startTime, endTime *tim
 	}
 
 	if len(result.MetricDataResults) == 0 || len(result.MetricDataResults[0].Values) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
+		return 0, &comman_function.NoDataError{Panel: "request_panel"}
 	}
 
 	// If there is only one value, return it
@@ -1,15 +1,14 @@
 package Lambda
 
 import (
-	"fmt"
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/spf13/cobra"
 )
@@ -18,37 +17,11 @@ var AwsxLambdaThrottlesFunctionCmd = &cobra.Command{
 	Use:   "throttles_function_panel",
 	Short: "get throttles function metrics data",
 	Long:  `Command to get throttles function metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			throttlesFunctionCount, err := GetLambdaThrottlesFunctionData(clientAuth)
-			if err != nil {
-				log.Println("Error getting throttles function data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				// Print cloudwatchMetricResp if necessary
-			} else {
-				fmt.Println("Throttles Function Count:", throttlesFunctionCount)
-			}
-		}
-	},
 }
 
 func GetLambdaThrottlesFunctionData(clientAuth *model.Auth) (int, error) {
-	lambdaClient := awsclient.GetClient(*clientAuth, awsclient.LAMBDA_CLIENT).(*lambda.Lambda)
-	cloudWatchClient := awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+	lambdaClient := comman_function.LambdaClient(*clientAuth)
+	cloudWatchClient := comman_function.CloudWatchClient(*clientAuth)
 
 	input := &lambda.ListFunctionsInput{}
 
@@ -72,7 +45,7 @@ func GetLambdaThrottlesFunctionData(clientAuth *model.Auth) (int, error) {
 	return throttlesFunctionCount, nil
 }
 
-func GetFunctionThrottlesCount(cloudWatchClient *cloudwatch.CloudWatch, functionName *string) (int, error) {
+func GetFunctionThrottlesCount(cloudWatchClient cloudwatchiface.CloudWatchAPI, functionName *string) (int, error) {
 	now := time.Now()
 	// Get the throttles count for the last 5 minutes
 	input := &cloudwatch.GetMetricStatisticsInput{
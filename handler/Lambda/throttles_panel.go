@@ -6,11 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -25,66 +25,12 @@ var AwsxLambdaThrottleCmd = &cobra.Command{
 	Use:   "throttle_panel",
 	Short: "get lambda throttle metrics data",
 	Long:  `Command to get lambda throttle metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("Running from child command")
-		authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			return
-		}
-		if !authFlag {
-			log.Println("Authentication failed")
-			return
-		}
-
-		responseType, _ := cmd.PersistentFlags().GetString("responseType")
-		if responseType != "json" && responseType != "frame" {
-			log.Println("Invalid response type. Valid options are 'json' or 'frame'.")
-			return
-		}
-
-		jsonResp, cloudwatchMetricResp, err := GetLambdaThrottleData(cmd, clientAuth, nil)
-		if err != nil {
-			log.Println("Error getting lambda throttle data: ", err)
-			return
-		}
-
-		if responseType == "frame" {
-			fmt.Println(cloudwatchMetricResp)
-		} else {
-			fmt.Println(jsonResp)
-		}
-	},
 }
 
-func GetLambdaThrottleData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+func GetLambdaThrottleData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
@@ -92,7 +38,7 @@ func GetLambdaThrottleData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatc
 	cloudwatchMetricData := make(map[string]*cloudwatch.GetMetricDataOutput)
 
 	// Fetch raw data
-	rawData, err := GetLambdaThrottleMetricData(clientAuth, startTime, endTime, cloudWatchClient)
+	rawData, err := GetLambdaThrottleMetricData(req.ClientAuth, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		return "", nil, err
 	}
@@ -108,7 +54,7 @@ func GetLambdaThrottleData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatc
 }
 
 
-func GetLambdaThrottleMetricData(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetLambdaThrottleMetricData(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	input := &cloudwatch.GetMetricDataInput{
 		EndTime:   endTime,
 		StartTime: startTime,
@@ -128,7 +74,7 @@ func GetLambdaThrottleMetricData(clientAuth *model.Auth, startTime, endTime *tim
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -17,44 +17,26 @@ var AwsxLambdaThrottlingTrendsCmd = &cobra.Command{
 	Use:   "throttling_trends_panel",
 	Short: "Get throttling trends metrics data",
 	Long:  `Command to get throttling trends metrics data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running throttling trends panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetThrottlingTrendsData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// ThrottlingTrendRow is one row of the throttling_trends_panel query.
+type ThrottlingTrendRow struct {
+	Time            time.Time `json:"time" logs:"bin(1m)"`
+	InvocationCount int64     `json:"invocationCount" logs:"InvocationCount"`
+	ErrorCount      int64     `json:"errorCount" logs:"errorCount"`
 }
 
-func GetThrottlingTrendsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+func GetThrottlingTrendsData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
 	// elementId, _ := cmd.PersistentFlags().GetString("elementId")
 	// cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	// if elementId != "" {
@@ -106,7 +88,7 @@ func GetThrottlingTrendsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWa
 	// 	endTime = defaultEndTime
 	// }
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, InvocationCount, errorCount| filter eventSource = "lambda.amazonaws.com"| stats count() as InvocationCount, count(errorCode) as errorCount by bin(1m)`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, InvocationCount, errorCount| filter eventSource = "lambda.amazonaws.com"| stats count() as InvocationCount, count(errorCode) as errorCount by bin(1m)`, cloudWatchLogs)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +114,7 @@ func GetThrottlingTrendsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWa
 
 // 	queryResult, err := cloudWatchLogs.StartQuery(params)
 // 	if err != nil {
-// 		return nil, fmt.Errorf("failed to start query: %v", err)
+// 		return nil, fmt.Errorf("failed to start query: %w", err)
 
 // 	}
 // 	queryId := queryResult.QueryId
@@ -146,7 +128,7 @@ func GetThrottlingTrendsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWa
 
 // 		queryResult, err := cloudWatchLogs.GetQueryResults(queryStatusInput)
 // 		if err != nil {
-// 			return nil, fmt.Errorf("failed to get query results: %v", err)
+// 			return nil, fmt.Errorf("failed to get query results: %w", err)
 // 		}
 
 // 		queryResults = append(queryResults, queryResult)
@@ -3,15 +3,9 @@ package Lambda
 import (
 	"encoding/json"
 	"fmt"
-	"log"
-	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
 	comman_function "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -19,39 +13,6 @@ var AwsxTopErrorsMessagesPanelCmd = &cobra.Command{
 	Use:   "top_errors_messages_panel",
 	Short: "Get top errors messages events",
 	Long:  `Command to retrieve top errors events`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running  panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, resp, err := GetLambdaTopErrorsMessagesEvents(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting top lambda zones data : ", err)
-				return
-			}
-			if responseType == "json" {
-				fmt.Println(jsonResp)
-			} else {
-				fmt.Println(resp)
-
-			}
-
-		}
-	},
 }
 
 type ResultData struct {
@@ -61,52 +22,20 @@ type ResultData struct {
 	FunctionName string `json:"functionName"`
 }
 
-func GetLambdaTopErrorsMessagesEvents(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) (string, []ResultData, error) {
-
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetLambdaTopErrorsMessagesEvents(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) (string, []ResultData, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	if cloudWatchLogs != nil {
-		cloudWatchLogs = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH_LOG).(*cloudwatchlogs.CloudWatchLogs)
-	}
-	input := &cloudwatchlogs.StartQueryInput{
-		LogGroupName: aws.String("CloudTrail/DefaultLogGroup"),
-		StartTime:    aws.Int64(startTime.Unix() * 1000),
-		EndTime:      aws.Int64(endTime.Unix() * 1000),
-		QueryString: aws.String(`
+	queryResults, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, "CloudTrail/DefaultLogGroup", `
 		fields @timestamp, @message, eventVersion, eventTime, requestParameters
 			| filter eventSource = "lambda.amazonaws.com"
 			| filter @message like /ERROR|Exception|Failed/
 			| stats count(*) as frequency by eventTime, requestParameters.functionName as functionName, eventVersion
 			| sort frequency desc
-			| limit 10`),
-	}
-	res, err := cloudWatchLogs.StartQuery(input)
+			| limit 10`, cloudWatchLogs)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to start query: %v", err)
-	}
-
-	queryId := res.QueryId
-	var queryResults []*cloudwatchlogs.GetQueryResultsOutput // Declare queryResults outside the loop
-
-	for {
-		// Check query status
-		queryStatusInput := &cloudwatchlogs.GetQueryResultsInput{
-			QueryId: queryId,
-		}
-
-		queryResult, err := cloudWatchLogs.GetQueryResults(queryStatusInput)
-		if err != nil {
-			return "", nil, fmt.Errorf("failed to get query results: %v", err)
-		}
-
-		queryResults = append(queryResults, queryResult) // Append each query result to queryResults
-		if *queryResult.Status != "Complete" {
-			time.Sleep(5 * time.Second) // wait before querying again
-			continue
-		}
-		break // exit loop if query is complete
+		return "", nil, err
 	}
 	resArrMap := make([]ResultData, 0)
 	for i := 0; i < len(queryResults); i++ {
@@ -134,7 +63,7 @@ func GetLambdaTopErrorsMessagesEvents(cmd *cobra.Command, clientAuth *model.Auth
 	// fmt.Println("resArrMap", resArrMap)
 	jsonData, err := json.Marshal(resArrMap)
 	if err != nil {
-		return "", nil, fmt.Errorf("error paring json: %v", err)
+		return "", nil, fmt.Errorf("error paring json: %w", err)
 	}
 	return string(jsonData), resArrMap, nil
 
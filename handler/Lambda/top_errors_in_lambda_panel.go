@@ -1,17 +1,15 @@
 package Lambda
  
 import (
+    "context"
     "fmt"
     "log"
     "time"
  
-    "github.com/Appkube-awsx/awsx-common/authenticate"
-    "github.com/Appkube-awsx/awsx-common/awsclient"
-    "github.com/Appkube-awsx/awsx-common/cmdb"
-    "github.com/Appkube-awsx/awsx-common/config"
     "github.com/Appkube-awsx/awsx-common/model"
-    "github.com/aws/aws-sdk-go/aws"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
     "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
     "github.com/spf13/cobra"
 )
  
@@ -20,142 +18,42 @@ var AwsxTopErrorsPanelCmd = &cobra.Command{
     Short: "Get top errors in lambda events",
     Long:  `Command to retrieve top errors events`,
  
-    Run: func(cmd *cobra.Command, args []string) {
-        fmt.Println("Running  panel command")
- 
-        var authFlag bool
-        var clientAuth *model.Auth
-        var err error
-        authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
- 
-        if err != nil {
-            log.Printf("Error during authentication: %v\n", err)
-            err := cmd.Help()
-            if err != nil {
-                return
-            }
-            return
-        }
-        if authFlag {
-            panel, err := GetLambdaTopErrorsEvents(cmd, clientAuth, nil)
-            if err != nil {
-                return
-            }
-            fmt.Println(panel)
- 
-        }
-    },
 }
  
-func GetLambdaTopErrorsEvents(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-    logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-    elementId, _ := cmd.PersistentFlags().GetString("elementId")
-    cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
- 
-    if elementId != "" {
-        log.Println("getting cloud-element data from cmdb")
-        apiUrl := cmdbApiUrl
-        if cmdbApiUrl == "" {
-            log.Println("using default cmdb url")
-            apiUrl = config.CmdbUrl
-        }
-        log.Println("cmdb url: " + apiUrl)
-        cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-        if err != nil {
-            return nil, err
-        }
-        logGroupName = cmdbData.LogGroup
- 
-    }
-    startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-    endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
- 
-    var startTime, endTime *time.Time
- 
-    // Parse start time if provided
-    if startTimeStr != "" {
-        parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-        if err != nil {
-            log.Printf("Error parsing start time: %v", err)
-            err := cmd.Help()
-            if err != nil {
+// TopErrorRow is one row of the top_errors_in_lambda_panel query.
+type TopErrorRow struct {
+	ErrorMessage string `json:"errorMessage" logs:"errorMessage"`
+	EventName    string `json:"eventName" logs:"eventName"`
+	ErrorCount   int64  `json:"errorCount" logs:"errorCount"`
+}
+
+func GetLambdaTopErrorsEvents(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
  
-            }
-        }
-        startTime = &parsedStartTime
-    } else {
-        defaultStartTime := time.Now().Add(-5 * time.Minute)
-        startTime = &defaultStartTime
+    logGroupName, err := comman_function.GetCmdbLogsData(req)
+    if err != nil {
+        return nil, err
     }
  
-    if endTimeStr != "" {
-        parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-        if err != nil {
-            log.Printf("Error parsing end time: %v", err)
-            err := cmd.Help()
-            if err != nil {
-                // handle error
-            }
-        }
-        endTime = &parsedEndTime
-    } else {
-        defaultEndTime := time.Now()
-        endTime = &defaultEndTime
+    startTime, endTime, err := comman_function.ParseTimes(req)
+    if err != nil {
+    	return nil, fmt.Errorf("error parsing time: %w", err)
     }
-    results, err := FilterTopErrorsTasks(clientAuth, startTime, endTime, logGroupName, cloudWatchLogs)
+    results, err := FilterTopErrorsTasks(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, cloudWatchLogs)
     if err != nil {
-        return nil, nil
+        return nil, err
     }
     processedResults := ProcessQueryResultss(results)
  
     return processedResults, nil
 }
  
-func FilterTopErrorsTasks(clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-    params := &cloudwatchlogs.StartQueryInput{
-        LogGroupName: aws.String(logGroupName),
-        StartTime:    aws.Int64(startTime.Unix() * 1000),
-        EndTime:      aws.Int64(endTime.Unix() * 1000),
-        QueryString: aws.String(`fields errorCount, errorMessage
+func FilterTopErrorsTasks(ctx context.Context, clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+    return comman_function.GetLogsDataWithContext(ctx, clientAuth, startTime, endTime, logGroupName, `fields errorCount, errorMessage
 		| filter eventSource = "lambda.amazonaws.com"
 		| filter ispresent(errorMessage)
 		| stats count(*) as errorCount by errorMessage, eventName
 		| sort errorCount desc
-		| limit 20`),
-    }
-    if cloudWatchLogs == nil {
-        cloudWatchLogs = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH_LOG).(*cloudwatchlogs.CloudWatchLogs)
-    }
- 
-    queryResult, err := cloudWatchLogs.StartQuery(params)
-    if err != nil {
-        return nil, fmt.Errorf("failed to start query: %v", err)
-    }
- 
-    queryId := queryResult.QueryId
-    var queryResults []*cloudwatchlogs.GetQueryResultsOutput // Declare queryResults outside the loop
-    for {
-        // Check query status
-        queryStatusInput := &cloudwatchlogs.GetQueryResultsInput{
-            QueryId: queryId,
-        }
- 
-        queryResult, err := cloudWatchLogs.GetQueryResults(queryStatusInput)
-        if err != nil {
-            return nil, fmt.Errorf("failed to get query results: %v", err)
-        }
- 
-        queryResults = append(queryResults, queryResult)
- 
-        if *queryResult.Status != "Complete" {
-            time.Sleep(5 * time.Second) // wait before querying again
-            continue
-        }
- 
-        break // exit loop if query is complete
-    }
- 
-    return queryResults, nil
+		| limit 20`, cloudWatchLogs)
 }
 func ProcessQueryResultss(results []*cloudwatchlogs.GetQueryResultsOutput) []*cloudwatchlogs.GetQueryResultsOutput {
     processedResults := make([]*cloudwatchlogs.GetQueryResultsOutput, 0)
@@ -1,16 +1,16 @@
 package Lambda
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -28,77 +28,21 @@ var AwsxLambdaFunctionFailureCmd = &cobra.Command{
 	Short: "get lambda failure metrics data",
 
 	Long: `command to get lambda failure metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-
-		fmt.Println("running from child command")
-
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-
-			log.Printf("Error during authentication: %v\n", err)
-
-			err := cmd.Help()
-
-			if err != nil {
-
-				return
-			}
-
-			return
-		}
-		if authFlag {
-
-			GetTotalFailureFunctionsPanel(cmd, clientAuth, nil)
-
-		}
-
-	},
 }
 
-func GetTotalFailureFunctionsPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) {
+func GetTotalFailureFunctionsPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) {
 	logGroupName := "CloudTrail/DefaultLogGroup"
 
-	filterPattern, _ := cmd.PersistentFlags().GetString("filterPattern")
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
+	filterPattern := req.FilterPattern
 
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			err := cmd.Help()
-			if err != nil {
-				// handle error
-			}
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			err := cmd.Help()
-			if err != nil {
-				// handle error
-			}
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		log.Printf("Error parsing time: %v", err)
+		return
 	}
 
 	// Get total failure count
-	totalFailureCount, err := getTotalFailureCount(clientAuth, startTime, endTime, logGroupName, filterPattern, cloudWatchLogs)
+	totalFailureCount, err := getTotalFailureCount(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, filterPattern, cloudWatchLogs)
 	if err != nil {
 		log.Println("Error in getting total failure count: ", err)
 		// handle error
@@ -107,7 +51,7 @@ func GetTotalFailureFunctionsPanel(cmd *cobra.Command, clientAuth *model.Auth, c
 	fmt.Printf("Total Failure Count for All Functions: %d\n", totalFailureCount)
 
 	// Get top failure functions
-	topFunctions, err := getTopFailureFunctions(clientAuth, startTime, endTime, logGroupName, filterPattern, cloudWatchLogs)
+	topFunctions, err := getTopFailureFunctions(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, filterPattern, cloudWatchLogs)
 	if err != nil {
 		log.Println("Error in getting top failure functions: ", err)
 		// handle error
@@ -120,40 +64,18 @@ func GetTotalFailureFunctionsPanel(cmd *cobra.Command, clientAuth *model.Auth, c
 	}
 }
 
-func getTotalFailureCount(clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, filterPattern string, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) (int64, error) {
-	params := &cloudwatchlogs.StartQueryInput{
-		LogGroupName: aws.String(logGroupName),
-		StartTime:    aws.Int64(startTime.Unix() * 1000), // Convert to milliseconds
-		EndTime:      aws.Int64(endTime.Unix() * 1000),   // Convert to milliseconds
-		QueryString: aws.String(`fields @timestamp, @message
+func getTotalFailureCount(ctx context.Context, clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, filterPattern string, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) (int64, error) {
+	queryResults, err := comman_function.RunLogsQuery(ctx, clientAuth, comman_function.LogsQuery{
+		LogGroupNames: []string{logGroupName},
+		QueryString: `fields @timestamp, @message
 		| filter eventSource=="lambda.amazonaws.com"
 		| filter @message like /ERROR|Exception|Failed/
-		| stats count(*) as FailureCount`),
-	}
-
-	if cloudWatchLogs == nil {
-		cloudWatchLogs = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH_LOG).(*cloudwatchlogs.CloudWatchLogs)
-	}
-
-	queryResult, err := cloudWatchLogs.StartQuery(params)
+		| stats count(*) as FailureCount`,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}, cloudWatchLogs)
 	if err != nil {
-		return 0, fmt.Errorf("failed to start query: %v", err)
-	}
-
-	queryId := queryResult.QueryId
-	queryStatus := ""
-	var queryResults *cloudwatchlogs.GetQueryResultsOutput
-
-	for queryStatus != "Complete" {
-		queryStatusInput := &cloudwatchlogs.GetQueryResultsInput{
-			QueryId: queryId,
-		}
-		queryResults, err = cloudWatchLogs.GetQueryResults(queryStatusInput)
-		if err != nil {
-			return 0, fmt.Errorf("failed to get query results: %v", err)
-		}
-		queryStatus = aws.StringValue(queryResults.Status)
-		time.Sleep(1 * time.Second)
+		return 0, err
 	}
 
 	// Extract total failure count
@@ -164,7 +86,7 @@ func getTotalFailureCount(clientAuth *model.Auth, startTime, endTime *time.Time,
 				// Correctly convert string to int64
 				totalFailureCount, err = strconv.ParseInt(aws.StringValue(resultField.Value), 10, 64)
 				if err != nil {
-					return 0, fmt.Errorf("failed to parse FailureCount: %v", err)
+					return 0, fmt.Errorf("failed to parse FailureCount: %w", err)
 				}
 			}
 		}
@@ -173,42 +95,20 @@ func getTotalFailureCount(clientAuth *model.Auth, startTime, endTime *time.Time,
 	return totalFailureCount, nil
 }
 
-func getTopFailureFunctions(clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, filterPattern string, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*FunctionDetails, error) {
-	params := &cloudwatchlogs.StartQueryInput{
-		LogGroupName: aws.String(logGroupName),
-		StartTime:    aws.Int64(startTime.Unix() * 1000), // Convert to milliseconds
-		EndTime:      aws.Int64(endTime.Unix() * 1000),   // Convert to milliseconds
-		QueryString: aws.String(`fields @timestamp, @message
+func getTopFailureFunctions(ctx context.Context, clientAuth *model.Auth, startTime, endTime *time.Time, logGroupName string, filterPattern string, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*FunctionDetails, error) {
+	queryResults, err := comman_function.RunLogsQuery(ctx, clientAuth, comman_function.LogsQuery{
+		LogGroupNames: []string{logGroupName},
+		QueryString: `fields @timestamp, @message
 		| filter eventSource=="lambda.amazonaws.com"
 		| filter @message like /ERROR|Exception|Failed/
 		| stats count(*) as FailureCount by requestParameters.functionName
 		| sort -FailureCount
-		| limit 10`),
-	}
-
-	if cloudWatchLogs == nil {
-		cloudWatchLogs = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH_LOG).(*cloudwatchlogs.CloudWatchLogs)
-	}
-
-	queryResult, err := cloudWatchLogs.StartQuery(params)
+		| limit 10`,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}, cloudWatchLogs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start query: %v", err)
-	}
-
-	queryId := queryResult.QueryId
-	queryStatus := ""
-	var queryResults *cloudwatchlogs.GetQueryResultsOutput
-
-	for queryStatus != "Complete" {
-		queryStatusInput := &cloudwatchlogs.GetQueryResultsInput{
-			QueryId: queryId,
-		}
-		queryResults, err = cloudWatchLogs.GetQueryResults(queryStatusInput)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get query results: %v", err)
-		}
-		queryStatus = aws.StringValue(queryResults.Status)
-		time.Sleep(1 * time.Second)
+		return nil, err
 	}
 
 	// Extract failure functions details
@@ -223,14 +123,14 @@ func getTopFailureFunctions(clientAuth *model.Auth, startTime, endTime *time.Tim
 				// Parse timestamp with a custom layout
 				timestamp, err := time.Parse("2006-01-02 15:04:05.999", aws.StringValue(resultField.Value))
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse timestamp: %v", err)
+					return nil, fmt.Errorf("failed to parse timestamp: %w", err)
 				}
 				functionDetails.Timestamp = timestamp.Format(time.RFC3339)
 			case "FailureCount":
 				// Correctly convert string to int64
 				functionDetails.FailureCount, err = strconv.ParseInt(aws.StringValue(resultField.Value), 10, 64)
 				if err != nil {
-					return nil, fmt.Errorf("failed to parse FailureCount: %v", err)
+					return nil, fmt.Errorf("failed to parse FailureCount: %w", err)
 				}
 			}
 		}
@@ -3,11 +3,11 @@ package Lambda
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,53 +16,36 @@ var AwsxTopFailureFunctionsLogCmd = &cobra.Command{
 	Use:   "top_failure_functions_panel",
 	Short: "Get top failure functions data",
 	Long:  `Command to get top failure functions data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running error log panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetTopFailureFunctionsLogData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// TopFailureFunctionRow is one row of the top_failure_functions_panel query.
+type TopFailureFunctionRow struct {
+	FunctionName string    `json:"functionName" logs:"requestParameters.functionName"`
+	EventName    string    `json:"eventName" logs:"eventName"`
+	EventTime    time.Time `json:"eventTime" logs:"eventTime"`
+	FailureCount int64     `json:"failureCount" logs:"FailureCount"`
 }
 
-func GetTopFailureFunctionsLogData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
+func GetTopFailureFunctionsLogData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
     
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 	
-	results, err :=  comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName,`fields @timestamp, @message
+	results, err :=  comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName,`fields @timestamp, @message
 	| filter eventSource=="lambda.amazonaws.com"
 	| filter @message like /ERROR|Exception|Failed/
 	| stats count(*) as FailureCount by requestParameters.functionName,eventTime,eventName
 	| limit 10
 	`, cloudWatchLogs)
 	if err != nil{
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQuerysResults(results)
 
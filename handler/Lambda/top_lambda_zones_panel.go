@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,45 +15,28 @@ var AwsxLambdaTopLambdaZonesCmd = &cobra.Command{
 	Use:   "top_lambda_zones_panel",
 	Short: "Get top 5 Lambda zones, event sources, and function names",
 	Long:  `Command to get top 5 Lambda zones along with their event sources and function names.`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running top lambda zones panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetTopLambdaZonesData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// TopZoneRow is one row of the top_lambda_zones_panel query.
+type TopZoneRow struct {
+	EventSource  string `json:"eventSource" logs:"eventSource"`
+	FunctionName string `json:"functionName" logs:"requestParameters.functionName"`
+	Region       string `json:"region" logs:"awsRegion"`
+	EventCount   int64  `json:"eventCount" logs:"EventCount"`
 }
 
-func GetTopLambdaZonesData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetTopLambdaZonesData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields eventSource, requestParameters.functionName|filter eventSource = "lambda.amazonaws.com"| stats count(*) as EventCount by eventSource, requestParameters.functionName, awsRegion| sort EventCount desc| limit 5`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields eventSource, requestParameters.functionName|filter eventSource = "lambda.amazonaws.com"| stats count(*) as EventCount by eventSource, requestParameters.functionName, awsRegion| sort EventCount desc| limit 5`, cloudWatchLogs)
 	if err != nil {
 		return nil, err
 	}
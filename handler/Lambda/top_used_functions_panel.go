@@ -3,11 +3,11 @@ package Lambda
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,47 +16,30 @@ var AwsxTopUsedFunctionsLogCmd = &cobra.Command{
 	Use:   "top_used_functions_panel",
 	Short: "Get top used functions data",
 	Long:  `Command to get top used functions data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running error log panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetTopUsedFunctionsLogData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// TopUsedFunctionRow is one row of the top_used_functions_panel query.
+type TopUsedFunctionRow struct {
+	FunctionName    string    `json:"functionName" logs:"requestParameters.functionName"`
+	EventName       string    `json:"eventName" logs:"eventName"`
+	EventTime       time.Time `json:"eventTime" logs:"eventTime"`
+	InvocationCount int64     `json:"invocationCount" logs:"InvocationCount"`
 }
 
-func GetTopUsedFunctionsLogData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+func GetTopUsedFunctionsLogData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
 	// elementId, _ := cmd.PersistentFlags().GetString("elementId")
 	// cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-    startTime, endTime, err := comman_function.ParseTimes(cmd)
+	logGroupName := req.LogGroupName
+    startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 	
-	results, err :=  comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName,`fields @timestamp, @message
+	results, err :=  comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName,`fields @timestamp, @message
 	| filter eventSource=="lambda.amazonaws.com" 
 	| filter eventName=="GetFunction20150331v2" and  requestParameters.functionName != ""
 	| stats count(*) as InvocationCount by requestParameters.functionName ,eventName, eventTime
@@ -64,7 +47,7 @@ func GetTopUsedFunctionsLogData(cmd *cobra.Command, clientAuth *model.Auth, clou
 	| limit 10
 	`, cloudWatchLogs)
 	if err != nil{
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQuerysResultss(results)
 
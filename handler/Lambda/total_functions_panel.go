@@ -2,13 +2,12 @@ package Lambda
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/spf13/cobra"
 )
 
@@ -20,36 +19,9 @@ var AwsxLambdaTotalFunctionCmd = &cobra.Command{
 	Use:   "total_function_panel",
 	Short: "get total function metrics data",
 	Long:  `command to get total function metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLambdaTotalFunctionData(clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting total function data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetLambdaTotalFunctionData(clientAuth *model.Auth, lambdaClient *lambda.Lambda) (string, map[string]float64, error) {
+func GetLambdaTotalFunctionData(clientAuth *model.Auth, lambdaClient lambdaiface.LambdaAPI) (string, map[string]float64, error) {
 	cloudwatchMetricData := map[string]float64{}
 
 	totalFunctions, err := GetTotalLambdaFunctions(clientAuth, lambdaClient)
@@ -70,9 +42,9 @@ func GetLambdaTotalFunctionData(clientAuth *model.Auth, lambdaClient *lambda.Lam
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetTotalLambdaFunctions(clientAuth *model.Auth, lambdaClient *lambda.Lambda) (int, error) {
+func GetTotalLambdaFunctions(clientAuth *model.Auth, lambdaClient lambdaiface.LambdaAPI) (int, error) {
 	if lambdaClient == nil {
-		lambdaClient = awsclient.GetClient(*clientAuth, awsclient.LAMBDA_CLIENT).(*lambda.Lambda)
+		lambdaClient = comman_function.LambdaClient(*clientAuth)
 	}
 
 	totalFunctions := 0
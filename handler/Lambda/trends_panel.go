@@ -6,11 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -24,86 +24,32 @@ var AwsxLambdaTrendsCmd = &cobra.Command{
 	Use:   "trends_panel",
 	Short: "get trends metrics data",
 	Long:  `command to get trends metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetLambdaTrendsData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting lambda trends data : ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetLambdaTrendsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]interface{}, error) {
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	var startTime, endTime *time.Time
-
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+func GetLambdaTrendsData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]interface{}, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// Debug prints
-	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
-
 	cloudwatchMetricData := map[string]interface{}{}
 
 	// Fetch raw data for last month and current month
 	lastMonthStartTime := startTime.AddDate(0, -1, 0)
 	lastMonthEndTime := endTime.AddDate(0, -1, 0)
-	lastMonthMemory, err := GetTotalLambdaInvocations(clientAuth, &lastMonthStartTime, &lastMonthEndTime, cloudWatchClient)
+	lastMonthMemory, err := GetTotalLambdaInvocations(req.ClientAuth, &lastMonthStartTime, &lastMonthEndTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting error metric value for last month: ", err)
 		return "", nil, err
 	}
 
-	currentMonthMemory, err := GetTotalLambdaInvocations(clientAuth, startTime, endTime, cloudWatchClient)
+	currentMonthMemory, err := GetTotalLambdaInvocations(req.ClientAuth, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting error metric value for current month: ", err)
 		return "", nil, err
 	}
 
-	fmt.Println(lastMonthMemory, currentMonthMemory)
+	log.Println(lastMonthMemory, currentMonthMemory)
 	// Calculate percentage change
 	percentageChange := ((currentMonthMemory - lastMonthMemory) / lastMonthMemory) * 100
 
@@ -126,7 +72,7 @@ func GetLambdaTrendsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchC
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetTotalLambdaInvocations(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (float64, error) {
+func GetTotalLambdaInvocations(clientAuth *model.Auth, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, error) {
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String("AWS/Lambda"),
 		MetricName: aws.String("Invocations"),
@@ -137,7 +83,7 @@ func GetTotalLambdaInvocations(clientAuth *model.Auth, startTime, endTime *time.
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricStatistics(input)
@@ -146,7 +92,7 @@ func GetTotalLambdaInvocations(clientAuth *model.Auth, startTime, endTime *time.
 	}
 
 	if len(result.Datapoints) == 0 {
-		return 0, fmt.Errorf("no data available for the specified time range")
+		return 0, &comman_function.NoDataError{Panel: "trends_panel"}
 	}
 
 	// Sum up the values from all the datapoints
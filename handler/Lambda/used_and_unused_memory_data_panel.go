@@ -6,11 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -24,48 +24,20 @@ var LambdaMemoryMetricsCmd = &cobra.Command{
 	Use:   "used_and_unused_memory_data_panel",
 	Short: "Get Lambda used and unused memory data",
 	Long:  `Command to get Lambda Used and Unused memory data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("Running Lambda memory metrics command")
-
-		responseType, _ := cmd.PersistentFlags().GetString("responseType")
-
-		authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Fatalf("Error getting client auth: %v", err)
-		}
-		if !authFlag {
-			log.Println("Authentication failed")
-			return
-		}
-
-		jsonResp, cloudwatchMetricResp, err := GetLambdaUnusedMemoryPanel(cmd, clientAuth, nil)
-		if err != nil {
-			log.Fatalf("Error getting Lambda memory metric data: %v", err)
-		}
-
-		if responseType == "frame" {
-			fmt.Println(cloudwatchMetricResp)
-		} else {
-			fmt.Println(jsonResp)
-		}
-	},
 }
 
-func GetLambdaUnusedMemoryPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, *map[string]*cloudwatch.GetMetricDataOutput, error) {
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
+func GetLambdaUnusedMemoryPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, *map[string]*cloudwatch.GetMetricDataOutput, error) {
 	functionName := "List-Org-Github"
-	fmt.Println("getting function", functionName)
+	log.Println("getting function", functionName)
 
-	startTime, endTime, err := parseTimeFlags(startTimeStr, endTimeStr)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
 		return "", nil, err
 	}
 
 	cloudwatchMetricData := make(map[string]*cloudwatch.GetMetricDataOutput)
 
-	rawData, err := GetLambdaMemoryMetricData(clientAuth, startTime, endTime, cloudWatchClient, functionName)
+	rawData, err := GetLambdaMemoryMetricData(req.ClientAuth, *startTime, *endTime, cloudWatchClient, functionName)
 	if err != nil {
 		return "", nil, err
 	}
@@ -75,12 +47,12 @@ func GetLambdaUnusedMemoryPanel(cmd *cobra.Command, clientAuth *model.Auth, clou
 
 	jsonString, err := json.Marshal(processedDataList)
 	if err != nil {
-		log.Fatalf("Error marshalling JSON response: %v", err)
+		return "", nil, fmt.Errorf("error marshalling JSON response: %w", err)
 	}
 	return string(jsonString), &cloudwatchMetricData, nil
 }
 
-func GetLambdaMemoryMetricData(clientAuth *model.Auth, startTime, endTime time.Time, cloudWatchClient *cloudwatch.CloudWatch, functionName string) (*cloudwatch.GetMetricDataOutput, error) {
+func GetLambdaMemoryMetricData(clientAuth *model.Auth, startTime, endTime time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI, functionName string) (*cloudwatch.GetMetricDataOutput, error) {
 	input := &cloudwatch.GetMetricDataInput{
 		StartTime: &startTime,
 		EndTime:   &endTime,
@@ -123,7 +95,7 @@ func GetLambdaMemoryMetricData(clientAuth *model.Auth, startTime, endTime time.T
 	}
 
 	if cloudWatchClient == nil {
-	cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+	cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 	result, err := cloudWatchClient.GetMetricData(input)
 	if err != nil {
@@ -162,31 +134,6 @@ func processMemoryRawData(result *cloudwatch.GetMetricDataOutput, functionName s
 }
 
 
-func parseTimeFlags(startTimeStr, endTimeStr string) (time.Time, time.Time, error) {
-	var startTime, endTime time.Time
-	var err error
-
-	if startTimeStr != "" {
-		startTime, err = time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			return time.Time{}, time.Time{}, err
-		}
-	} else {
-		startTime = time.Now().Add(-5 * time.Minute)
-	}
-
-	if endTimeStr != "" {
-		endTime, err = time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			return time.Time{}, time.Time{}, err
-		}
-	} else {
-		endTime = time.Now()
-	}
-
-	return startTime, endTime, nil
-}
-
 func init() {
 	LambdaMemoryMetricsCmd.PersistentFlags().String("startTime", "", "Start time")
 	LambdaMemoryMetricsCmd.PersistentFlags().String("endTime", "", "End time")
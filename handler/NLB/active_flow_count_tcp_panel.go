@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,45 +14,17 @@ var AwsxNLBActiveFlowCountTCPCmd = &cobra.Command{
 	Use:   "active_flow_count_tcp_panel",
 	Short: "Get NLB active flow count TCP",
 	Long:  `Command to get NLB active flow count TCP`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command..")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNLBActiveFlowCountTCP(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting NLB active flow count TCP data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetNLBActiveFlowCountTCP(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetNLBActiveFlowCountTCP(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data
-	rawData, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/NetworkELB", "ActiveFlowCount_TCP", startTime, endTime, "Average", "LoadBalancer", cloudWatchClient)
+	rawData, err := comman_function.GetMetricData(req, instanceId, "AWS/NetworkELB", "ActiveFlowCount_TCP", startTime, endTime, "Average", "LoadBalancer", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting NLB active flow count TCP data: ", err)
 		return "", nil, err
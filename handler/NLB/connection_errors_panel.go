@@ -2,16 +2,15 @@ package NLB
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"sort"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -28,71 +27,26 @@ var AwsxNLBConnectionErrorsCmd = &cobra.Command{
 	Use:   "nlb_connection_errors_panel",
 	Short: "Get NLB connection errors metrics data",
 	Long:  `Command to get NLB connection errors metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNLBConnectionErrorsData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting NLB connection errors: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				fmt.Println(jsonResp)
-			}
-		}
-	},
 }
 
-func GetNLBConnectionErrorsData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	loadBalancerArn, _ := cmd.PersistentFlags().GetString("loadBalancerArn")
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
+func GetNLBConnectionErrorsData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	loadBalancerArn := req.LoadBalancerArn
 
-	var startTime, endTime *time.Time
+	startTime, endTime := req.StartTime, req.EndTime
 
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
+	// Default start time if not provided
+	if startTime == nil {
 		defaultStartTime := time.Now().Add(-1 * time.Hour) // Default to 1 hour ago
 		startTime = &defaultStartTime
 	}
 
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
+	if endTime == nil {
 		defaultEndTime := time.Now()
 		endTime = &defaultEndTime
 	}
 
-	// Debug prints
-	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
-
 	// Fetch raw data
-	rawData, err := GetNLBConnectionErrorsMetricData(clientAuth, loadBalancerArn, startTime, endTime, cloudWatchClient)
+	rawData, err := GetNLBConnectionErrorsMetricData(req.ClientAuth, loadBalancerArn, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting raw data: ", err)
 		return "", nil, err
@@ -138,7 +92,7 @@ func GetNLBConnectionErrorsData(cmd *cobra.Command, clientAuth *model.Auth, clou
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetNLBConnectionErrorsMetricData(clientAuth *model.Auth, loadBalancerArn string, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetNLBConnectionErrorsMetricData(clientAuth *model.Auth, loadBalancerArn string, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	input := &cloudwatch.GetMetricDataInput{
 		EndTime:   endTime,
 		StartTime: startTime,
@@ -198,7 +152,7 @@ func GetNLBConnectionErrorsMetricData(clientAuth *model.Auth, loadBalancerArn st
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -0,0 +1,191 @@
+package NLB
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var nlbElementTypes = []string{"AWS/NetworkELB", "AWS/NLB"}
+
+func init() {
+	comman_function.RegisterIdentifier(nlbElementTypes, comman_function.IdentifierLoadBalancer)
+	comman_function.RegisterPanels(nlbElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "error_log_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetNLBErrorLogData, ErrorLogRow{}),
+		},
+		{
+			Name:          "active_flow_count_tcp_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetNLBActiveFlowCountTCP),
+		},
+		{
+			Name:          "target_health_check_configuration_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetNLBTargetHealthCheckData, TargetHealthCheckRow{}),
+		},
+		{
+			Name:          "target_health_check_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxNLBTargetHealthChecksCmd,
+			Panel:         comman_function.MetricPanel(GetNLBTargetHealthCheckPanel),
+		},
+		{
+			Name:          "target_status_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				targetStatuses, jsonResp, err := GetTargetStatussPanel(req)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: targetStatuses}, err
+			}),
+		},
+		{
+			Name:          "target_tls_negotiation_error_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetTargetTlsErrorCountData),
+		},
+		{
+			Name:          "port_allocation_error_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetPortAllocationErrorCountData),
+		},
+		{
+			Name:          "target_error_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetTargetErrorCountData),
+		},
+		{
+			Name:          "security_group_configuration_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				securityGroups, jsonResp, err := GetSecurityGroupConfigurations(req.ClientAuth)
+				return &comman_function.PanelResult{Json: jsonResp, Frame: securityGroups}, err
+			}),
+		},
+		{
+			Name:          "target_deregistrations_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetTargetDeregistrationspanel, TargetDeregistrationRow{}),
+		},
+		{
+			Name:          "connection_errors_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetNLBConnectionErrorsData),
+		},
+		{
+			Name:          "active_connections_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxNLBActiveConnectionsCmd,
+			Panel:         comman_function.MetricPanel(GetNLBActiveConnectionsPanel),
+		},
+		{
+			Name:          "new_connections_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxNLBNewConnectionsCmd,
+			Panel:         comman_function.MetricPanel(GetNLBNewConnectionsPanel),
+		},
+		{
+			Name:          "processed_bytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxNLBProcessedBytesCmd,
+			Panel:         comman_function.MetricPanel(GetNLBProcessedBytesPanel),
+		},
+		{
+			Name:          "healthy_host_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxNLBHealthyHostCountCmd,
+			Panel:         comman_function.MetricPanel(GetNLBHealthyHostCountPanel),
+		},
+		{
+			Name:          "unhealthy_host_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxNLBUnhealthyHostCountCmd,
+			Panel:         comman_function.MetricPanel(GetNLBUnhealthyHostCountPanel),
+		},
+		{
+			Name:          "new_flow_count_tls_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetNLBNewFlowCountTLSPanel),
+		},
+		{
+			Name:          "processed_packets_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetNLBProcessedPacketsPanel),
+		},
+		{
+			Name:          "tcp_target_reset_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetNLBTCPResetCountPanel),
+		},
+		{
+			Name:          "tcp_client_reset_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxNLBTCPClientResetCountCmd,
+			Panel:         comman_function.MetricPanel(GetNLBTCPClientResetCountPanel),
+		},
+		{
+			Name:          "tcp_elb_reset_count_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxNLBTCPElbResetCountCmd,
+			Panel:         comman_function.MetricPanel(GetNLBTCPElbResetCountPanel),
+		},
+		{
+			Name:          "new_flow_count_tcp_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxNLBNewFlowTCPCountCmd,
+			Panel:         comman_function.MetricPanel(GetNLBNewFlowTCPCountPanel),
+		},
+		{
+			Name:          "tls_new_connection_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetNLBTlsNewConnectionPanel),
+		},
+		{
+			Name:          "tls_active_connection_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetNLBTlsActiveConnection),
+		},
+		{
+			Name:          "tcp_procesed_bytes_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.MetricPanel(GetNLBTcpProcesedBytes),
+		},
+		{
+			Name:          "loadbalancer_count_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Panel:         comman_function.TypedLogsPanel(GetNLBCount, LoadBalancerCountRow{}),
+		},
+		{
+			Name:          "ssl_tls_negotiation_time_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxNLBSSLTLSNegotiationCmd,
+			Panel:         comman_function.MetricPanel(GetSSLTLSNegotiationDataData),
+		},
+	})
+}
@@ -3,12 +3,10 @@ package NLB
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"strings"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -24,29 +22,6 @@ var AwsxSecurityGroupCmd = &cobra.Command{
 	Use:   "security_group_panel",
 	Short: "Retrieve security group configurations",
 	Long:  `Command to retrieve security group configurations`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		authFlag, clientAuth, err := handleAuth(cmd)
-		if err != nil {
-			log.Println("Error during authentication:", err)
-			return
-		}
-
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			securityGroups, printresp, err := GetSecurityGroupConfigurations(clientAuth)
-			if err != nil {
-				log.Println("Error getting security group configurations:", err)
-				return
-			}
-
-			if responseType == "frame" {
-				fmt.Println(securityGroups)
-			} else {
-				fmt.Println(printresp)
-			}
-		}
-	},
 }
 
 func GetSecurityGroupConfigurations(clientAuth *model.Auth) ([]SecurityGroupInfo, string, error) {
@@ -61,13 +36,13 @@ func GetSecurityGroupConfigurations(clientAuth *model.Auth) ([]SecurityGroupInfo
 
 func DescribeSecurityGroups(clientAuth *model.Auth) ([]SecurityGroupInfo, error) {
 	// Use existing AWS client
-	svc := awsclient.GetClient(*clientAuth, awsclient.EC2_CLIENT).(*ec2.EC2)
+	svc := comman_function.EC2Client(*clientAuth)
 
 	// Describe security groups
 	describeSGInput := &ec2.DescribeSecurityGroupsInput{}
 	describeSGOutput, err := svc.DescribeSecurityGroups(describeSGInput)
 	if err != nil {
-		log.Fatalf("Error describing security groups: %v", err)
+		return nil, fmt.Errorf("error describing security groups: %w", err)
 	}
 
 	// Retrieve security group configurations
@@ -114,14 +89,6 @@ func formatRuleList(rules []string) string {
 	return strings.Join(rules, "\n")
 }
 
-func handleAuth(cmd *cobra.Command) (bool, *model.Auth, error) {
-	authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-	if err != nil {
-		return false, nil, err
-	}
-	return authFlag, clientAuth, nil
-}
-
 func printSecurityGroups(securityGroups []SecurityGroupInfo) string {
 	var buffer bytes.Buffer
 	table := tablewriter.NewWriter(&buffer)
@@ -6,14 +6,13 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-
 	// "github.com/Appkube-awsx/awsx-common/cmdb"
 	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -30,43 +29,14 @@ var AwsxNLBSSLTLSNegotiationCmd = &cobra.Command{
 	Use:   "nlb_ssl_tls_negotiation_panel",
 	Short: "Get NLB SSL/TLS negotiation time metrics data",
 	Long:  `Command to get NLB SSL/TLS negotiation time metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command..")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			rawData, calculatedData, err := GetSSLTLSNegotiationDataData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting NLB SSL/TLS negotiation time metrics: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(calculatedData)
-			} else {
-				fmt.Println(rawData)
-			}
-		}
-
-	},
 }
 
-func GetSSLTLSNegotiationDataData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	lbID, _ := cmd.PersistentFlags().GetString("lbID")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
+func GetSSLTLSNegotiationDataData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementId := req.ElementId
+	lbID := req.LoadBalancerArn
+	cmdbApiUrl := req.CmdbApiUrl
 	// instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
+	elementType := req.ElementType
 
 	if elementId != "" {
 		log.Println("getting cloud-element data from cmdb")
@@ -80,38 +50,13 @@ func GetSSLTLSNegotiationDataData(cmd *cobra.Command, clientAuth *model.Auth, cl
 
 	}
 
-	var startTime, endTime *time.Time
-
-	// Parse start time if provided
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	// Debug prints
-	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
-
 	// Fetch raw data
-	rawData, err := GetSSLTLSNegotiationDataMetricData(clientAuth, lbID, elementType, startTime, endTime, cloudWatchClient)
+	rawData, err := GetSSLTLSNegotiationDataMetricData(req.ClientAuth, lbID, elementType, startTime, endTime, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting raw data: ", err)
 		return "", nil, err
@@ -158,7 +103,7 @@ func GetSSLTLSNegotiationDataData(cmd *cobra.Command, clientAuth *model.Auth, cl
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetSSLTLSNegotiationDataMetricData(clientAuth *model.Auth, lbID, elementType string, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetSSLTLSNegotiationDataMetricData(clientAuth *model.Auth, lbID, elementType string, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 
 	log.Printf("Getting SSL/TLS negotiation metric data for NLB %s from %v to %v", lbID, startTime, endTime)
 
@@ -205,7 +150,7 @@ func GetSSLTLSNegotiationDataMetricData(clientAuth *model.Auth, lbID, elementTyp
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 	result, err := cloudWatchClient.GetMetricData(input)
 	if err != nil {
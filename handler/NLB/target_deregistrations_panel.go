@@ -2,12 +2,11 @@ package NLB
 
 import (
 	"fmt"
-	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	 "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,51 +14,31 @@ var AwsxTargetDeregistrationsCmd = &cobra.Command{
 	Use:   "target_deregistration_panel",
 	Short: "Get target deregistration panel",
 	Long:  `Command to retrieve target deregistration panel`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running target deregistration command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetTargetDeregistrationspanel(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-
-		}
-	},
+// TargetDeregistrationRow is one row of the target_deregistrations_panel query.
+type TargetDeregistrationRow struct {
+	Time                      time.Time `json:"time" logs:"@timestamp"`
+	DeregistrationTargetCount int64     `json:"deregistrationTargetCount" logs:"DeregistrationTargetCount"`
 }
 
-func GetTargetDeregistrationspanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetTargetDeregistrationspanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 		if err != nil {
-			return nil, fmt.Errorf("Error parsing time: %v", err)
+			return nil, fmt.Errorf("Error parsing time: %w", err)
 		}
-		logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+		logGroupName, err = comman_function.GetCmdbLogsData(req)
 
 	
 		if err != nil {
-			return nil, fmt.Errorf("error getting instance ID: %v", err)
+			return nil, fmt.Errorf("error getting instance ID: %w", err)
 		}
-		results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp| filter eventSource=="elasticloadbalancing.amazonaws.com"| filter eventName=="DeregisterTargets"| stats count(*) as DeregistrationTargetCount by @timestamp| sort @timestamp desc`, cloudWatchLogs)
+		results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp| filter eventSource=="elasticloadbalancing.amazonaws.com"| filter eventName=="DeregisterTargets"| stats count(*) as DeregistrationTargetCount by @timestamp| sort @timestamp desc`, cloudWatchLogs)
 		
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := comman_function.ProcessQueryResult(results)
 
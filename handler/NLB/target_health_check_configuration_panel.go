@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,46 +15,32 @@ var AwsxNLBTargetHealthCheckCmd = &cobra.Command{
 	Use:   "target_health_check_configuration_panel",
 	Short: "Get target health check configuration logs data",
 	Long:  `Command to get target health check configuration logs data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running target health check configuration panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetNLBTargetHealthCheckData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// TargetHealthCheckRow is one row of the target_health_check_configuration_panel query.
+type TargetHealthCheckRow struct {
+	HealthCheckProtocol        string `json:"healthCheckProtocol" logs:"responseElements.targetGroups.0.healthCheckProtocol"`
+	HealthCheckPort            string `json:"healthCheckPort" logs:"responseElements.targetGroups.0.healthCheckPort"`
+	HealthCheckPath            string `json:"healthCheckPath" logs:"responseElements.targetGroups.0.healthCheckPath"`
+	HealthCheckTimeoutSeconds  int64  `json:"healthCheckTimeoutSeconds" logs:"responseElements.targetGroups.0.healthCheckTimeoutSeconds"`
+	HealthCheckIntervalSeconds int64  `json:"healthCheckIntervalSeconds" logs:"responseElements.targetGroups.0.healthCheckIntervalSeconds"`
+	UnhealthyThresholdCount    int64  `json:"unhealthyThresholdCount" logs:"responseElements.targetGroups.0.unhealthyThresholdCount"`
+	HealthyThresholdCount      int64  `json:"healthyThresholdCount" logs:"responseElements.targetGroups.0.healthyThresholdCount"`
 }
 
-func GetNLBTargetHealthCheckData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetNLBTargetHealthCheckData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp| filter eventSource = "elasticloadbalancing.amazonaws.com"| filter eventName= "CreateTargetGroup"| display responseElements.targetGroups.0.healthCheckProtocol,responseElements.targetGroups.0.healthCheckPort,responseElements.targetGroups.0.healthCheckPath,responseElements.targetGroups.0.healthCheckTimeoutSeconds,responseElements.targetGroups.0.healthCheckIntervalSeconds,responseElements.targetGroups.0.unhealthyThresholdCount,responseElements.targetGroups.0.healthyThresholdCount`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp| filter eventSource = "elasticloadbalancing.amazonaws.com"| filter eventName= "CreateTargetGroup"| display responseElements.targetGroups.0.healthCheckProtocol,responseElements.targetGroups.0.healthCheckPort,responseElements.targetGroups.0.healthCheckPath,responseElements.targetGroups.0.healthCheckTimeoutSeconds,responseElements.targetGroups.0.healthCheckIntervalSeconds,responseElements.targetGroups.0.unhealthyThresholdCount,responseElements.targetGroups.0.healthyThresholdCount`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQueryResult(results)
 
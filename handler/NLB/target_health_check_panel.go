@@ -6,13 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/cmdb"
-	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -27,86 +25,20 @@ var AwsxNLBTargetHealthChecksCmd = &cobra.Command{
 	Use:   "nlb_target_health_checks_panel",
 	Short: "Get NLB target health checks metrics data",
 	Long:  `Command to get NLB target health checks metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command..")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetNLBTargetHealthCheckPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting NLB target health check data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// Default case. It prints JSON
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetNLBTargetHealthCheckPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+func GetNLBTargetHealthCheckPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
 	//nlbArn, _ := cmd.PersistentFlags().GetString("nlbArn")
 
-	startTimeStr, _ := cmd.PersistentFlags().GetString("startTime")
-	endTimeStr, _ := cmd.PersistentFlags().GetString("endTime")
-
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
 	//elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-
-	if elementId != "" {
-		log.Println("getting cloud-element data from cmdb")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("using default cmdb url")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("cmdb url: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", nil, err
-		}
-		instanceId = cmdbData.InstanceId
 
+	instanceId, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", nil, err
 	}
-	var startTime, endTime *time.Time
-
-	if startTimeStr != "" {
-		parsedStartTime, err := time.Parse(time.RFC3339, startTimeStr)
-		if err != nil {
-			log.Printf("Error parsing start time: %v", err)
-			return "", nil, err
-		}
-		startTime = &parsedStartTime
-	} else {
-		defaultStartTime := time.Now().Add(-5 * time.Minute)
-		startTime = &defaultStartTime
-	}
-
-	if endTimeStr != "" {
-		parsedEndTime, err := time.Parse(time.RFC3339, endTimeStr)
-		if err != nil {
-			log.Printf("Error parsing end time: %v", err)
-			return "", nil, err
-		}
-		endTime = &parsedEndTime
-	} else {
-		defaultEndTime := time.Now()
-		endTime = &defaultEndTime
+	startTime, endTime, err := comman_function.ParseTimes(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
@@ -114,7 +46,7 @@ func GetNLBTargetHealthCheckPanel(cmd *cobra.Command, clientAuth *model.Auth, cl
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data for successful host count
-	successfulRawData, err := GetNLBTargetHealthCheckMetricData(clientAuth, instanceId, startTime, endTime, "HealthyHostCount", "Average", cloudWatchClient)
+	successfulRawData, err := GetNLBTargetHealthCheckMetricData(req.ClientAuth, instanceId, startTime, endTime, "HealthyHostCount", "Average", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting NLB successful target health check data: ", err)
 		return "", nil, err
@@ -122,7 +54,7 @@ func GetNLBTargetHealthCheckPanel(cmd *cobra.Command, clientAuth *model.Auth, cl
 	cloudwatchMetricData["Successful"] = successfulRawData
 
 	// Fetch raw data for failed host count
-	failedRawData, err := GetNLBTargetHealthCheckMetricData(clientAuth, instanceId, startTime, endTime, "UnHealthyHostCount", "Average", cloudWatchClient)
+	failedRawData, err := GetNLBTargetHealthCheckMetricData(req.ClientAuth, instanceId, startTime, endTime, "UnHealthyHostCount", "Average", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting NLB failed target health check data: ", err)
 		return "", nil, err
@@ -151,7 +83,7 @@ func GetNLBTargetHealthCheckPanel(cmd *cobra.Command, clientAuth *model.Auth, cl
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetNLBTargetHealthCheckMetricData(clientAuth *model.Auth, instanceId string, startTime, endTime *time.Time, metricName, statistic string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetNLBTargetHealthCheckMetricData(clientAuth *model.Auth, instanceId string, startTime, endTime *time.Time, metricName, statistic string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for NLB %s from %v to %v", instanceId, startTime, endTime)
 
 	input := &cloudwatch.GetMetricDataInput{
@@ -182,7 +114,7 @@ func GetNLBTargetHealthCheckMetricData(clientAuth *model.Auth, instanceId string
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -2,13 +2,13 @@ package NLB
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -25,34 +25,11 @@ var AwsxNLBTargetStatussCmd = &cobra.Command{
 	Use:   "new_target_status_panel",
 	Short: "Retrieve target status metrics data",
 	Long:  `Command to retrieve target status metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		authFlag, clientAuth, err := handleAuths(cmd)
-		if err != nil {
-			log.Println("Error during authentication:", err)
-			return
-		}
-
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			targetStatuses, printresp, err := GetTargetStatussPanel(clientAuth)
-			if err != nil {
-				log.Println("Error getting target status:", err)
-				return
-			}
-
-			if responseType == "frame" {
-				fmt.Println(targetStatuses)
-			} else {
-				fmt.Println(printresp)
-			}
-		}
-	},
 }
 
-func GetTargetStatussPanel(clientAuth *model.Auth) ([]TargetStatuss, string, error) {
+func GetTargetStatussPanel(req *comman_function.PanelRequest) ([]TargetStatuss, string, error) {
 	// Retrieve target status from AWS NLB target groups
-	targetStatuses, err := GetNLBTargetStatus(clientAuth)
+	targetStatuses, err := GetNLBTargetStatus(req)
 	if err != nil {
 		return nil, "", err
 	}
@@ -60,28 +37,27 @@ func GetTargetStatussPanel(clientAuth *model.Auth) ([]TargetStatuss, string, err
 	return targetStatuses, formattedTable, nil
 }
 
-func GetNLBTargetStatus(clientAuth *model.Auth) ([]TargetStatuss, error) {
+func GetNLBTargetStatus(req *comman_function.PanelRequest) ([]TargetStatuss, error) {
 	// Use existing AWS client
-	svc := awsclient.GetClient(*clientAuth, awsclient.ELBV2_CLIENT).(*elbv2.ELBV2)
+	svc := comman_function.ELBV2Client(*req.ClientAuth)
 
 	// Describe NLB target groups
-	targetGroupsOutput, err := svc.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{})
+	targetGroupsOutput, err := svc.DescribeTargetGroupsWithContext(req.Context(), &elbv2.DescribeTargetGroupsInput{})
 	if err != nil {
-		log.Fatalf("Error describing target groups: %v", err)
+		return nil, fmt.Errorf("error describing target groups: %w", err)
 	}
 
 	// Retrieve target status for each target group
-	var targetStatuses []TargetStatuss
-	for _, tg := range targetGroupsOutput.TargetGroups {
-		targetHealthOutput, err := svc.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+	groupStatuses, err := comman_function.FanOut(req, targetGroupsOutput.TargetGroups, func(ctx context.Context, tg *elbv2.TargetGroup) ([]TargetStatuss, error) {
+		targetHealthOutput, err := svc.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{
 			TargetGroupArn: tg.TargetGroupArn,
 		})
 		if err != nil {
-			log.Printf("Error describing target health for target group %s: %v", *tg.TargetGroupName, err)
-			continue
+			return nil, fmt.Errorf("target group %s: %w", aws.StringValue(tg.TargetGroupName), err)
 		}
 
 		// Extract relevant information from the response and construct TargetStatus objects
+		var targetStatuses []TargetStatuss
 		for _, healthDescription := range targetHealthOutput.TargetHealthDescriptions {
 			reason := ""
 			if healthDescription.TargetHealth.Reason != nil {
@@ -105,17 +81,20 @@ func GetNLBTargetStatus(clientAuth *model.Auth) ([]TargetStatuss, error) {
 
 			targetStatuses = append(targetStatuses, targetStatus)
 		}
+		return targetStatuses, nil
+	})
+	if err != nil {
+		if !comman_function.IsPartial(err) {
+			return nil, err
+		}
+		log.Printf("Error describing target health of target groups: %v", err)
 	}
 
-	return targetStatuses, nil
-}
-
-func handleAuths(cmd *cobra.Command) (bool, *model.Auth, error) {
-	authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-	if err != nil {
-		return false, nil, err
+	var targetStatuses []TargetStatuss
+	for _, statuses := range groupStatuses {
+		targetStatuses = append(targetStatuses, statuses...)
 	}
-	return authFlag, clientAuth, nil
+	return targetStatuses, nil
 }
 
 func printTables(targetStatuses []TargetStatuss) string {
@@ -145,6 +124,7 @@ func printTables(targetStatuses []TargetStatuss) string {
 
 func init() {
 	AwsxNLBTargetStatussCmd.PersistentFlags().String("responseType", "", "response type. json/frame")
+	AwsxNLBTargetStatussCmd.PersistentFlags().String("concurrency", "", "most aws calls a panel makes at once")
 }
 
 func getRegionFromARN(arn string) string {
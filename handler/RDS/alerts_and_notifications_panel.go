@@ -7,9 +7,6 @@ import (
 
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
-
 	// "github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
@@ -24,47 +21,16 @@ var RdsAlarmandNotificationcmd = &cobra.Command{
 	Use:   "rds_alerts_and_notifications_panel",
 	Short: "Retrieve recent alerts and notifications related to RDS instance availability",
 	Long:  `Command to retrieve recent alerts and notifications related to RDS instance availability`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		authFlag, clientAuth, err := handleAuth(cmd)
-		if err != nil {
-			log.Println("Error during authentication:", err)
-			return
-		}
-
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			notifications, err := GetAlertsAndNotificationsPanell(cmd, clientAuth)
-			if err != nil {
-				log.Println("Error getting alerts and notifications:", err)
-				return
-			}
-
-			if responseType == "frame" {
-				fmt.Println(notifications)
-			} else {
-				//printTable(notifications)
-			}
-		}
-	},
-}
-
-func handleAuth(cmd *cobra.Command) (bool, *model.Auth, error) {
-	authFlag, clientAuth, err := authenticate.AuthenticateCommand(cmd)
-	if err != nil {
-		return false, nil, err
-	}
-	return authFlag, clientAuth, nil
 }
 
-func GetAlertsAndNotificationsPanell(cmd *cobra.Command, clientAuth *model.Auth) ([]AlarmNotification, error) {
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetAlertsAndNotificationsPanell(req *comman_function.PanelRequest) ([]AlarmNotification, error) {
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	// Retrieve CloudWatch alarms
-	alarms, err := comman_function.GetCloudWatchAlarms(clientAuth, startTime, endTime)
+	alarms, err := comman_function.GetCloudWatchAlarms(req.ClientAuth, startTime, endTime)
 	if err != nil {
 		log.Println("Error getting CloudWatch alarms:", err)
 		return nil, err
@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -21,58 +20,28 @@ import (
 
 // Define a CLI command to get CPU Surplus Credit Balance for RDS instances
 var AwsxRDSSurplusCreditsChargedCmd = &cobra.Command{
-	Use:   "cpu_surplus_credit_balance",
-	Short: "Get CPU Surplus Credit Balance metrics data for RDS instances",
-	Long:  `Command to get CPU Surplus Credit Balance metrics data for RDS instances`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetCPUSurplusCreditCharged(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting CPU Surplus Credit Balance: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// Default case. It prints JSON.
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
+	Use:   "cpu_surplus_credits_charged_panel",
+	Short: "Get CPU Surplus Credits Charged metrics data for RDS instances",
+	Long:  `Command to get CPU Surplus Credits Charged metrics data for RDS instances`,
 }
 
 // Function to get CPU Surplus Credit Balance metrics data
-func GetCPUSurplusCreditCharged(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+func GetCPUSurplusCreditCharged(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	instanceId := req.InstanceId
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
-	rawData, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/RDS", "CPUSurplusCreditsCharged", startTime, endTime, "Average", "DBInstanceIdentifier", cloudWatchClient)
+	rawData, err := comman_function.GetMetricData(req, instanceId, "AWS/RDS", "CPUSurplusCreditsCharged", startTime, endTime, "Average", "DBInstanceIdentifier", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting cpu surplus credits charged  data: ", err)
 		return "", nil, err
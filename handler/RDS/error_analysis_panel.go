@@ -2,12 +2,11 @@ package RDS
 
 import (
 	"fmt"
-	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	 "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -24,51 +23,34 @@ var AwsxRDSErrorAnalysisCmd = &cobra.Command{
 	Use:   "error_analysis_panel",
 	Short: "Get error analysis panel for RDS instances",
 	Long:  `Command to retrieve error analysis panel for RDS instances`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running error analysis panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-
-		if authFlag {
-			data, err := GetErrorAnalysisData(cmd, clientAuth, nil)
-			if err != nil {
+}
 
-				return
-			}
-			fmt.Println(data)
-		}
-	},
+// ErrorAnalysisRow is one row of the error_analysis_panel query.
+type ErrorAnalysisRow struct {
+	EventTime    time.Time `json:"eventTime" logs:"eventTime"`
+	EventName    string    `json:"eventName" logs:"eventName"`
+	ErrorMessage string    `json:"errorMessage" logs:"errorMessage"`
+	ErrorCount   int64     `json:"errorCount" logs:"errorCode"`
 }
 
 // Function to retrieve error analysis panel data
-func GetErrorAnalysisData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+func GetErrorAnalysisData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
 	// Retrieve necessary parameters from command flags
 
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing time: %v", err)
+		return nil, fmt.Errorf("Error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventType, eventSource, errorCode, errorMessage| filter eventSource = 'rds.amazonaws.com' | filter ispresent(responseElements) or ispresent(errorCode)| stats count(errorMessage) as errorCode by eventTime,errorMessage,eventName`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, eventType, eventSource, errorCode, errorMessage| filter eventSource = 'rds.amazonaws.com' | filter ispresent(responseElements) or ispresent(errorCode)| stats count(errorMessage) as errorCode by eventTime,errorMessage,eventName`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := comman_function.ProcessQueryResult(results)
 
@@ -1,11 +1,8 @@
 package RDS
 
 import (
-	"fmt"
-	"log"
 	"time"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
 	"github.com/spf13/cobra"
 )
 
@@ -14,31 +11,6 @@ var AwsxDBInstanceHealthCheckCmd = &cobra.Command{
 	Use:   "dbinstance_health_check_panel",
 	Short: "get dbinstance health check metrics data",
 	Long:  `command to get dbinstance status metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-
-		var authFlag, _, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-
-			err := cmd.Help()
-
-			if err != nil {
-				return
-			}
-
-			return
-		}
-		if authFlag {
-			check, err := GetDBInstanceHealthCheck()
-			if err != nil {
-				log.Printf("Error getting instance status: %v", err)
-			}
-			fmt.Println(check)
-		}
-	},
 }
 
 type  InstanceHealthCheckData struct {
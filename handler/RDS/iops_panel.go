@@ -6,12 +6,11 @@ import (
 	"log"
 	"time"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -30,40 +29,12 @@ var AwsxRDSIopsCmd = &cobra.Command{
 	Use:   "iops_panel",
 	Short: "get iops metrics data",
 	Long:  `command to get iops metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err, _ := GetRDSIopsPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting iops data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetRDSIopsPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
+func GetRDSIopsPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementId := req.ElementId
+	elementType := req.ElementType
+	cmdbApiUrl := req.CmdbApiUrl
 
 	if elementId != "" {
 		log.Println("getting cloud-element data from cmdb")
@@ -75,10 +46,10 @@ func GetRDSIopsPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClien
 		log.Println("cmdb url: " + apiUrl)
 	}
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	if err != nil {
-		return "","", nil, fmt.Errorf("error parsing time: %v", err)
+		return "","", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
@@ -86,14 +57,14 @@ func GetRDSIopsPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClien
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data for inbound and outbound metrics separately
-	rawReadIopsData, err := GetIopsMetricData(clientAuth, elementType, startTime, endTime, "ReadIOPS", cloudWatchClient)
+	rawReadIopsData, err := GetIopsMetricData(req.ClientAuth, elementType, startTime, endTime, "ReadIOPS", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting network inbound data: ", err)
 		return "", "", nil, err
 	}
 	cloudwatchMetricData["Read"] = rawReadIopsData
 
-	rawWriteIopsData, err := GetIopsMetricData(clientAuth, elementType, startTime, endTime, "WriteIOPS", cloudWatchClient)
+	rawWriteIopsData, err := GetIopsMetricData(req.ClientAuth, elementType, startTime, endTime, "WriteIOPS", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting network outbound data: ", err)
 		return "", "", nil, err
@@ -118,7 +89,7 @@ func GetRDSIopsPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClien
 	return string(jsonInbound), string(jsonOutbound), cloudwatchMetricData, nil
 }
 
-func GetIopsMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetIopsMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for instance %s in namespace AWS/RDS from %v to %v", elementType, startTime, endTime)
 
 	input := &cloudwatch.GetMetricDataInput{
@@ -140,7 +111,7 @@ func GetIopsMetricData(clientAuth *model.Auth, elementType string, startTime, en
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
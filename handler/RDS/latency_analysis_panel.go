@@ -7,12 +7,11 @@ import (
 	"sort"
 	"time"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -25,40 +24,12 @@ var AwsxRDSLatencyAnalysisCmd = &cobra.Command{
 	Use:   "latency_analysis_panel",
 	Short: "get latency analysis data",
 	Long:  `command to get latency analysis data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetRDSLatencyAnalysisData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting latency analysis data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetRDSLatencyAnalysisData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-    elementId, _ := cmd.PersistentFlags().GetString("elementId")
-    elementType, _ := cmd.PersistentFlags().GetString("elementType")
-    cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
+func GetRDSLatencyAnalysisData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+    elementId := req.ElementId
+    elementType := req.ElementType
+    cmdbApiUrl := req.CmdbApiUrl
 
     if elementId != "" {
         log.Println("getting cloud-element data from cmdb")
@@ -70,15 +41,15 @@ func GetRDSLatencyAnalysisData(cmd *cobra.Command, clientAuth *model.Auth, cloud
         log.Println("cmdb url: " + apiUrl)
     }
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
     log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
 
-    rawLatencyData, err := GetMetricDatas(clientAuth, elementType, startTime, endTime, cloudWatchClient)
+    rawLatencyData, err := GetMetricDatas(req.ClientAuth, elementType, startTime, endTime, cloudWatchClient)
     if err != nil {
         log.Println("Error in getting latency data: ", err)
         return "", nil, err
@@ -117,7 +88,7 @@ func GetRDSLatencyAnalysisData(cmd *cobra.Command, clientAuth *model.Auth, cloud
 }
 
 
-func GetMetricDatas(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetMetricDatas(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for elementType %s in namespace AWS/RDS from %v to %v", elementType, startTime, endTime)
 
 	input := &cloudwatch.GetMetricDataInput{
@@ -149,7 +120,7 @@ func GetMetricDatas(clientAuth *model.Auth, elementType string, startTime, endTi
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
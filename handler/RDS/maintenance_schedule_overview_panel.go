@@ -19,12 +19,6 @@ type ScheduleOverview struct {
 var ListScheduleOverviewCmd = &cobra.Command{
 	Use:   "ListScheduleOverview",
 	Short: "List schedule overview",
-	Run: func(cmd *cobra.Command, args []string) {
-		_, err := ListScheduleOverview()
-		if err != nil {
-			return
-		}
-	},
 }
 
 func ListScheduleOverview() ([]ScheduleOverview, error) {
@@ -7,12 +7,11 @@ import (
 	"time"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -31,40 +30,12 @@ var AwsxRDSNetworkTrafficCmd = &cobra.Command{
 	Use:   "network_traffic_panel",
 	Short: "get network traffic metrics data",
 	Long:  `command to get network traffic metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err, _ := GetRDSNetworkTrafficPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting network traffic data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetRDSNetworkTrafficPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
+func GetRDSNetworkTrafficPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementId := req.ElementId
+	elementType := req.ElementType
+	cmdbApiUrl := req.CmdbApiUrl
 
 	if elementId != "" {
 		log.Println("getting cloud-element data from cmdb")
@@ -76,10 +47,10 @@ func GetRDSNetworkTrafficPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 		log.Println("cmdb url: " + apiUrl)
 	}
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	if err != nil {
-		return "","", nil, fmt.Errorf("error parsing time: %v", err)
+		return "","", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
@@ -87,14 +58,14 @@ func GetRDSNetworkTrafficPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data for inbound and outbound metrics separately
-	rawInboundData, err := GetNetworkMetricData(clientAuth, elementType, startTime, endTime, "NetworkReceiveThroughput", cloudWatchClient)
+	rawInboundData, err := GetNetworkMetricData(req.ClientAuth, elementType, startTime, endTime, "NetworkReceiveThroughput", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting network inbound data: ", err)
 		return "", "", nil, err
 	}
 	cloudwatchMetricData["Inbound Traffic"] = rawInboundData
 
-	rawOutboundData, err := GetNetworkMetricData(clientAuth, elementType, startTime, endTime, "NetworkTransmitThroughput", cloudWatchClient)
+	rawOutboundData, err := GetNetworkMetricData(req.ClientAuth, elementType, startTime, endTime, "NetworkTransmitThroughput", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting network outbound data: ", err)
 		return "", "", nil, err
@@ -119,7 +90,7 @@ func GetRDSNetworkTrafficPanel(cmd *cobra.Command, clientAuth *model.Auth, cloud
 	return string(jsonInbound), string(jsonOutbound), cloudwatchMetricData, nil
 }
 
-func GetNetworkMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetNetworkMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for instance %s in namespace AWS/RDS from %v to %v", elementType, startTime, endTime)
 
 	input := &cloudwatch.GetMetricDataInput{
@@ -141,7 +112,7 @@ func GetNetworkMetricData(clientAuth *model.Auth, elementType string, startTime,
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -6,8 +6,6 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 
 	// "github.com/Appkube-awsx/awsx-common/cmdb"
@@ -15,6 +13,7 @@ import (
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -32,40 +31,12 @@ var AwsxRDSNetworkUtilizationCmd = &cobra.Command{
 	Use:   "network_utilization_panel",
 	Short: "get network utilization metrics data",
 	Long:  `command to get network utilization metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetRDSNetworkUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting network utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetRDSNetworkUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
+func GetRDSNetworkUtilizationPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementId := req.ElementId
+	elementType := req.ElementType
+	cmdbApiUrl := req.CmdbApiUrl
 	// instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
 
 	if elementId != "" {
@@ -84,17 +55,17 @@ func GetRDSNetworkUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, c
 
 	}
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Get Inbound Traffic
-	inboundTraffic, err := GetRDSNetworkUtilizationMetricData(clientAuth, elementType, startTime, endTime, "Average", "NetworkReceiveThroughput", cloudWatchClient)
+	inboundTraffic, err := GetRDSNetworkUtilizationMetricData(req.ClientAuth, elementType, startTime, endTime, "Average", "NetworkReceiveThroughput", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting inbound traffic: ", err)
 		return "", nil, err
@@ -111,7 +82,7 @@ func GetRDSNetworkUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, c
 	cloudwatchMetricData["Network RX"] = createMetricDataOutput(inboundTrafficMegabytes)
 
 	// Get Outbound Traffic
-	outboundTraffic, err := GetRDSNetworkUtilizationMetricData(clientAuth, elementType, startTime, endTime, "Average", "NetworkTransmitThroughput", cloudWatchClient)
+	outboundTraffic, err := GetRDSNetworkUtilizationMetricData(req.ClientAuth, elementType, startTime, endTime, "Average", "NetworkTransmitThroughput", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting outbound traffic: ", err)
 		return "", nil, err
@@ -146,8 +117,8 @@ func GetRDSNetworkUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, c
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetRDSNetworkUtilizationMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, statistic string, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
-	log.Printf("Getting metric data for instance %s in namespace %s from %v to %v", elementType, startTime, endTime)
+func GetRDSNetworkUtilizationMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, statistic string, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
+	log.Printf("Getting metric data in namespace %s from %v to %v", elementType, startTime, endTime)
 	elmType := "AWS/RDS"
 	if elementType == "RDS" {
 		elmType = "AWS/" + elementType
@@ -176,7 +147,7 @@ func GetRDSNetworkUtilizationMetricData(clientAuth *model.Auth, elementType stri
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -0,0 +1,271 @@
+package RDS
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var rdsElementTypes = []string{"RDS", "AWS/RDS"}
+
+func init() {
+	comman_function.RegisterIdentifier(rdsElementTypes, comman_function.IdentifierDBInstanceIdentifier)
+	comman_function.RegisterPanels(rdsElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "cpu_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxRDSCpuUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetRDSCpuUtilizationPanel),
+		},
+		{
+			Name:          "memory_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxRDSMemoryUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetRDSMemoryUtilizationPanel),
+		},
+		{
+			Name:          "database_connections_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSDatabaseConnectionsCmd,
+			Panel:         comman_function.MetricPanel(GetDatabaseConnectionsPanel),
+		},
+		{
+			Name:          "index_size_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSIndexSizeCmd,
+			Panel:         comman_function.MetricPanel(GetIndexSizePanel),
+		},
+		{
+			Name:          "maintenance_schedule_overview_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				scheduleOverview, err := ListScheduleOverview()
+				return &comman_function.PanelResult{Json: scheduleOverview}, err
+			}),
+		},
+		{
+			Name:          "cpu_credit_usage_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSCPUCreditUsageCmd,
+			Panel:         comman_function.MetricPanel(GetCPUCreditUsagePanel),
+		},
+		{
+			Name:          "storage_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "percent",
+			Command:       AwsxRDSStorageUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetRDSStorageUtilizationPanel),
+		},
+		{
+			Name:          "cpu_credit_balance_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetCPUCreditBalancePanel),
+		},
+		{
+			Name:          "cpu_surplus_credit_balance_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSCPUSurplusCreditBalanceCmd,
+			Panel:         comman_function.MetricPanel(GetCPUSurplusCreditBalance),
+		},
+		{
+			Name:          "cpu_surplus_credits_charged_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSSurplusCreditsChargedCmd,
+			Panel:         comman_function.MetricPanel(GetCPUSurplusCreditCharged),
+		},
+		{
+			Name:          "write_iops_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSWriteIOPSCmd,
+			Panel:         comman_function.MetricPanel(GetRDSWriteIOPSPanel),
+		},
+		{
+			Name:          "read_iops_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSReadIOPSCmd,
+			Panel:         comman_function.MetricPanel(GetRDSReadIOPSPanel),
+		},
+		{
+			Name:          "network_utilization_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "MBs",
+			Command:       AwsxRDSNetworkUtilizationCmd,
+			Panel:         comman_function.MetricPanel(GetRDSNetworkUtilizationPanel),
+		},
+		{
+			Name:          "network_traffic_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSNetworkTrafficCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				inboundResp, _, cloudwatchMetricResp, err := GetRDSNetworkTrafficPanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: inboundResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "instance_health_check_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeStatus,
+			Command:       AwsxDBInstanceHealthCheckCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				instanceHealthChecks, err := GetDBInstanceHealthCheck()
+				return &comman_function.PanelResult{Json: instanceHealthChecks}, err
+			}),
+		},
+		{
+			Name:          "cpu_utilization_graph_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSCpuUtilizationGraphCmd,
+			Panel:         comman_function.MetricPanel(GetRDSCPUUtilizationGraphPanel),
+		},
+		{
+			Name:          "alert_and_notification_panel",
+			ResponseTypes: comman_function.JsonResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, err := GetAlertsAndNotificationsPanell(req)
+				return &comman_function.PanelResult{Json: jsonResp}, err
+			}),
+		},
+		{
+			Name:          "iops_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSIopsCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				readIopsResp, _, cloudwatchMetricResp, err := GetRDSIopsPanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: readIopsResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "freeable_memory_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSFreeableMemoryCmd,
+			Panel:         comman_function.MetricPanel(GetRDSFreeableMemoryPanel),
+		},
+		{
+			Name:          "free_storage_space_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSFreeStorageSpaceCmd,
+			Panel:         comman_function.MetricPanel(GetRDSFreeStorageSpacePanel),
+		},
+		{
+			Name:          "disk_queue_depth_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSDiskQueueDepthCmd,
+			Panel:         comman_function.MetricPanel(GetRDSDiskQueueDepthPanel),
+		},
+		{
+			Name:          "replication_slot_disk_usage",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSReplicationSlotDiskUsageCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, _, cloudwatchMetricResp, err := GetRDSReplicationSlotDiskUsagePanel(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "network_receive_throughput_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSNetworkReceiveThroughputCmd,
+			Panel:         comman_function.MetricPanel(GetRDSNetworkReceiveThroughputPanel),
+		},
+		{
+			Name:          "network_transmit_throughput_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSNetworkTransmitThroughputCmd,
+			Panel:         comman_function.MetricPanel(GetRDSNetworkTransmitThroughputPanel),
+		},
+		{
+			Name:          "database_workload_overview_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSDBLoadCmd,
+			Panel:         comman_function.MetricPanel(GetRDSDBLoadPanel),
+		},
+		{
+			Name:          "db_load_non_cpu_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSDBLoadNonCPUCmd,
+			Panel:         comman_function.MetricPanel(GetRDSDBLoadNonCPU),
+		},
+		{
+			Name:          "db_load_cpu_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSDBLoadCPUCmd,
+			Panel:         comman_function.MetricPanel(GetRDSDBLoadCPU),
+		},
+		{
+			Name:          "latency_analysis_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSLatencyAnalysisCmd,
+			Panel:         comman_function.MetricPanel(GetRDSLatencyAnalysisData),
+		},
+		{
+			Name:          "transaction_logs_generation_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSTransactionLogsGenCmd,
+			Panel:         comman_function.MetricPanel(GetTransactionLogsGenerationPanel),
+		},
+		{
+			Name:          "transaction_logs_disk_usage_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Command:       AwsxRDSTransactionLogsDiskCmd,
+			Panel:         comman_function.MetricPanel(GetTransactionLogsDiskUsagePanel),
+		},
+		{
+			Name:          "recent_error_log_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetRdsErrorLogsPanel, ErrorLogRow{}),
+		},
+		{
+			Name:          "recent_event_log_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Panel:         comman_function.TypedLogsPanel(GetRecentEventLogsPanel, EventLogRow{}),
+		},
+		{
+			Name:          "uptime_percentage",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Command:       AwsxRDSUptimeCmd,
+			Panel: comman_function.PanelFunc(func(req *comman_function.PanelRequest) (*comman_function.PanelResult, error) {
+				jsonResp, cloudwatchMetricResp, err := GetRDSUptimeData(req, req.CloudWatchClient())
+				return &comman_function.PanelResult{Json: jsonResp, Frame: cloudwatchMetricResp}, err
+			}),
+		},
+		{
+			Name:          "error_analysis_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxRDSErrorAnalysisCmd,
+			Panel:         comman_function.TypedLogsPanel(GetErrorAnalysisData, ErrorAnalysisRow{}),
+		},
+	})
+}
@@ -2,12 +2,11 @@ package RDS
 
 import (
 	"fmt"
-	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	 "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,31 +14,6 @@ var AwsxRdsErrorLogsCmd = &cobra.Command{
 	Use:   "rds_error_logs",
 	Short: "Get recent error logs for RDS instances",
 	Long:  `Command to retrieve recent error logs for RDS instances`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-
-		if authFlag {
-			data, err := GetRdsErrorLogsPanel(cmd, clientAuth, nil)
-			if err != nil {
-
-				return
-			}
-			fmt.Println(data)
-		}
-	},
 }
 
 // type RdsErrorLogEntry struct {
@@ -49,23 +23,32 @@ var AwsxRdsErrorLogsCmd = &cobra.Command{
 // 	Description string // No changes here
 // }
 
-func GetRdsErrorLogsPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+// ErrorLogRow is one row of the recent_error_log_panel query.
+type ErrorLogRow struct {
+	Time         time.Time `json:"time" logs:"@timestamp"`
+	EventType    string    `json:"eventType" logs:"eventType"`
+	ErrorCode    string    `json:"errorCode" logs:"errorCode"`
+	ErrorMessage string    `json:"errorMessage" logs:"errorMessage"`
+	Message      string    `json:"message" logs:"@message"`
+}
+
+func GetRdsErrorLogsPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
 
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing time: %v", err)
+		return nil, fmt.Errorf("Error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message, errorCode, eventType, errorMessage| filter eventSource = 'rds.amazonaws.com' | filter ispresent(responseElements) or ispresent(errorCode)| limit 1000`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message, errorCode, eventType, errorMessage| filter eventSource = 'rds.amazonaws.com' | filter ispresent(responseElements) or ispresent(errorCode)| limit 1000`, cloudWatchLogs)
 
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := comman_function.ProcessQueryResult(results)
 
@@ -2,12 +2,11 @@ package RDS
 
 import (
 	"fmt"
-	"log"
+	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	 "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,32 +14,6 @@ var AwsxRecentEventLogsCmd = &cobra.Command{
 	Use:   "recent_event_logs",
 	Short: "Get recent event logs",
 	Long:  `Command to retrieve recent event logs`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running from child command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-
-		if authFlag {
-			data, err := GetRecentEventLogsPanel(cmd, clientAuth, nil)
-			if err != nil {
-				
-				return
-			}
-			fmt.Println(data)
-
-		}
-	},
 }
 
 // type RecentEventLogEntry struct {
@@ -51,24 +24,33 @@ var AwsxRecentEventLogsCmd = &cobra.Command{
 // 	UserAgent       string // No changes here
 // }
 
-func GetRecentEventLogsPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+// EventLogRow is one row of the recent_event_log_panel query.
+type EventLogRow struct {
+	Time            time.Time `json:"time" logs:"@timestamp"`
+	EventName       string    `json:"eventName" logs:"eventName"`
+	SourceIPAddress string    `json:"sourceIPAddress" logs:"sourceIPAddress"`
+	EventSource     string    `json:"eventSource" logs:"eventSource"`
+	UserAgent       string    `json:"userAgent" logs:"userAgent"`
+}
+
+func GetRecentEventLogsPanel(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("Error parsing time: %v", err)
+		return nil, fmt.Errorf("Error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName,`fields @timestamp, eventName, sourceIPAddress, eventSource, userAgent| filter eventSource = 'rds.amazonaws.com' | limit 1000`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName,`fields @timestamp, eventName, sourceIPAddress, eventSource, userAgent| filter eventSource = 'rds.amazonaws.com' | limit 1000`, cloudWatchLogs)
 
 
 	
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 
 	processedResults := comman_function.ProcessQueryResult(results)
@@ -6,12 +6,11 @@ import (
 	"log"
 	"time"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -24,40 +23,12 @@ var AwsxRDSReplicationSlotDiskUsageCmd = &cobra.Command{
 	Use:   "replication_slot_disk_usage_panel",
 	Short: "get replication slot disk usage metrics data",
 	Long:  `command to get replication slot disk usage metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err, _ := GetRDSReplicationSlotDiskUsagePanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting replication slot disk usage data: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetRDSReplicationSlotDiskUsagePanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
+func GetRDSReplicationSlotDiskUsagePanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementId := req.ElementId
+	elementType := req.ElementType
+	cmdbApiUrl := req.CmdbApiUrl
 
 	if elementId != "" {
 		log.Println("getting cloud-element data from cmdb")
@@ -69,9 +40,9 @@ func GetRDSReplicationSlotDiskUsagePanel(cmd *cobra.Command, clientAuth *model.A
 		log.Println("cmdb url: " + apiUrl)
 	}
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "","", nil, fmt.Errorf("Error parsing time: %v", err)
+		return "","", nil, fmt.Errorf("Error parsing time: %w", err)
 	}
 
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
@@ -79,7 +50,7 @@ func GetRDSReplicationSlotDiskUsagePanel(cmd *cobra.Command, clientAuth *model.A
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
 	// Fetch raw data for replication slot disk usage metric
-	rawReplicationSlotDiskUsageData, err := GetReplicationSlotDiskUsageMetricData(clientAuth, elementType, startTime, endTime, "ReplicationSlotDiskUsage", cloudWatchClient)
+	rawReplicationSlotDiskUsageData, err := GetReplicationSlotDiskUsageMetricData(req.ClientAuth, elementType, startTime, endTime, "ReplicationSlotDiskUsage", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting replication slot disk usage data: ", err)
 		return "", "", nil, err
@@ -97,7 +68,7 @@ func GetRDSReplicationSlotDiskUsagePanel(cmd *cobra.Command, clientAuth *model.A
 	return string(jsonReplicationSlotDiskUsage), string(jsonReplicationSlotDiskUsage), cloudwatchMetricData, nil
 }
 
-func GetReplicationSlotDiskUsageMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetReplicationSlotDiskUsageMetricData(clientAuth *model.Auth, elementType string, startTime, endTime *time.Time, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for instance %s in namespace AWS/RDS from %v to %v", elementType, startTime, endTime)
 
 	input := &cloudwatch.GetMetricDataInput{
@@ -119,7 +90,7 @@ func GetReplicationSlotDiskUsageMetricData(clientAuth *model.Auth, elementType s
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -7,13 +7,10 @@ import (
 	"math"
 	"time"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
-	"github.com/Appkube-awsx/awsx-common/cmdb"
-	"github.com/Appkube-awsx/awsx-common/config"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -27,65 +24,23 @@ var AwsxRDSStorageUtilizationCmd = &cobra.Command{
 	Use:   "Storage_utilization_panel",
 	Short: "get storage utilization metrics data",
 	Long:  `command to get storage utilization metrics data`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetRDSStorageUtilizationPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting storage utilization: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetRDSStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementId, _ := cmd.PersistentFlags().GetString("elementId")
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	cmdbApiUrl, _ := cmd.PersistentFlags().GetString("cmdbApiUrl")
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
-
-	if elementId != "" {
-		log.Println("getting cloud-element data from cmdb")
-		apiUrl := cmdbApiUrl
-		if cmdbApiUrl == "" {
-			log.Println("using default cmdb url")
-			apiUrl = config.CmdbUrl
-		}
-		log.Println("cmdb url: " + apiUrl)
-		cmdbData, err := cmdb.GetCloudElementData(apiUrl, elementId)
-		if err != nil {
-			return "", nil, err
-		}
-		instanceId = cmdbData.InstanceId
+func GetRDSStorageUtilizationPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
 
+	instanceId, err := comman_function.GetCmdbData(req)
+	if err != nil {
+		return "", nil, err
 	}
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "",nil, fmt.Errorf("Error parsing time: %v", err)
+		return "",nil, fmt.Errorf("Error parsing time: %w", err)
 	}
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 	// Get Root Volume Utilization
-	rootVolumeUsage, err := GetRDSStorageUtilizationMetricData(clientAuth, instanceId, elementType, startTime, endTime, "Average", "FreeStorageSpace", cloudWatchClient)
+	rootVolumeUsage, err := GetRDSStorageUtilizationMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, "Average", "FreeStorageSpace", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting Root Volume Utilization: ", err)
 		return "", nil, err
@@ -93,7 +48,7 @@ func GetRDSStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, c
 	cloudwatchMetricData["RootVolumeUtilization"] = rootVolumeUsage
 
 	// Get EBS1 Volume Utilization
-	ebs1VolumeUsage, err := GetRDSStorageUtilizationMetricData(clientAuth, instanceId, elementType, startTime, endTime, "Average", "FreeStorageSpace", cloudWatchClient)
+	ebs1VolumeUsage, err := GetRDSStorageUtilizationMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, "Average", "FreeStorageSpace", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting EBS1 Volume Utilization: ", err)
 		return "", nil, err
@@ -101,7 +56,7 @@ func GetRDSStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, c
 	cloudwatchMetricData["EBS1VolumeUtilization"] = ebs1VolumeUsage
 
 	// Get EBS2 Volume Utilization
-	ebs2VolumeUsage, err := GetRDSStorageUtilizationMetricData(clientAuth, instanceId, elementType, startTime, endTime, "Average", "FreeStorageSpace", cloudWatchClient)
+	ebs2VolumeUsage, err := GetRDSStorageUtilizationMetricData(req.ClientAuth, instanceId, elementType, startTime, endTime, "Average", "FreeStorageSpace", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting EBS2 Volume Utilization: ", err)
 		return "", nil, err
@@ -124,7 +79,7 @@ func GetRDSStorageUtilizationPanel(cmd *cobra.Command, clientAuth *model.Auth, c
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetRDSStorageUtilizationMetricData(clientAuth *model.Auth, instanceID, elementType string, startTime, endTime *time.Time, statistic, metricName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetRDSStorageUtilizationMetricData(clientAuth *model.Auth, instanceID, elementType string, startTime, endTime *time.Time, statistic, metricName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for instance %s in namespace %s from %v to %v", instanceID, elementType, startTime, endTime)
 
 	elmType := "AWS/RDS"
@@ -148,7 +103,7 @@ func GetRDSStorageUtilizationMetricData(clientAuth *model.Auth, instanceID, elem
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
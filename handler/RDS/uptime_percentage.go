@@ -6,11 +6,10 @@ import (
 	"log"
 	"time"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -22,39 +21,18 @@ var AwsxRDSUptimeCmd = &cobra.Command{
 	Use:   "rds_uptime_panel",
 	Short: "get uptime metrics data for RDS",
 	Long:  `command to get uptime metrics data for RDS`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			jsonResp, _, err := GetRDSUptimeData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting RDS uptime data: ", err)
-				return
-			}
-			fmt.Println(jsonResp)
-		}
-	},
 }
 
-func GetRDSUptimeData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]string, error) {
+func GetRDSUptimeData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]string, error) {
 	DBInstanceIdentifier := "postgresql"
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("Error parsing time: %v", err)
+		return "", nil, fmt.Errorf("Error parsing time: %w", err)
 	}
 	log.Printf("StartTime: %v, EndTime: %v", startTime, endTime)
 
 	// Fetch raw data
-	totalUptime, totalTime, err := GetDatabaseConnectionsMetricValues(clientAuth, startTime, endTime, DBInstanceIdentifier, cloudWatchClient)
+	totalUptime, totalTime, err := GetDatabaseConnectionsMetricValues(req.ClientAuth, startTime, endTime, DBInstanceIdentifier, cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting database connections metric values: ", err)
 		return "", nil, err
@@ -81,7 +59,7 @@ func GetRDSUptimeData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClie
 	return string(jsonString), timeSeriesData, nil
 }
 
-func GetDatabaseConnectionsMetricValues(clientAuth *model.Auth, startTime, endTime *time.Time, DBInstanceIdentifier string, cloudWatchClient *cloudwatch.CloudWatch) (float64, float64, error) {
+func GetDatabaseConnectionsMetricValues(clientAuth *model.Auth, startTime, endTime *time.Time, DBInstanceIdentifier string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (float64, float64, error) {
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String("AWS/RDS"),
 		MetricName: aws.String("DatabaseConnections"),
@@ -99,7 +77,7 @@ func GetDatabaseConnectionsMetricValues(clientAuth *model.Auth, startTime, endTi
 	}
 
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricStatistics(input)
@@ -6,12 +6,11 @@ import (
 	"log"
 	"time"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/awsclient"
 	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -19,54 +18,26 @@ var AwsxS3DataTransferCmd = &cobra.Command{
 	Use:   "data_transfer_panel",
 	Short: "get data transfer metrics data for s3",
 	Long:  `command to get data transfer metrics data for s3`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command..")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetDataTransferData(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting data transfer: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetDataTransferData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
+func GetDataTransferData(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
 
-	bucketName, _ := cmd.PersistentFlags().GetString("bucketName")
+	bucketName := req.BucketName
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	bucketName, err = comman_function.GetCmdbData(cmd)
+	bucketName, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting bucket Name: %v", err)
+		return "", nil, fmt.Errorf("error getting bucket Name: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 
-	BytesUploadedData, err := GetS3MetricData(clientAuth, bucketName, "AWS/"+elementType, "BytesUploaded", startTime, endTime, "Sum", "BucketName", cloudWatchClient)
+	BytesUploadedData, err := GetS3MetricData(req.ClientAuth, bucketName, "AWS/"+elementType, "BytesUploaded", startTime, endTime, "Sum", "BucketName", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting BytesUploaded data: ", err)
 		return "", nil, err
@@ -79,7 +50,7 @@ func GetDataTransferData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchC
 		log.Println("No data available for BytesUploadedData")
 	}
 
-	BytesDownloadedData, err := GetS3MetricData(clientAuth, bucketName, "AWS/"+elementType, "BytesDownloaded", startTime, endTime, "Sum", "BucketName", cloudWatchClient)
+	BytesDownloadedData, err := GetS3MetricData(req.ClientAuth, bucketName, "AWS/"+elementType, "BytesDownloaded", startTime, endTime, "Sum", "BucketName", cloudWatchClient)
 	if err != nil {
 		log.Println("Error in getting cpu usage idle data: ", err)
 		return "", nil, err
@@ -108,7 +79,7 @@ func GetDataTransferData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchC
 	return string(jsonString), cloudwatchMetricData, nil
 }
 
-func GetS3MetricData(clientAuth *model.Auth, BucketName, elementType string, metricName string, startTime, endTime *time.Time, statistic string, dimensionsName string, cloudWatchClient *cloudwatch.CloudWatch) (*cloudwatch.GetMetricDataOutput, error) {
+func GetS3MetricData(clientAuth *model.Auth, BucketName, elementType string, metricName string, startTime, endTime *time.Time, statistic string, dimensionsName string, cloudWatchClient cloudwatchiface.CloudWatchAPI) (*cloudwatch.GetMetricDataOutput, error) {
 	log.Printf("Getting metric data for instance %s in namespace %s from %v to %v", BucketName, elementType, startTime, endTime)
 	input := &cloudwatch.GetMetricDataInput{
 		EndTime:   endTime,
@@ -139,7 +110,7 @@ func GetS3MetricData(clientAuth *model.Auth, BucketName, elementType string, met
 		},
 	}
 	if cloudWatchClient == nil {
-		cloudWatchClient = awsclient.GetClient(*clientAuth, awsclient.CLOUDWATCH).(*cloudwatch.CloudWatch)
+		cloudWatchClient = comman_function.CloudWatchClient(*clientAuth)
 	}
 
 	result, err := cloudWatchClient.GetMetricData(input)
@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/spf13/cobra"
 )
 
@@ -16,48 +15,31 @@ var AwsxMaximumErrorsMessageCmd = &cobra.Command{
 	Use:   "maximum_errors_message_panel",
 	Short: "Get maximum errors message data",
 	Long:  `Command to get maximum errors message data`,
+}
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Running maximum errors message panel command")
-
-		var authFlag bool
-		var clientAuth *model.Auth
-		var err error
-		authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			panel, err := GetMaximumErrorsMessageData(cmd, clientAuth, nil)
-			if err != nil {
-				return
-			}
-			fmt.Println(panel)
-		}
-	},
+// ErrorMessageRow is one row of the maximum_errors_message_panel query.
+type ErrorMessageRow struct {
+	BucketName     string `json:"bucketName" logs:"BucketName"`
+	ErrorCode      string `json:"errorCode" logs:"errorCode"`
+	ErrorCodeCount int64  `json:"errorCodeCount" logs:"ErrorCodeCount"`
+	ErrorCount     int64  `json:"errorCount" logs:"ErrorCount"`
 }
 
-func GetMaximumErrorsMessageData(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchLogs *cloudwatchlogs.CloudWatchLogs) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
-	logGroupName, _ := cmd.PersistentFlags().GetString("logGroupName")
+func GetMaximumErrorsMessageData(req *comman_function.PanelRequest, cloudWatchLogs cloudwatchlogsiface.CloudWatchLogsAPI) ([]*cloudwatchlogs.GetQueryResultsOutput, error) {
+	logGroupName := req.LogGroupName
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing time: %v", err)
+		return nil, fmt.Errorf("error parsing time: %w", err)
 	}
-	logGroupName, err = comman_function.GetCmdbLogsData(cmd)
+	logGroupName, err = comman_function.GetCmdbLogsData(req)
 	if err != nil {
-		return nil, fmt.Errorf("error getting instance ID: %v", err)
+		return nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
-	results, err := comman_function.GetLogsData(clientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource == "s3.amazonaws.com"| filter eventName == "GetBucketAcl"| filter ispresent(errorCode) and errorCode != ""| filter errorCode in ["AccessDenied", "NoSuchBucket", "NoSuchKey", "InvalidBucketName", "AllAccessDisabled", "InvalidObjectState", "RequestTimeTooSkewed"]| stats count(errorCode) as ErrorCodeCount,count(errorMessage) as ErrorCount by requestParameters.bucketName as BucketName,errorCode| sort ErrorCodeCount desc| limit 10`, cloudWatchLogs)
+	results, err := comman_function.GetLogsDataWithContext(req.Context(), req.ClientAuth, startTime, endTime, logGroupName, `fields @timestamp, @message| filter eventSource == "s3.amazonaws.com"| filter eventName == "GetBucketAcl"| filter ispresent(errorCode) and errorCode != ""| filter errorCode in ["AccessDenied", "NoSuchBucket", "NoSuchKey", "InvalidBucketName", "AllAccessDisabled", "InvalidObjectState", "RequestTimeTooSkewed"]| stats count(errorCode) as ErrorCodeCount,count(errorMessage) as ErrorCount by requestParameters.bucketName as BucketName,errorCode| sort ErrorCodeCount desc| limit 10`, cloudWatchLogs)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	processedResults := ProcessQueryResult(results)
 
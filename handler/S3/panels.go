@@ -0,0 +1,36 @@
+package S3
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var s3ElementTypes = []string{"S3", "AWS/S3"}
+
+func init() {
+	comman_function.RegisterIdentifier(s3ElementTypes, comman_function.IdentifierBucketName)
+	comman_function.RegisterPanels(s3ElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "latency_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "ms",
+			Command:       AwsxS3LatencyCmd,
+			Panel:         comman_function.MetricPanel(GetLatencyPanel),
+		},
+		{
+			Name:          "data_transfer_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeUtilization,
+			Unit:          "decbytes",
+			Command:       AwsxS3DataTransferCmd,
+			Panel:         comman_function.MetricPanel(GetDataTransferData),
+		},
+		{
+			Name:          "maximum_errors_message_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTable,
+			Command:       AwsxMaximumErrorsMessageCmd,
+			Panel:         comman_function.TypedLogsPanel(GetMaximumErrorsMessageData, ErrorMessageRow{}),
+		},
+	})
+}
@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/Appkube-awsx/awsx-common/authenticate"
-	"github.com/Appkube-awsx/awsx-common/model"
 	 "github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/spf13/cobra"
 )
 
@@ -15,66 +14,37 @@ var AwsxErrorsCmd = &cobra.Command{
 	Use:   "errors_panel",
 	Short: "get errors metrics data for s3",
 	Long:  `command to get errors metrics data for s3`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("running from child command..")
-		var authFlag, clientAuth, err = authenticate.AuthenticateCommand(cmd)
-		if err != nil {
-			log.Printf("Error during authentication: %v\n", err)
-			err := cmd.Help()
-			if err != nil {
-				return
-			}
-			return
-		}
-		if authFlag {
-			responseType, _ := cmd.PersistentFlags().GetString("responseType")
-			jsonResp, cloudwatchMetricResp, err := GetS3ErrorsPanel(cmd, clientAuth, nil)
-			if err != nil {
-				log.Println("Error getting errors: ", err)
-				return
-			}
-			if responseType == "frame" {
-				fmt.Println(cloudwatchMetricResp)
-			} else {
-				// default case. it prints json
-				fmt.Println(jsonResp)
-			}
-		}
-
-	},
 }
 
-func GetS3ErrorsPanel(cmd *cobra.Command, clientAuth *model.Auth, cloudWatchClient *cloudwatch.CloudWatch) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
-	elementType, _ := cmd.PersistentFlags().GetString("elementType")
-	fmt.Println(elementType)
-	instanceId, _ := cmd.PersistentFlags().GetString("instanceId")
+func GetS3ErrorsPanel(req *comman_function.PanelRequest, cloudWatchClient cloudwatchiface.CloudWatchAPI) (string, map[string]*cloudwatch.GetMetricDataOutput, error) {
+	elementType := req.ElementType
+	instanceId := req.InstanceId
 	//bucketName := "abdulweb.com"
 
-	startTime, endTime, err := comman_function.ParseTimes(cmd)
+	startTime, endTime, err := comman_function.ParseTimes(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error parsing time: %v", err)
+		return "", nil, fmt.Errorf("error parsing time: %w", err)
 	}
 
-	instanceId, err = comman_function.GetCmdbData(cmd)
+	instanceId, err = comman_function.GetCmdbData(req)
 	if err != nil {
-		return "", nil, fmt.Errorf("error getting instance ID: %v", err)
+		return "", nil, fmt.Errorf("error getting instance ID: %w", err)
 	}
 
 	cloudwatchMetricData := map[string]*cloudwatch.GetMetricDataOutput{}
 	// Fetch raw data
-	fourxxErrorsData, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "4xxErrors", startTime, endTime, "Average", "bucketName", cloudWatchClient)
+	metricQuery := req.MetricQuery(startTime, endTime)
+	metricQuery.AddMetric("fourxxErrorsData", "AWS/"+elementType, "4xxErrors", "Average", comman_function.Dimension("bucketName", instanceId))
+	metricQuery.AddMetric("fivexxErrorsData", "AWS/"+elementType, "5xxErrors", "Average", comman_function.Dimension("bucketName", instanceId))
+	metricData, err := metricQuery.Execute(req.ClientAuth, cloudWatchClient)
 	if err != nil {
-		log.Println("Error in getting s3 4xx errors data: ", err)
+		log.Println("Error in getting metric data: ", err)
 		return "", nil, err
 	}
+	fourxxErrorsData := metricData.Output("fourxxErrorsData")
 	cloudwatchMetricData["4xxErrorsData"] = fourxxErrorsData
 
-	fivexxErrorsData, err := comman_function.GetMetricData(clientAuth, instanceId, "AWS/"+elementType, "5xxErrors", startTime, endTime, "Average", "bucketName", cloudWatchClient)
-	if err != nil {
-		log.Println("Error in getting s3 4xx errors data: ", err)
-		return "", nil, err
-	}
+	fivexxErrorsData := metricData.Output("fivexxErrorsData")
 	cloudwatchMetricData["5xxErrorsData"] = fivexxErrorsData
 	return "", cloudwatchMetricData, nil
 
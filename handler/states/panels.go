@@ -0,0 +1,43 @@
+package States
+
+import (
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+)
+
+var statesElementTypes = []string{"States", "AWS/States"}
+
+func init() {
+	comman_function.RegisterIdentifier(statesElementTypes, comman_function.IdentifierStateMachineArn)
+	comman_function.RegisterPanels(statesElementTypes, []comman_function.PanelDefinition{
+		{
+			Name:          "activity_failed_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetActivityFailedPanel, ActivityFailedRow{}),
+		},
+		{
+			Name:          "lambda_function_failed_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetStepLambdaFunctionFailed),
+		},
+		{
+			Name:          "activity_failed_timed_out_panel",
+			ResponseTypes: comman_function.LogsRowsResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.TypedLogsPanel(GetActivityFailedTimedOutPanel, ActivityTimedOutRow{}),
+		},
+		{
+			Name:          "execution_failed_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetStepExecutionFailed),
+		},
+		{
+			Name:          "lambda_function_timed_out_panel",
+			ResponseTypes: comman_function.JsonFrameResponseTypes,
+			Shape:         comman_function.AppkubeTimeSeries,
+			Panel:         comman_function.MetricPanel(GetStepLambdaFunctionTimedOut),
+		},
+	})
+}
@@ -0,0 +1,232 @@
+package mockaws
+
+import (
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxDatapoints bounds one synthetic series like the GetMetricData limit.
+const maxDatapoints = 100800
+
+type getMetricDataResponse struct {
+	XMLName xml.Name           `xml:"GetMetricDataResponse"`
+	Xmlns   string             `xml:"xmlns,attr"`
+	Results []metricDataResult `xml:"GetMetricDataResult>MetricDataResults>member"`
+}
+
+type metricDataResult struct {
+	Id         string    `xml:"Id"`
+	Label      string    `xml:"Label,omitempty"`
+	StatusCode string    `xml:"StatusCode"`
+	Timestamps []string  `xml:"Timestamps>member"`
+	Values     []float64 `xml:"Values>member"`
+}
+
+type getMetricStatisticsResponse struct {
+	XMLName    xml.Name    `xml:"GetMetricStatisticsResponse"`
+	Xmlns      string      `xml:"xmlns,attr"`
+	Label      string      `xml:"GetMetricStatisticsResult>Label"`
+	Datapoints []datapoint `xml:"GetMetricStatisticsResult>Datapoints>member"`
+}
+
+type datapoint struct {
+	Timestamp   string   `xml:"Timestamp"`
+	Average     *float64 `xml:"Average,omitempty"`
+	Sum         *float64 `xml:"Sum,omitempty"`
+	Minimum     *float64 `xml:"Minimum,omitempty"`
+	Maximum     *float64 `xml:"Maximum,omitempty"`
+	SampleCount *float64 `xml:"SampleCount,omitempty"`
+	Unit        string   `xml:"Unit,omitempty"`
+}
+
+type describeAlarmsResponse struct {
+	XMLName xml.Name      `xml:"DescribeAlarmsResponse"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Alarms  []metricAlarm `xml:"DescribeAlarmsResult>MetricAlarms>member"`
+}
+
+type metricAlarm struct {
+	AlarmName             string  `xml:"AlarmName"`
+	AlarmDescription      string  `xml:"AlarmDescription"`
+	Namespace             string  `xml:"Namespace"`
+	MetricName            string  `xml:"MetricName"`
+	StateValue            string  `xml:"StateValue"`
+	StateReason           string  `xml:"StateReason"`
+	StateUpdatedTimestamp string  `xml:"StateUpdatedTimestamp"`
+	Threshold             float64 `xml:"Threshold"`
+	ComparisonOperator    string  `xml:"ComparisonOperator"`
+}
+
+func (s *Server) serveCloudWatch(w http.ResponseWriter, r *http.Request, action string) {
+	switch action {
+	case "GetMetricData":
+		s.getMetricData(w, r.Form)
+	case "GetMetricStatistics":
+		s.getMetricStatistics(w, r.Form)
+	case "DescribeAlarms":
+		now := s.Now().UTC()
+		writeXml(w, describeAlarmsResponse{Xmlns: cloudWatchXmlns, Alarms: []metricAlarm{{
+			AlarmName:             "mock-high-cpu",
+			AlarmDescription:      "CPU above 80%",
+			Namespace:             "AWS/EC2",
+			MetricName:            "CPUUtilization",
+			StateValue:            "ALARM",
+			StateReason:           "Threshold Crossed",
+			StateUpdatedTimestamp: now.Add(-time.Hour).Format(time.RFC3339),
+			Threshold:             80,
+			ComparisonOperator:    "GreaterThanThreshold",
+		}}})
+	default:
+		writeQueryError(w, http.StatusBadRequest, "InvalidAction", fmt.Sprintf("mockaws does not serve CloudWatch %s", action))
+	}
+}
+
+func (s *Server) getMetricData(w http.ResponseWriter, form url.Values) {
+	startTime, endTime, err := formTimeRange(form)
+	if err != nil {
+		writeQueryError(w, http.StatusBadRequest, "InvalidParameterValue", err.Error())
+		return
+	}
+	ascending := form.Get("ScanBy") == "TimestampAscending"
+
+	response := getMetricDataResponse{Xmlns: cloudWatchXmlns}
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("MetricDataQueries.member.%d.", i)
+		id := form.Get(prefix + "Id")
+		if id == "" {
+			break
+		}
+		if form.Get(prefix+"ReturnData") == "false" {
+			continue
+		}
+		key := form.Get(prefix + "Expression")
+		period, _ := strconv.ParseInt(form.Get(prefix+"Period"), 10, 64)
+		if key == "" {
+			key = metricSeriesKey(form, prefix+"MetricStat.")
+			period, _ = strconv.ParseInt(form.Get(prefix+"MetricStat.Period"), 10, 64)
+		}
+		result := metricDataResult{Id: id, Label: form.Get(prefix + "Label"), StatusCode: "Complete"}
+		for _, t := range periodTimestamps(startTime, endTime, period, ascending) {
+			result.Timestamps = append(result.Timestamps, t.Format(time.RFC3339))
+			result.Values = append(result.Values, seriesValue(key, t))
+		}
+		response.Results = append(response.Results, result)
+	}
+	writeXml(w, response)
+}
+
+func (s *Server) getMetricStatistics(w http.ResponseWriter, form url.Values) {
+	startTime, endTime, err := formTimeRange(form)
+	if err != nil {
+		writeQueryError(w, http.StatusBadRequest, "InvalidParameterValue", err.Error())
+		return
+	}
+	period, _ := strconv.ParseInt(form.Get("Period"), 10, 64)
+	key := metricSeriesKey(form, "")
+
+	response := getMetricStatisticsResponse{Xmlns: cloudWatchXmlns, Label: form.Get("MetricName")}
+	for _, t := range periodTimestamps(startTime, endTime, period, true) {
+		value := seriesValue(key, t)
+		point := datapoint{Timestamp: t.Format(time.RFC3339), Unit: form.Get("Unit")}
+		for i := 1; ; i++ {
+			stat := form.Get(fmt.Sprintf("Statistics.member.%d", i))
+			if stat == "" {
+				break
+			}
+			switch stat {
+			case "Average":
+				point.Average = &value
+			case "Sum":
+				point.Sum = &value
+			case "Minimum":
+				point.Minimum = &value
+			case "Maximum":
+				point.Maximum = &value
+			case "SampleCount":
+				one := 1.0
+				point.SampleCount = &one
+			}
+		}
+		response.Datapoints = append(response.Datapoints, point)
+	}
+	writeXml(w, response)
+}
+
+func formTimeRange(form url.Values) (time.Time, time.Time, error) {
+	startTime, err := time.Parse(time.RFC3339, form.Get("StartTime"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid StartTime %q", form.Get("StartTime"))
+	}
+	endTime, err := time.Parse(time.RFC3339, form.Get("EndTime"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid EndTime %q", form.Get("EndTime"))
+	}
+	if !startTime.Before(endTime) {
+		return time.Time{}, time.Time{}, fmt.Errorf("StartTime must be before EndTime")
+	}
+	return startTime, endTime, nil
+}
+
+// metricSeriesKey identifies the series of the metric under prefix by its
+// namespace, name, dimensions and statistic.
+func metricSeriesKey(form url.Values, prefix string) string {
+	metricPrefix := prefix
+	if prefix != "" {
+		metricPrefix = prefix + "Metric."
+	}
+	parts := []string{form.Get(metricPrefix + "Namespace"), form.Get(metricPrefix + "MetricName"), form.Get(prefix + "Stat")}
+	for i := 1; ; i++ {
+		value := form.Get(fmt.Sprintf("%sDimensions.member.%d.Value", metricPrefix, i))
+		if value == "" {
+			break
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, "|")
+}
+
+// periodTimestamps returns the period aligned timestamps in [startTime,
+// endTime).
+func periodTimestamps(startTime, endTime time.Time, period int64, ascending bool) []time.Time {
+	if period <= 0 {
+		period = 60
+	}
+	step := time.Duration(period) * time.Second
+	first := startTime.Truncate(step)
+	if first.Before(startTime) {
+		first = first.Add(step)
+	}
+	var timestamps []time.Time
+	for t := first; t.Before(endTime) && len(timestamps) < maxDatapoints; t = t.Add(step) {
+		timestamps = append(timestamps, t.UTC())
+	}
+	if !ascending {
+		for i, j := 0, len(timestamps)-1; i < j; i, j = i+1, j-1 {
+			timestamps[i], timestamps[j] = timestamps[j], timestamps[i]
+		}
+	}
+	return timestamps
+}
+
+// seriesValue is a daily wave whose level and phase depend on key, so every
+// metric has its own stable curve. Byte and packet metrics are scaled up.
+func seriesValue(key string, t time.Time) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	sum := h.Sum32()
+	level := 20 + float64(sum%50)
+	phase := float64(sum%360) * math.Pi / 180
+	hours := float64(t.Unix()) / 3600
+	value := level + 15*math.Sin(hours*2*math.Pi/24+phase)
+	if strings.Contains(key, "Bytes") || strings.Contains(key, "Network") || strings.Contains(key, "Packets") {
+		value *= 100000
+	}
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,27 @@
+package mockaws
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+)
+
+// serveCmdb answers /cloud-element/search?id=<id> with one mock element
+// whose instance is the first mock instance.
+func (s *Server) serveCmdb(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		writeJsonError(w, http.StatusBadRequest, "InvalidParameterException", "id must be a number")
+		return
+	}
+	writeJson(w, []model.CloudElement{{
+		Id:           id,
+		ElementType:  "EC2",
+		Arn:          "arn:aws:ec2:us-east-1:123456789012:instance/" + mockInstances[0].id,
+		InstanceId:   mockInstances[0].id,
+		InstanceName: "mock-" + strconv.FormatInt(id, 10),
+		Status:       "ACTIVE",
+		LogGroup:     "mock-cloudtrail-log-group",
+	}})
+}
@@ -0,0 +1,249 @@
+package mockaws
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type mockInstance struct {
+	id               string
+	instanceType     string
+	state            string
+	availabilityZone string
+}
+
+// mockInstances are the instances every DescribeInstances call filters.
+var mockInstances = []mockInstance{
+	{"i-0a1b2c3d4e5f60001", "t3.micro", "running", "us-east-1a"},
+	{"i-0a1b2c3d4e5f60002", "t3.micro", "running", "us-east-1b"},
+	{"i-0a1b2c3d4e5f60003", "m5.large", "running", "us-east-1a"},
+	{"i-0a1b2c3d4e5f60004", "m5.large", "stopped", "us-east-1c"},
+}
+
+var instanceStateCodes = map[string]int{"pending": 0, "running": 16, "stopping": 64, "stopped": 80}
+
+type ec2Item struct {
+	Key   string `xml:"key"`
+	Value string `xml:"value"`
+}
+
+type instanceState struct {
+	Code int    `xml:"code"`
+	Name string `xml:"name"`
+}
+
+type instanceXml struct {
+	InstanceId       string        `xml:"instanceId"`
+	ImageId          string        `xml:"imageId"`
+	InstanceState    instanceState `xml:"instanceState"`
+	InstanceType     string        `xml:"instanceType"`
+	LaunchTime       string        `xml:"launchTime"`
+	AvailabilityZone string        `xml:"placement>availabilityZone"`
+	PrivateIpAddress string        `xml:"privateIpAddress"`
+	Tags             []ec2Item     `xml:"tagSet>item"`
+}
+
+type reservationXml struct {
+	ReservationId string        `xml:"reservationId"`
+	OwnerId       string        `xml:"ownerId"`
+	Instances     []instanceXml `xml:"instancesSet>item"`
+}
+
+type describeInstancesResponse struct {
+	XMLName      xml.Name         `xml:"DescribeInstancesResponse"`
+	Xmlns        string           `xml:"xmlns,attr"`
+	RequestId    string           `xml:"requestId"`
+	Reservations []reservationXml `xml:"reservationSet>item"`
+}
+
+type statusDetailXml struct {
+	Name   string `xml:"name"`
+	Status string `xml:"status"`
+}
+
+type statusXml struct {
+	Status  string            `xml:"status"`
+	Details []statusDetailXml `xml:"details>item"`
+}
+
+type instanceStatusXml struct {
+	InstanceId       string        `xml:"instanceId"`
+	AvailabilityZone string        `xml:"availabilityZone"`
+	InstanceState    instanceState `xml:"instanceState"`
+	SystemStatus     statusXml     `xml:"systemStatus"`
+	InstanceStatus   statusXml     `xml:"instanceStatus"`
+}
+
+type describeInstanceStatusResponse struct {
+	XMLName   xml.Name            `xml:"DescribeInstanceStatusResponse"`
+	Xmlns     string              `xml:"xmlns,attr"`
+	RequestId string              `xml:"requestId"`
+	Statuses  []instanceStatusXml `xml:"instanceStatusSet>item"`
+}
+
+type snapshotXml struct {
+	SnapshotId string `xml:"snapshotId"`
+	VolumeId   string `xml:"volumeId"`
+	Status     string `xml:"status"`
+	StartTime  string `xml:"startTime"`
+	VolumeSize int    `xml:"volumeSize"`
+	OwnerId    string `xml:"ownerId"`
+	Encrypted  bool   `xml:"encrypted"`
+}
+
+type describeSnapshotsResponse struct {
+	XMLName   xml.Name      `xml:"DescribeSnapshotsResponse"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	RequestId string        `xml:"requestId"`
+	Snapshots []snapshotXml `xml:"snapshotSet>item"`
+}
+
+type ipPermissionXml struct {
+	IpProtocol string   `xml:"ipProtocol"`
+	FromPort   int      `xml:"fromPort"`
+	ToPort     int      `xml:"toPort"`
+	CidrIps    []string `xml:"ipRanges>item>cidrIp"`
+}
+
+type securityGroupXml struct {
+	GroupId          string            `xml:"groupId"`
+	GroupName        string            `xml:"groupName"`
+	GroupDescription string            `xml:"groupDescription"`
+	VpcId            string            `xml:"vpcId"`
+	IpPermissions    []ipPermissionXml `xml:"ipPermissions>item"`
+}
+
+type describeSecurityGroupsResponse struct {
+	XMLName        xml.Name           `xml:"DescribeSecurityGroupsResponse"`
+	Xmlns          string             `xml:"xmlns,attr"`
+	RequestId      string             `xml:"requestId"`
+	SecurityGroups []securityGroupXml `xml:"securityGroupInfo>item"`
+}
+
+func (s *Server) serveEC2(w http.ResponseWriter, r *http.Request, action string) {
+	launchTime := s.Now().UTC().Add(-72 * time.Hour).Truncate(time.Hour).Format(time.RFC3339)
+	instances := filterInstances(r.Form)
+
+	switch action {
+	case "DescribeInstances":
+		response := describeInstancesResponse{Xmlns: ec2Xmlns, RequestId: "mock-request"}
+		for _, instance := range instances {
+			response.Reservations = append(response.Reservations, reservationXml{
+				ReservationId: "r-" + strings.TrimPrefix(instance.id, "i-"),
+				OwnerId:       "123456789012",
+				Instances: []instanceXml{{
+					InstanceId:       instance.id,
+					ImageId:          "ami-0mock",
+					InstanceState:    instanceState{Code: instanceStateCodes[instance.state], Name: instance.state},
+					InstanceType:     instance.instanceType,
+					LaunchTime:       launchTime,
+					AvailabilityZone: instance.availabilityZone,
+					PrivateIpAddress: "10.0.0." + instance.id[len(instance.id)-1:],
+					Tags:             []ec2Item{{Key: "Name", Value: "mock-" + instance.id[len(instance.id)-4:]}},
+				}},
+			})
+		}
+		writeXml(w, response)
+	case "DescribeInstanceStatus":
+		response := describeInstanceStatusResponse{Xmlns: ec2Xmlns, RequestId: "mock-request"}
+		for _, instance := range instances {
+			if instance.state != "running" && r.Form.Get("IncludeAllInstances") != "true" {
+				continue
+			}
+			status := statusXml{Status: "ok", Details: []statusDetailXml{{Name: "reachability", Status: "passed"}}}
+			if instance.state != "running" {
+				status = statusXml{Status: "not-applicable"}
+			}
+			response.Statuses = append(response.Statuses, instanceStatusXml{
+				InstanceId:       instance.id,
+				AvailabilityZone: instance.availabilityZone,
+				InstanceState:    instanceState{Code: instanceStateCodes[instance.state], Name: instance.state},
+				SystemStatus:     status,
+				InstanceStatus:   status,
+			})
+		}
+		writeXml(w, response)
+	case "DescribeSnapshots":
+		response := describeSnapshotsResponse{Xmlns: ec2Xmlns, RequestId: "mock-request"}
+		for i, status := range []string{"completed", "completed", "pending"} {
+			response.Snapshots = append(response.Snapshots, snapshotXml{
+				SnapshotId: fmt.Sprintf("snap-0mock%04d", i+1),
+				VolumeId:   fmt.Sprintf("vol-0mock%04d", i+1),
+				Status:     status,
+				StartTime:  launchTime,
+				VolumeSize: 8 * (i + 1),
+				OwnerId:    "123456789012",
+				Encrypted:  i%2 == 0,
+			})
+		}
+		writeXml(w, response)
+	case "DescribeSecurityGroups":
+		writeXml(w, describeSecurityGroupsResponse{Xmlns: ec2Xmlns, RequestId: "mock-request", SecurityGroups: []securityGroupXml{{
+			GroupId:          "sg-0mock0001",
+			GroupName:        "mock-web",
+			GroupDescription: "mock web tier",
+			VpcId:            "vpc-0mock",
+			IpPermissions:    []ipPermissionXml{{IpProtocol: "tcp", FromPort: 443, ToPort: 443, CidrIps: []string{"0.0.0.0/0"}}},
+		}}})
+	default:
+		writeEC2Error(w, http.StatusBadRequest, "InvalidAction", fmt.Sprintf("mockaws does not serve EC2 %s", action))
+	}
+}
+
+// filterInstances applies the InstanceId.N parameters and the instance-id,
+// instance-state-name and instance-type filters to mockInstances.
+func filterInstances(form url.Values) []mockInstance {
+	filters := map[string][]string{}
+	for i := 1; ; i++ {
+		id := form.Get(fmt.Sprintf("InstanceId.%d", i))
+		if id == "" {
+			break
+		}
+		filters["instance-id"] = append(filters["instance-id"], id)
+	}
+	for i := 1; ; i++ {
+		name := form.Get(fmt.Sprintf("Filter.%d.Name", i))
+		if name == "" {
+			break
+		}
+		for j := 1; ; j++ {
+			value := form.Get(fmt.Sprintf("Filter.%d.Value.%d", i, j))
+			if value == "" {
+				break
+			}
+			filters[name] = append(filters[name], value)
+		}
+	}
+
+	var instances []mockInstance
+	for _, instance := range mockInstances {
+		fields := map[string]string{
+			"instance-id":         instance.id,
+			"instance-state-name": instance.state,
+			"instance-type":       instance.instanceType,
+		}
+		matches := true
+		for name, values := range filters {
+			if field, ok := fields[name]; ok && !containsString(values, field) {
+				matches = false
+			}
+		}
+		if matches {
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
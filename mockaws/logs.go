@@ -0,0 +1,252 @@
+package mockaws
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logsInsightsTimeFormat is the format logs insights returns @timestamp and
+// bin() values in.
+const logsInsightsTimeFormat = "2006-01-02 15:04:05.000"
+
+type logsQuery struct {
+	queryString string
+	startTime   time.Time
+	endTime     time.Time
+	polls       int
+	status      string
+}
+
+type resultField struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+type logsEvent struct {
+	EventId       string `json:"eventId"`
+	IngestionTime int64  `json:"ingestionTime"`
+	LogStreamName string `json:"logStreamName"`
+	Message       string `json:"message"`
+	Timestamp     int64  `json:"timestamp"`
+}
+
+func (s *Server) serveLogs(w http.ResponseWriter, r *http.Request, op string) {
+	var input struct {
+		QueryId     string `json:"queryId"`
+		QueryString string `json:"queryString"`
+		StartTime   int64  `json:"startTime"`
+		EndTime     int64  `json:"endTime"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeJsonError(w, http.StatusBadRequest, "SerializationException", err.Error())
+		return
+	}
+
+	switch op {
+	case "StartQuery":
+		if input.QueryString == "" {
+			writeJsonError(w, http.StatusBadRequest, "InvalidParameterException", "queryString is required")
+			return
+		}
+		s.mu.Lock()
+		s.nextId++
+		queryId := fmt.Sprintf("mock-query-%d", s.nextId)
+		s.queries[queryId] = &logsQuery{
+			queryString: input.QueryString,
+			startTime:   logsTime(input.StartTime),
+			endTime:     logsTime(input.EndTime),
+			status:      "Scheduled",
+		}
+		s.mu.Unlock()
+		writeJson(w, map[string]string{"queryId": queryId})
+	case "GetQueryResults":
+		s.mu.Lock()
+		query, ok := s.queries[input.QueryId]
+		if !ok {
+			s.mu.Unlock()
+			writeJsonError(w, http.StatusBadRequest, "ResourceNotFoundException", fmt.Sprintf("query %s does not exist", input.QueryId))
+			return
+		}
+		if query.status != "Cancelled" {
+			query.polls++
+			switch {
+			case query.polls > 2*s.Polls:
+				query.status = "Complete"
+			case query.polls > s.Polls:
+				query.status = "Running"
+			}
+		}
+		status := query.status
+		s.mu.Unlock()
+
+		results := [][]resultField{}
+		if status == "Complete" {
+			results = logsRows(query, s.Rows)
+		}
+		writeJson(w, map[string]interface{}{
+			"results":    results,
+			"status":     status,
+			"statistics": map[string]float64{"recordsMatched": float64(len(results)), "recordsScanned": float64(10 * len(results))},
+		})
+	case "StopQuery":
+		s.mu.Lock()
+		query, ok := s.queries[input.QueryId]
+		if ok {
+			query.status = "Cancelled"
+		}
+		s.mu.Unlock()
+		writeJson(w, map[string]bool{"success": ok})
+	case "FilterLogEvents":
+		now := s.Now().UnixMilli()
+		events := make([]logsEvent, 0, s.Rows)
+		for i := 0; i < s.Rows; i++ {
+			timestamp := now - int64(i)*60000
+			events = append(events, logsEvent{
+				EventId:       strconv.Itoa(i),
+				IngestionTime: timestamp,
+				LogStreamName: "mock-stream",
+				Message:       fmt.Sprintf("mock log event %d", i),
+				Timestamp:     timestamp,
+			})
+		}
+		writeJson(w, map[string]interface{}{"events": events})
+	default:
+		writeJsonError(w, http.StatusBadRequest, "UnknownOperationException", fmt.Sprintf("mockaws does not serve CloudWatch Logs %s", op))
+	}
+}
+
+// logsTime reads a StartQuery time given in epoch seconds or, as the panels
+// send it, in epoch milliseconds.
+func logsTime(epoch int64) time.Time {
+	if epoch > 1e11 {
+		return time.UnixMilli(epoch).UTC()
+	}
+	return time.Unix(epoch, 0).UTC()
+}
+
+// logsRows builds the rows of query from the fields its last stats, display
+// or fields command outputs. Aggregates are counts, @timestamp and bin()
+// are spread over the query range and everything else is a mock string.
+func logsRows(query *logsQuery, rows int) [][]resultField {
+	fields, aggregates, grouped := logsQueryFields(query.queryString)
+	if !grouped {
+		rows = 1
+	}
+	step := query.endTime.Sub(query.startTime) / time.Duration(rows)
+	results := make([][]resultField, 0, rows)
+	for i := 0; i < rows; i++ {
+		timestamp := query.endTime.Add(-time.Duration(i) * step).Add(-step / 2)
+		row := []resultField{}
+		for _, field := range fields {
+			var value string
+			switch {
+			case aggregates[field] || isLogsCountField(field):
+				value = strconv.FormatUint(uint64(logsHash(field, i)%100+1), 10)
+			case field == "@timestamp" || strings.HasPrefix(field, "bin("):
+				value = timestamp.Format(logsInsightsTimeFormat)
+			case field == "eventTime" || field == "launchTime":
+				value = timestamp.Format(time.RFC3339)
+			default:
+				value = fmt.Sprintf("mock-%s-%d", field[strings.LastIndex(field, ".")+1:], i+1)
+			}
+			row = append(row, resultField{Field: field, Value: value})
+		}
+		results = append(results, row)
+	}
+	return results
+}
+
+// logsQueryFields returns the output fields of queryString, which of them are
+// stats aggregates and whether the rows are grouped.
+func logsQueryFields(queryString string) ([]string, map[string]bool, bool) {
+	var fields []string
+	aggregates := map[string]bool{}
+	grouped := true
+	for _, stage := range strings.Split(queryString, "|") {
+		stage = strings.TrimSpace(stage)
+		command, body, _ := strings.Cut(stage, " ")
+		switch strings.ToLower(command) {
+		case "stats":
+			fields, aggregates = nil, map[string]bool{}
+			stats, by, found := cutFold(body, " by ")
+			for _, expr := range splitTopLevel(stats) {
+				name := fieldName(expr)
+				aggregates[name] = true
+				fields = append(fields, name)
+			}
+			for _, expr := range splitTopLevel(by) {
+				fields = append(fields, fieldName(expr))
+			}
+			grouped = found
+		case "display":
+			fields = nil
+			for _, expr := range splitTopLevel(body) {
+				fields = append(fields, fieldName(expr))
+			}
+		case "fields":
+			if len(aggregates) > 0 {
+				continue
+			}
+			for _, expr := range splitTopLevel(body) {
+				fields = append(fields, fieldName(expr))
+			}
+		}
+	}
+	return fields, aggregates, grouped
+}
+
+// fieldName is the alias of expr if it has one, else expr itself.
+func fieldName(expr string) string {
+	if _, alias, found := cutFold(expr, " as "); found {
+		return strings.TrimSpace(alias)
+	}
+	return strings.TrimSpace(expr)
+}
+
+func cutFold(s, sep string) (string, string, bool) {
+	if i := strings.LastIndex(strings.ToLower(s), sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// splitTopLevel splits s at the commas outside parentheses.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+func isLogsCountField(field string) bool {
+	return strings.HasSuffix(field, "Count") || strings.HasSuffix(field, "Seconds")
+}
+
+func logsHash(field string, row int) uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s|%d", field, row)
+	return h.Sum32()
+}
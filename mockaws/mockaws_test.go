@@ -0,0 +1,331 @@
+package mockaws_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/ApiGateway"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/EC2"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/ECS"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/EKS"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/Lambda"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/NLB"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/RDS"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/S3"
+	_ "github.com/Appkube-awsx/awsx-getelementdetails/handler/states"
+	"github.com/Appkube-awsx/awsx-getelementdetails/mockaws"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the panel test")
+
+// endTime is the clock of the mock and the end of the range every panel is
+// asked for, so the output of the panels does not change from run to run.
+var endTime = time.Date(2026, 10, 17, 6, 0, 0, 0, time.UTC)
+
+// clockPanels read the wall clock instead of the range of the request, so
+// they are run but their output is not compared.
+var clockPanels = map[string]bool{
+	"AWS/RDS/instance_health_check_panel": true,
+	"RDS/instance_health_check_panel":     true,
+	"Lambda/throttles_function_panel":     true,
+}
+
+// newMock starts mockaws on a fixed clock and points the aws clients, the
+// cmdb and the retry policies at it for the duration of the test.
+func newMock(t *testing.T) (*httptest.Server, *mockaws.Server) {
+	t.Helper()
+	mock := mockaws.NewServer()
+	mock.Polls = 0
+	mock.Now = func() time.Time { return endTime }
+	server := httptest.NewServer(mock)
+	t.Cleanup(server.Close)
+	t.Cleanup(comman_function.SetEndpointUrl(server.URL))
+	t.Cleanup(comman_function.SetCmdbResolver(comman_function.NewCmdbResolver(nil, 0, "")))
+	t.Cleanup(comman_function.SetResultCache(nil))
+	// The mock does not throttle, so calls are neither retried nor rate
+	// limited.
+	t.Cleanup(comman_function.SetRetryPolicies(map[string]comman_function.RetryPolicy{comman_function.DefaultRetryService: {}}))
+	t.Cleanup(func(interval time.Duration) func() {
+		return func() { comman_function.LogsPollInterval = interval }
+	}(comman_function.LogsPollInterval))
+	comman_function.LogsPollInterval = 10 * time.Millisecond
+	return server, mock
+}
+
+// TestPanels runs every registered panel against the mock, the way the cli
+// does with --endpointUrl and --cmdbApiUrl pointing at mockaws, and compares
+// their json and frame output with testdata/golden. Run go test -update
+// after changing a panel or the mock.
+func TestPanels(t *testing.T) {
+	server, _ := newMock(t)
+
+	startTime := endTime.Add(-time.Hour)
+	inputs := map[string]string{
+		"elementId":  "1",
+		"cmdbApiUrl": server.URL,
+		"instanceId": "i-0a1b2c3d4e5f60001",
+	}
+	// Panels of these element types read identifiers the cmdb element of
+	// mockaws does not carry.
+	elementInputs := map[string]map[string]string{
+		"ApiGateway":     {"apiName": "mock-rest-api"},
+		"AWS/ApiGateway": {"apiName": "mock-rest-api"},
+		"AWS/NetworkELB": {"loadBalancerArn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/mock-nlb/0123456789abcdef"},
+		"AWS/NLB":        {"loadBalancerArn": "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/mock-nlb/0123456789abcdef"},
+	}
+	responseTypes := []string{comman_function.ResponseTypeJson, comman_function.ResponseTypeFrame}
+
+	panels := comman_function.Panels()
+	if len(panels) == 0 {
+		t.Fatal("no panels registered")
+	}
+	var mu sync.Mutex
+	outputs := map[string]map[string]string{}
+	t.Run("panels", func(t *testing.T) {
+		for _, p := range panels {
+			for _, elementType := range p.ElementTypes {
+				p, elementType := p, elementType
+				t.Run(elementType+"/"+p.Name, func(t *testing.T) {
+					t.Parallel()
+					req, err := comman_function.BuildPanelRequest(func(name string) string {
+						if value, ok := elementInputs[elementType][name]; ok {
+							return value
+						}
+						return inputs[name]
+					}, &model.Auth{Region: "us-east-1"})
+					if err != nil {
+						t.Fatal(err)
+					}
+					req.ElementType = elementType
+					// The range is set on the request directly, so the check
+					// that cloudwatch still keeps it does not fail the test
+					// once it is old.
+					req.StartTime, req.EndTime = &startTime, &endTime
+					result, err := comman_function.ExecutePanel(elementType, p.Name, req)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if clockPanels[elementType+"/"+p.Name] {
+						return
+					}
+					for _, responseType := range responseTypes {
+						payload, err := p.OutputPayload(result, responseType, comman_function.OutputJson)
+						if err != nil {
+							t.Fatal(err)
+						}
+						var output bytes.Buffer
+						if err := comman_function.WriteOutput(&output, payload, comman_function.OutputJson); err != nil {
+							t.Fatal(err)
+						}
+						mu.Lock()
+						if outputs[responseType] == nil {
+							outputs[responseType] = map[string]string{}
+						}
+						outputs[responseType][elementType+"/"+p.Name] = output.String()
+						mu.Unlock()
+					}
+				})
+			}
+		}
+	})
+
+	for _, responseType := range responseTypes {
+		checkGolden(t, filepath.Join("testdata", "golden", "panels_"+responseType+".txt"), outputs[responseType])
+	}
+}
+
+// checkGolden compares the outputs, one line per panel sorted by name, with
+// the golden file.
+func checkGolden(t *testing.T, golden string, outputs map[string]string) {
+	t.Helper()
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var got bytes.Buffer
+	for _, name := range names {
+		got.WriteString(name + " " + outputs[name])
+	}
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, got.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLines := strings.SplitAfter(string(want), "\n")
+	for i, line := range strings.SplitAfter(got.String(), "\n") {
+		if i >= len(wantLines) || line != wantLines[i] {
+			t.Errorf("output differs from %s at line %d:\n%s", golden, i+1, line)
+			return
+		}
+	}
+	if got.Len() != len(want) {
+		t.Errorf("output is shorter than %s", golden)
+	}
+}
+
+// TestRetries throttles the calls of a panel and checks that they are retried
+// within the policy and fail as aws api errors once the retries run out.
+func TestRetries(t *testing.T) {
+	server, mock := newMock(t)
+	policy := comman_function.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	comman_function.SetRetryPolicies(map[string]comman_function.RetryPolicy{comman_function.DefaultRetryService: policy})
+
+	startTime := endTime.Add(-time.Hour)
+	run := func() (*comman_function.PanelResult, error) {
+		t.Helper()
+		req, err := comman_function.BuildPanelRequest(func(name string) string {
+			return map[string]string{"elementId": "1", "cmdbApiUrl": server.URL, "instanceId": "i-0a1b2c3d4e5f60001"}[name]
+		}, &model.Auth{Region: "us-east-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.ElementType = "EC2"
+		req.StartTime, req.EndTime = &startTime, &endTime
+		return comman_function.ExecutePanel("EC2", "cpu_utilization_panel", req)
+	}
+
+	// Throttled fewer times than MaxRetries, the call is answered on its
+	// last retry.
+	mock.ThrottleNext = policy.MaxRetries
+	result, err := run()
+	if err != nil {
+		t.Fatalf("panel failed with %d throttled calls: %v", policy.MaxRetries, err)
+	}
+	if result.Retries != int64(policy.MaxRetries) {
+		t.Errorf("the panel retried %d calls, want %d", result.Retries, policy.MaxRetries)
+	}
+
+	// Every call is throttled, so the panel gives up after MaxRetries.
+	mock.Throttle = 1
+	before := comman_function.AwsRetries()
+	start := time.Now()
+	_, err = run()
+	if got := comman_function.ErrorTypeOf(err); got != comman_function.ErrorTypeAwsApi {
+		t.Errorf("panel with every call throttled failed with %v (%s), want an aws api error", err, got)
+	}
+	if got := comman_function.AwsRetries() - before; got != int64(policy.MaxRetries) {
+		t.Errorf("retried %d times, want %d", got, policy.MaxRetries)
+	}
+	// The delays double from BaseDelay but stay below MaxDelay.
+	if elapsed := time.Since(start); elapsed > time.Duration(policy.MaxRetries)*policy.MaxDelay+time.Second {
+		t.Errorf("the retries took %v, want about %v at most", elapsed, time.Duration(policy.MaxRetries)*policy.MaxDelay)
+	}
+}
+
+// TestCli builds the cli and runs a panel against the mock the way the
+// README does, checking its output and the exit code of a failing query.
+func TestCli(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the cli")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not in PATH")
+	}
+	binary := filepath.Join(t.TempDir(), "awsx-getelementdetails")
+	build := exec.Command(goTool, "build", "-o", binary, "..")
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, output)
+	}
+	server, _ := newMock(t)
+
+	run := func(args ...string) ([]byte, int) {
+		t.Helper()
+		args = append([]string{"--endpointUrl", server.URL, "--zone", "us-east-1", "--elementType", "EC2", "--instanceId", "i-0a1b2c3d4e5f60001"}, args...)
+		output, err := exec.Command(binary, args...).Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return output, exitErr.ExitCode()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return output, 0
+	}
+
+	output, code := run("--query", "cpu_utilization_panel")
+	if code != 0 {
+		t.Fatalf("cpu_utilization_panel exited with %d: %s", code, output)
+	}
+	var usage struct {
+		AverageUsage *float64
+		CurrentUsage *float64
+		MaxUsage     *float64
+	}
+	if err := json.Unmarshal(output, &usage); err != nil || usage.AverageUsage == nil || usage.CurrentUsage == nil || usage.MaxUsage == nil {
+		t.Errorf("cpu_utilization_panel output = %s (%v)", output, err)
+	}
+
+	output, code = run("--query", "no_such_panel")
+	var envelope struct {
+		Error struct {
+			Type     string `json:"type"`
+			ExitCode int    `json:"exitCode"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(output, &envelope); err != nil {
+		t.Fatalf("no_such_panel output = %s (%v)", output, err)
+	}
+	if code != 2 || envelope.Error.Type != "validation" || envelope.Error.ExitCode != 2 {
+		t.Errorf("no_such_panel exited with %d and error %+v, want 2 and a validation error", code, envelope.Error)
+	}
+
+	// Panels of the same name for other element types share one sub command.
+	output, _ = run("--help")
+	commands := map[string]bool{}
+	_, available, _ := strings.Cut(string(output), "Available Commands:\n")
+	available, _, _ = strings.Cut(available, "\n\n")
+	for _, line := range strings.Split(available, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if commands[fields[0]] {
+			t.Errorf("--help lists the %s sub command twice", fields[0])
+		}
+		commands[fields[0]] = true
+	}
+	if !commands["cpu_utilization_panel"] || !commands["cpu_surplus_credits_charged_panel"] {
+		t.Errorf("--help does not list the cpu_utilization_panel and cpu_surplus_credits_charged_panel sub commands: %s", output)
+	}
+
+	// A batch prints the results of all panels and exits with the code of
+	// the first that failed.
+	batchFile := filepath.Join(t.TempDir(), "batch.yaml")
+	if err := os.WriteFile(batchFile, []byte("defaults:\n  elementType: EC2\n  instanceId: i-0a1b2c3d4e5f60001\npanels:\n  - query: cpu_utilization_panel\n  - query: no_such_panel\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output, code = run("batch", "--file", batchFile)
+	var results map[string]struct {
+		Data      json.RawMessage `json:"data"`
+		ErrorType string          `json:"errorType"`
+	}
+	if err := json.Unmarshal(output, &results); err != nil {
+		t.Fatalf("batch output = %s (%v)", output, err)
+	}
+	if code != 2 || results["cpu_utilization_panel"].Data == nil || results["no_such_panel"].ErrorType != "validation" {
+		t.Errorf("batch exited with %d and printed %s, want 2 and the results of both panels", code, output)
+	}
+}
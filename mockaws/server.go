@@ -0,0 +1,176 @@
+// Package mockaws is a local stand-in for the CloudWatch, CloudWatch Logs,
+// EC2 and CMDB endpoints the panels call, plus the few Lambda, API Gateway,
+// ELBv2 and AutoScaling calls some panels make. Metrics are synthetic but
+// stable time series, logs insights queries go through Scheduled, Running and
+// Complete, and every CMDB element id resolves to a mock element. Point the
+// cli at it with --endpointUrl and --cmdbApiUrl.
+package mockaws
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	cloudWatchXmlns  = "http://monitoring.amazonaws.com/doc/2010-08-01/"
+	ec2Xmlns         = "http://ec2.amazonaws.com/doc/2016-11-15/"
+	ec2ApiVersion    = "2016-11-15"
+	logsTargetPrefix = "Logs_20140328."
+)
+
+// Server serves the mock endpoints. The zero value is not usable, use
+// NewServer.
+type Server struct {
+	// Polls is the number of GetQueryResults calls a logs query spends in
+	// Scheduled and then in Running before it completes.
+	Polls int
+	// Rows is the number of rows every logs query returns.
+	Rows int
+	// Throttle, when above zero, answers every Throttle-th aws call with a
+	// ThrottlingException to exercise retries.
+	Throttle int
+	// ThrottleNext answers that many of the next aws calls with a
+	// ThrottlingException, before Throttle applies.
+	ThrottleNext int
+	// Now is the clock of alarm states, log events and resource timestamps.
+	// NewServer sets it to time.Now.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	queries map[string]*logsQuery
+	nextId  int
+	calls   int
+}
+
+// NewServer returns a Server whose logs queries complete on the third poll
+// with five rows.
+func NewServer() *Server {
+	return &Server{Polls: 1, Rows: 5, Now: time.Now, queries: map[string]*logsQuery{}}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log.Printf("mockaws %s %s %s", r.Method, r.URL.Path, r.Header.Get("X-Amz-Target"))
+	if !strings.HasSuffix(r.URL.Path, "/cloud-element/search") && s.throttled() {
+		switch {
+		case strings.HasPrefix(r.Header.Get("X-Amz-Target"), logsTargetPrefix):
+			writeJsonError(w, http.StatusBadRequest, "ThrottlingException", "Rate exceeded")
+		case r.ParseForm() == nil && r.Form.Get("Version") == ec2ApiVersion:
+			writeEC2Error(w, http.StatusServiceUnavailable, "RequestLimitExceeded", "Request limit exceeded.")
+		default:
+			writeQueryError(w, http.StatusBadRequest, "Throttling", "Rate exceeded")
+		}
+		return
+	}
+	if target := r.Header.Get("X-Amz-Target"); strings.HasPrefix(target, logsTargetPrefix) {
+		s.serveLogs(w, r, strings.TrimPrefix(target, logsTargetPrefix))
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "/cloud-element/search") {
+		s.serveCmdb(w, r)
+		return
+	}
+	if r.Method == http.MethodGet && s.serveRest(w, r) {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeQueryError(w, http.StatusBadRequest, "MalformedQueryString", err.Error())
+		return
+	}
+	action := r.Form.Get("Action")
+	if action == "" {
+		writeJsonError(w, http.StatusNotFound, "UnknownOperationException", fmt.Sprintf("mockaws does not serve %s %s", r.Method, r.URL.Path))
+		return
+	}
+	switch r.Form.Get("Version") {
+	case ec2ApiVersion:
+		s.serveEC2(w, r, action)
+	case elbv2ApiVersion:
+		s.serveELBV2(w, r, action)
+	case autoScalingApiVersion:
+		s.serveAutoScaling(w, r, action)
+	default:
+		s.serveCloudWatch(w, r, action)
+	}
+}
+
+// throttled counts an aws call and reports whether it is one to throttle.
+func (s *Server) throttled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ThrottleNext > 0 {
+		s.ThrottleNext--
+		return true
+	}
+	if s.Throttle <= 0 {
+		return false
+	}
+	s.calls++
+	return s.calls%s.Throttle == 0
+}
+
+func writeXml(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "text/xml")
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		return
+	}
+	if err := xml.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("mockaws: error encoding response: %v", err)
+	}
+}
+
+func writeJson(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	writeJsonBody(w, value)
+}
+
+func writeJsonBody(w http.ResponseWriter, value interface{}) {
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("mockaws: error encoding response: %v", err)
+	}
+}
+
+type queryError struct {
+	XMLName xml.Name `xml:"ErrorResponse"`
+	Code    string   `xml:"Error>Code"`
+	Message string   `xml:"Error>Message"`
+}
+
+func writeQueryError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	if err := xml.NewEncoder(w).Encode(queryError{Code: code, Message: message}); err != nil {
+		log.Printf("mockaws: error encoding error: %v", err)
+	}
+}
+
+type ec2Error struct {
+	XMLName   xml.Name `xml:"Response"`
+	Code      string   `xml:"Errors>Error>Code"`
+	Message   string   `xml:"Errors>Error>Message"`
+	RequestId string   `xml:"RequestID"`
+}
+
+// writeEC2Error writes an error in the shape of the EC2 query api, which
+// differs from the other query apis.
+func writeEC2Error(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "text/xml")
+	w.WriteHeader(status)
+	if err := xml.NewEncoder(w).Encode(ec2Error{Code: code, Message: message, RequestId: "mockaws"}); err != nil {
+		log.Printf("mockaws: error encoding error: %v", err)
+	}
+}
+
+func writeJsonError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.Header().Set("X-Amzn-Errortype", code)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]string{"__type": code, "message": message}); err != nil {
+		log.Printf("mockaws: error encoding error: %v", err)
+	}
+}
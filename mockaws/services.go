@@ -0,0 +1,210 @@
+package mockaws
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	elbv2Xmlns             = "http://elasticloadbalancing.amazonaws.com/doc/2015-12-01/"
+	elbv2ApiVersion        = "2015-12-01"
+	autoScalingXmlns       = "http://autoscaling.amazonaws.com/doc/2011-01-01/"
+	autoScalingApiVersion  = "2011-01-01"
+	mockAccountId          = "123456789012"
+	mockLoadBalancerSuffix = "net/mock-nlb/0123456789abcdef"
+)
+
+// mockFunctions are the lambda functions ListFunctions returns.
+var mockFunctions = []string{"mock-orders", "mock-payments", "mock-notifications"}
+
+type targetGroupXml struct {
+	TargetGroupArn  string   `xml:"TargetGroupArn"`
+	TargetGroupName string   `xml:"TargetGroupName"`
+	Protocol        string   `xml:"Protocol"`
+	Port            int      `xml:"Port"`
+	VpcId           string   `xml:"VpcId"`
+	TargetType      string   `xml:"TargetType"`
+	LoadBalancers   []string `xml:"LoadBalancerArns>member"`
+}
+
+type describeTargetGroupsResponse struct {
+	XMLName      xml.Name         `xml:"DescribeTargetGroupsResponse"`
+	Xmlns        string           `xml:"xmlns,attr"`
+	TargetGroups []targetGroupXml `xml:"DescribeTargetGroupsResult>TargetGroups>member"`
+}
+
+type targetHealthXml struct {
+	TargetId        string `xml:"Target>Id"`
+	TargetPort      int    `xml:"Target>Port"`
+	HealthCheckPort string `xml:"HealthCheckPort"`
+	State           string `xml:"TargetHealth>State"`
+	Reason          string `xml:"TargetHealth>Reason,omitempty"`
+}
+
+type describeTargetHealthResponse struct {
+	XMLName xml.Name          `xml:"DescribeTargetHealthResponse"`
+	Xmlns   string            `xml:"xmlns,attr"`
+	Targets []targetHealthXml `xml:"DescribeTargetHealthResult>TargetHealthDescriptions>member"`
+}
+
+type autoScalingInstanceXml struct {
+	InstanceId       string `xml:"InstanceId"`
+	InstanceType     string `xml:"InstanceType"`
+	AvailabilityZone string `xml:"AvailabilityZone"`
+	LifecycleState   string `xml:"LifecycleState"`
+	HealthStatus     string `xml:"HealthStatus"`
+}
+
+type autoScalingGroupXml struct {
+	AutoScalingGroupName    string                   `xml:"AutoScalingGroupName"`
+	LaunchConfigurationName string                   `xml:"LaunchConfigurationName"`
+	MinSize                 int                      `xml:"MinSize"`
+	MaxSize                 int                      `xml:"MaxSize"`
+	DesiredCapacity         int                      `xml:"DesiredCapacity"`
+	DefaultCooldown         int                      `xml:"DefaultCooldown"`
+	HealthCheckType         string                   `xml:"HealthCheckType"`
+	CreatedTime             string                   `xml:"CreatedTime"`
+	AvailabilityZones       []string                 `xml:"AvailabilityZones>member"`
+	Instances               []autoScalingInstanceXml `xml:"Instances>member"`
+}
+
+type describeAutoScalingGroupsResponse struct {
+	XMLName xml.Name              `xml:"DescribeAutoScalingGroupsResponse"`
+	Xmlns   string                `xml:"xmlns,attr"`
+	Groups  []autoScalingGroupXml `xml:"DescribeAutoScalingGroupsResult>AutoScalingGroups>member"`
+}
+
+type launchConfigurationXml struct {
+	LaunchConfigurationName string `xml:"LaunchConfigurationName"`
+	ImageId                 string `xml:"ImageId"`
+	InstanceType            string `xml:"InstanceType"`
+	CreatedTime             string `xml:"CreatedTime"`
+}
+
+type describeLaunchConfigurationsResponse struct {
+	XMLName              xml.Name                 `xml:"DescribeLaunchConfigurationsResponse"`
+	Xmlns                string                   `xml:"xmlns,attr"`
+	LaunchConfigurations []launchConfigurationXml `xml:"DescribeLaunchConfigurationsResult>LaunchConfigurations>member"`
+}
+
+func (s *Server) serveELBV2(w http.ResponseWriter, r *http.Request, action string) {
+	loadBalancerArn := fmt.Sprintf("arn:aws:elasticloadbalancing:us-east-1:%s:loadbalancer/%s", mockAccountId, mockLoadBalancerSuffix)
+	switch action {
+	case "DescribeTargetGroups":
+		writeXml(w, describeTargetGroupsResponse{Xmlns: elbv2Xmlns, TargetGroups: []targetGroupXml{{
+			TargetGroupArn:  fmt.Sprintf("arn:aws:elasticloadbalancing:us-east-1:%s:targetgroup/mock-tg/0123456789abcdef", mockAccountId),
+			TargetGroupName: "mock-tg",
+			Protocol:        "TCP",
+			Port:            443,
+			VpcId:           "vpc-0mock",
+			TargetType:      "instance",
+			LoadBalancers:   []string{loadBalancerArn},
+		}}})
+	case "DescribeTargetHealth":
+		response := describeTargetHealthResponse{Xmlns: elbv2Xmlns}
+		for _, instance := range mockInstances {
+			target := targetHealthXml{TargetId: instance.id, TargetPort: 443, HealthCheckPort: "443", State: "healthy"}
+			if instance.state != "running" {
+				target.State, target.Reason = "unused", "Target.InvalidState"
+			}
+			response.Targets = append(response.Targets, target)
+		}
+		writeXml(w, response)
+	default:
+		writeQueryError(w, http.StatusBadRequest, "InvalidAction", fmt.Sprintf("mockaws does not serve ELBv2 %s", action))
+	}
+}
+
+func (s *Server) serveAutoScaling(w http.ResponseWriter, r *http.Request, action string) {
+	createdTime := s.Now().UTC().Add(-30 * 24 * time.Hour).Truncate(time.Hour).Format(time.RFC3339)
+	switch action {
+	case "DescribeAutoScalingGroups":
+		group := autoScalingGroupXml{
+			AutoScalingGroupName:    "mock-asg",
+			LaunchConfigurationName: "mock-launch-config",
+			MinSize:                 1,
+			MaxSize:                 4,
+			DefaultCooldown:         300,
+			HealthCheckType:         "EC2",
+			CreatedTime:             createdTime,
+		}
+		for _, instance := range mockInstances {
+			if instance.state != "running" {
+				continue
+			}
+			group.DesiredCapacity++
+			group.Instances = append(group.Instances, autoScalingInstanceXml{
+				InstanceId:       instance.id,
+				InstanceType:     instance.instanceType,
+				AvailabilityZone: instance.availabilityZone,
+				LifecycleState:   "InService",
+				HealthStatus:     "Healthy",
+			})
+			if !containsString(group.AvailabilityZones, instance.availabilityZone) {
+				group.AvailabilityZones = append(group.AvailabilityZones, instance.availabilityZone)
+			}
+		}
+		writeXml(w, describeAutoScalingGroupsResponse{Xmlns: autoScalingXmlns, Groups: []autoScalingGroupXml{group}})
+	case "DescribeLaunchConfigurations":
+		writeXml(w, describeLaunchConfigurationsResponse{Xmlns: autoScalingXmlns, LaunchConfigurations: []launchConfigurationXml{{
+			LaunchConfigurationName: "mock-launch-config",
+			ImageId:                 "ami-0mock",
+			InstanceType:            "t3.micro",
+			CreatedTime:             createdTime,
+		}}})
+	default:
+		writeQueryError(w, http.StatusBadRequest, "InvalidAction", fmt.Sprintf("mockaws does not serve AutoScaling %s", action))
+	}
+}
+
+// serveRest answers the rest-json calls of lambda and api gateway. It
+// reports whether path is one of them.
+func (s *Server) serveRest(w http.ResponseWriter, r *http.Request) bool {
+	now := s.Now().UTC()
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case path == "/2015-03-31/functions":
+		functions := make([]map[string]interface{}, 0, len(mockFunctions))
+		for _, name := range mockFunctions {
+			functions = append(functions, map[string]interface{}{
+				"FunctionName": name,
+				"FunctionArn":  fmt.Sprintf("arn:aws:lambda:us-east-1:%s:function:%s", mockAccountId, name),
+				"Runtime":      "go1.x",
+				"MemorySize":   128,
+				"LastModified": now.Add(-48 * time.Hour).Format("2006-01-02T15:04:05.000-0700"),
+			})
+		}
+		writeRestJson(w, map[string]interface{}{"Functions": functions})
+	case path == "/2016-08-19/account-settings":
+		writeRestJson(w, map[string]interface{}{
+			"AccountLimit": map[string]interface{}{"ConcurrentExecutions": 1000, "UnreservedConcurrentExecutions": 900, "CodeSizeUnzipped": 262144000, "CodeSizeZipped": 52428800, "TotalCodeSize": 80530636800},
+			"AccountUsage": map[string]interface{}{"FunctionCount": len(mockFunctions), "TotalCodeSize": 3 * 1048576},
+		})
+	case path == "/restapis":
+		writeRestJson(w, map[string]interface{}{"item": []map[string]interface{}{
+			{"id": "mockrest01", "name": "mock-rest-api", "createdDate": now.Add(-90 * 24 * time.Hour).Unix()},
+		}})
+	case strings.HasPrefix(path, "/restapis/") && strings.HasSuffix(path, "/stages"):
+		writeRestJson(w, map[string]interface{}{"item": []map[string]interface{}{
+			{"stageName": "dev", "deploymentId": "mockdeploy1", "createdDate": now.Add(-30 * 24 * time.Hour).Unix(), "lastUpdatedDate": now.Add(-time.Hour).Unix()},
+			{"stageName": "prod", "deploymentId": "mockdeploy2", "createdDate": now.Add(-60 * 24 * time.Hour).Unix(), "lastUpdatedDate": now.Add(-24 * time.Hour).Unix()},
+		}})
+	case path == "/v2/apis":
+		createdDate := now.Add(-90 * 24 * time.Hour).Format(time.RFC3339)
+		writeRestJson(w, map[string]interface{}{"items": []map[string]interface{}{
+			{"apiId": "mockhttp01", "name": "mock-http-api", "protocolType": "HTTP", "createdDate": createdDate},
+			{"apiId": "mockws01", "name": "mock-websocket-api", "protocolType": "WEBSOCKET", "createdDate": createdDate},
+		}})
+	default:
+		return false
+	}
+	return true
+}
+
+func writeRestJson(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	writeJsonBody(w, value)
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Appkube-awsx/awsx-common/model"
+	"github.com/Appkube-awsx/awsx-getelementdetails/comman-function"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+)
+
+const (
+	panelsPath   = "/v1/panels"
+	elementsPath = "/v1/elements/"
+	cachePath    = "/v1/cache"
+)
+
+// PanelInfo describes one registered panel in the /v1/panels catalogue.
+type PanelInfo struct {
+	Name          string   `json:"name"`
+	ElementTypes  []string `json:"elementTypes"`
+	ResponseTypes []string `json:"responseTypes"`
+	AppkubeShape  string   `json:"appkubeShape,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	// Type is the error type, see comman_function.ErrorTypeOf.
+	Type string `json:"type"`
+}
+
+// Server exposes the registered panels over http. Every request uses the
+// credentials and CMDB of Defaults. Requests may pick the zone and elementId,
+// and assume the cross account roles of AllowedRoleArns with
+// crossAccountRoleArn and externalId; the server does not authenticate its
+// callers, so they cannot pick any other role, landing zone or CMDB.
+type Server struct {
+	Defaults        model.CommandParam
+	AllowedRoleArns []string
+}
+
+func NewServer(defaults model.CommandParam) *Server {
+	return &Server{Defaults: defaults}
+}
+
+// Handler returns the http routes of the server.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(panelsPath, s.handlePanels)
+	mux.HandleFunc(elementsPath, s.handleElementPanel)
+	mux.HandleFunc(cachePath, s.handleCache)
+	return mux
+}
+
+// handleCache serves GET /v1/cache, the hits and misses of the result cache.
+func (s *Server) handleCache(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	writeJson(w, http.StatusOK, comman_function.ResultCacheStats())
+}
+
+// handlePanels serves GET /v1/panels, optionally filtered by ?elementType=.
+func (s *Server) handlePanels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	elementType := r.URL.Query().Get("elementType")
+
+	catalogue := []PanelInfo{}
+	for _, p := range comman_function.Panels() {
+		if elementType != "" && !contains(p.ElementTypes, elementType) && !contains(p.ElementTypes, comman_function.AnyElementType) {
+			continue
+		}
+		catalogue = append(catalogue, PanelInfo{
+			Name:          p.Name,
+			ElementTypes:  p.ElementTypes,
+			ResponseTypes: p.SupportedResponseTypes(),
+			AppkubeShape:  string(p.Shape),
+		})
+	}
+	writeJson(w, http.StatusOK, catalogue)
+}
+
+// handleElementPanel serves GET /v1/elements/{elementType}/panels/{query}.
+// The element type may itself contain a slash, e.g. AWS/EC2.
+func (s *Server) handleElementPanel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	rest := strings.TrimPrefix(r.URL.Path, elementsPath)
+	idx := strings.LastIndex(rest, "/panels/")
+	if idx <= 0 || idx+len("/panels/") == len(rest) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("expected %s{elementType}/panels/{query}", elementsPath))
+		return
+	}
+	elementType := rest[:idx]
+	query := rest[idx+len("/panels/"):]
+
+	p, err := comman_function.LookupPanel(elementType, query)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("panel %s panicked: %v", query, rec))
+		}
+	}()
+
+	params := r.URL.Query()
+	if !p.SupportsResponseType(params.Get("responseType")) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("panel %q does not support response type %q. supported: %s", query, params.Get("responseType"), strings.Join(p.SupportedResponseTypes(), ", ")))
+		return
+	}
+
+	param, err := s.commandParam(params.Get)
+	if err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	authFlag, clientAuth, err := comman_function.Authenticate(param)
+	if err != nil || !authFlag {
+		if err == nil {
+			err = errors.New("no aws credentials found")
+		}
+		writeError(w, http.StatusUnauthorized, &comman_function.AuthError{Err: err})
+		return
+	}
+
+	req, err := comman_function.BuildPanelRequest(params.Get, clientAuth)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	req.ElementType = elementType
+	req.CmdbApiUrl = s.Defaults.CloudElementApiUrl
+	req = req.WithContext(r.Context())
+
+	log.Printf("running panel %s for element type %s", query, elementType)
+	result, err := comman_function.ExecutePanel(elementType, query, req)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	if result == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	output := req.OutputFormat()
+	payload, err := p.OutputPayload(result, req.ResponseType, output)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	payload = req.WithMetadataPayload(payload, result, output)
+	var body bytes.Buffer
+	if err := comman_function.WriteOutput(&body, payload, output); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", outputContentTypes[output])
+	for _, identity := range result.Identities {
+		w.Header().Add("X-Awsx-Identity", identity.Name+"="+identity.Value+"; source="+identity.Source)
+	}
+	w.Header().Set("X-Awsx-Retries", strconv.FormatInt(result.Retries, 10))
+	if m := result.Metadata; m != nil {
+		w.Header().Set("X-Awsx-Status", m.Status)
+		w.Header().Set("X-Awsx-Datapoints", strconv.Itoa(m.Datapoints))
+		if m.Period > 0 {
+			w.Header().Set("X-Awsx-Period", strconv.FormatInt(m.Period, 10))
+		}
+		if m.First != nil && m.Last != nil {
+			w.Header().Set("X-Awsx-First", m.First.UTC().Format(time.RFC3339))
+			w.Header().Set("X-Awsx-Last", m.Last.UTC().Format(time.RFC3339))
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+// outputContentTypes are the content types of the outputs of the output
+// query parameter.
+var outputContentTypes = map[string]string{
+	comman_function.OutputJson:   "application/json",
+	comman_function.OutputNdjson: "application/x-ndjson",
+	comman_function.OutputCsv:    "text/csv; charset=utf-8",
+	comman_function.OutputTable:  "text/plain; charset=utf-8",
+	comman_function.OutputYaml:   "application/yaml",
+}
+
+// commandParam returns the credentials of a request, see Server. Parameters
+// the request may not set are a ValidationError.
+func (s *Server) commandParam(get func(string) string) (model.CommandParam, error) {
+	param := s.Defaults
+	for _, name := range []string{"cmdbApiUrl", "landingZoneId"} {
+		if get(name) != "" {
+			return param, &comman_function.ValidationError{Err: fmt.Errorf("%s cannot be set per request", name)}
+		}
+	}
+	if value := get("zone"); value != "" {
+		param.Region = value
+	}
+	if value := get("elementId"); value != "" {
+		param.CloudElementId = value
+	}
+	if roleArn := get("crossAccountRoleArn"); roleArn != "" {
+		if !contains(s.AllowedRoleArns, roleArn) {
+			return param, &comman_function.ValidationError{Err: fmt.Errorf("role %s is not allowed, see serve --allowedRoleArns", roleArn)}
+		}
+		param.CrossAccountRoleArn = roleArn
+		param.ExternalId = get("externalId")
+	} else if get("externalId") != "" {
+		return param, &comman_function.ValidationError{Err: errors.New("externalId needs crossAccountRoleArn")}
+	}
+	return param, nil
+}
+
+// statusFor maps a panel error to the http status returned to the caller.
+func statusFor(err error) int {
+	var validationErr *comman_function.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest
+	}
+
+	var noDataErr *comman_function.NoDataError
+	if errors.As(err, &noDataErr) {
+		return http.StatusNotFound
+	}
+
+	var authErr *comman_function.AuthError
+	if errors.As(err, &authErr) {
+		return http.StatusUnauthorized
+	}
+
+	var cmdbErr *comman_function.CmdbError
+	if errors.As(err, &cmdbErr) {
+		return http.StatusBadGateway
+	}
+
+	var logsErr *comman_function.LogsQueryError
+	if errors.As(err, &logsErr) {
+		if logsErr.Status == cloudwatchlogs.QueryStatusTimeout {
+			return http.StatusGatewayTimeout
+		}
+		return http.StatusBadGateway
+	}
+
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) {
+		switch {
+		case reqErr.StatusCode() == http.StatusUnauthorized || reqErr.StatusCode() == http.StatusForbidden:
+			return http.StatusForbidden
+		case reqErr.StatusCode() == http.StatusTooManyRequests || strings.Contains(reqErr.Code(), "Throttl"):
+			return http.StatusTooManyRequests
+		}
+		return http.StatusBadGateway
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+func writeJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("error writing response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	log.Printf("request failed with status %d: %v", status, err)
+	writeJson(w, status, errorResponse{Error: err.Error(), Type: comman_function.ErrorTypeOf(err)})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}